@@ -1,26 +1,28 @@
 package agents
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"net/http"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/NetSepio/nexus/api/v1/middleware"
-	caddy "github.com/NetSepio/nexus/api/v1/service"
 	"github.com/NetSepio/nexus/model"
+	"github.com/NetSepio/nexus/storage"
+	"github.com/NetSepio/nexus/util/pkg/policy"
 	"github.com/gin-gonic/gin"
 )
 
 // ApplyRoutes applies router to gin Router
 func ApplyRoutes(r *gin.RouterGroup) {
-	g := r.Group("/agents")
+	g := r.Group("/agents", middleware.Scope(policy.ScopeAgentsWrite))
 	{
 		g.POST("", addAgent)
 		g.GET("", getAgents)
@@ -30,71 +32,41 @@ func ApplyRoutes(r *gin.RouterGroup) {
 	}
 }
 
-var agentsFilePath string
-
 func init() {
-	// Initialize the agentsFilePath during package initialization
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		log.Fatalf("Error getting home directory: %v", err)
-	}
-	// Create the "erebrus" folder(SERVICE_CONF_DIR) inside the home directory if it doesn't exist
-	erebrusDir := filepath.Join(homeDir, "erebrus")
-	// err = os.MkdirAll(erebrusDir, os.ModePerm)
-	// if err != nil {
-	// 	log.Fatalf("Error creating erebrus directory: %v", err)
-	// }
-
-	// Set the path for agents.json inside the erebrus folder
-	agentsFilePath = filepath.Join(erebrusDir, "agents.json")
-
 	monitorAndRecoverAgents()
-
 }
 
-// Load agents from file
-func loadAgents() ([]model.Agent, error) {
-	file, err := os.Open(agentsFilePath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return []model.Agent{}, nil
-		}
-		return nil, err
-	}
-	defer file.Close()
+// agentsMu serializes read-modify-write cycles of the agents list
+var agentsMu sync.Mutex
 
-	var agents []model.Agent
-	if err := json.NewDecoder(file).Decode(&agents); err != nil {
-		return nil, err
-	}
-	return agents, nil
+// Load agents from store
+func loadAgents() ([]model.Agent, error) {
+	return storage.Get().ReadAgents()
 }
 
-// Save agents to file
-func saveAgents(newAgent model.Agent) error {
-	// Load existing agents
+// updateAgents applies fn to the stored agents and saves the result under agentsMu
+func updateAgents(fn func([]model.Agent) []model.Agent) error {
+	agentsMu.Lock()
+	defer agentsMu.Unlock()
+
 	agents, err := loadAgents()
 	if err != nil {
 		return err
 	}
 
-	agents = append(agents, newAgent)
-
-	file, err := os.Create(agentsFilePath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
+	return storage.Get().WriteAgents(fn(agents))
+}
 
-	// Encode the updated agents list into the file with indentation
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(agents)
+// Save agents to store
+func saveAgents(newAgent model.Agent) error {
+	return updateAgents(func(agents []model.Agent) []model.Agent {
+		return append(agents, newAgent)
+	})
 }
 
 // GET /agents
 func getAgents(c *gin.Context) {
-	agents, err := loadAgents()
+	agents, err := ListAgents()
 	if err != nil {
 		log.Printf("Error loading agents: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load agents"})
@@ -111,33 +83,30 @@ func getAgent(c *gin.Context) {
 		return
 	}
 
-	agents, err := loadAgents()
+	agent, err := FindAgent(agentID)
+	if errors.Is(err, ErrAgentNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Agent not found"})
+		return
+	}
 	if err != nil {
 		log.Printf("Error loading agents: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load agents"})
 		return
 	}
 
-	for _, agent := range agents {
-		if strings.EqualFold(agent.ID, agentID) {
-			c.JSON(http.StatusOK, gin.H{
-				"agent": gin.H{
-					"id":           agent.ID,
-					"name":         agent.Name,
-					"clients":      agent.Clients,
-					"domain":       agent.Domain,
-					"status":       agent.Status,
-					"avatar_img":   agent.AvatarImg,
-					"cover_img":    agent.CoverImg,
-					"voice_model":  agent.VoiceModel,
-					"organization": agent.Organization,
-				},
-			})
-			return
-		}
-	}
-
-	c.JSON(http.StatusNotFound, gin.H{"error": "Agent not found"})
+	c.JSON(http.StatusOK, gin.H{
+		"agent": gin.H{
+			"id":           agent.ID,
+			"name":         agent.Name,
+			"clients":      agent.Clients,
+			"domain":       agent.Domain,
+			"status":       agent.Status,
+			"avatar_img":   agent.AvatarImg,
+			"cover_img":    agent.CoverImg,
+			"voice_model":  agent.VoiceModel,
+			"organization": agent.Organization,
+		},
+	})
 }
 
 // Function to find an available port on the host machine
@@ -154,12 +123,6 @@ func getAvailablePort() (int, error) {
 func addAgent(c *gin.Context) {
 	log.Println("Received request to add an agent.")
 
-	// Get additional fields from form data
-	avatarImg := c.PostForm("avatar_img")
-	coverImg := c.PostForm("cover_img")
-	voiceModel := c.PostForm("voice_model")
-	organization := c.PostForm("organization")
-
 	// Retrieve the file from the request
 	file, err := c.FormFile("character_file")
 	if err != nil {
@@ -170,175 +133,38 @@ func addAgent(c *gin.Context) {
 
 	log.Printf("Uploaded file: %s", file.Filename)
 
-	// Read the saved file
 	content, err := file.Open()
 	if err != nil {
 		log.Printf("Error opening file: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read file"})
 		return
 	}
-
-	// Decode the JSON content
-	var character model.CharacterFile
-
-	if err := json.NewDecoder(content).Decode(&character); err != nil {
-		log.Printf("Invalid JSON format: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON file"})
-		return
-	}
-
-	// Ensure the "name" field is present
-	if character.Name == "" {
-		log.Printf("Missing 'name' field in JSON file")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "'name' field is required in the JSON file"})
-		return
-	}
-
-	agentName := character.Name
-
-	// Ensure the characters directory exists
-	if _, err := os.Stat("./characters"); os.IsNotExist(err) {
-		log.Println("Characters directory does not exist. Creating...")
-		if err := os.Mkdir("./characters", 0755); err != nil {
-			log.Printf("Error creating characters directory: %v", err)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create characters directory"})
-			return
-		}
-	}
-
-	characterFilePath := fmt.Sprintf("./characters/%s/%s", agentName, file.Filename)
-
-	// Save the file to the characters directory
-	log.Printf("Saving character file to %s", characterFilePath)
-	if err := c.SaveUploadedFile(file, characterFilePath); err != nil {
-		log.Printf("Error saving character file: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to save character file: %s", err.Error())})
-		return
-	}
-
-	// Ensure the Docker image is present
-	docker_url := c.DefaultPostForm("docker_url", "")
-	if docker_url == "" {
-		docker_url = os.Getenv("DOCKER_IMAGE_AGENT")
-	}
-	dockerImage := docker_url
-	log.Printf("Checking Docker image: %s", dockerImage)
-	pullCmd := exec.Command("docker", "pull", dockerImage)
-	if output, err := pullCmd.CombinedOutput(); err != nil {
-		log.Printf("Error pulling Docker image: %s", string(output))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to pull Docker image: %s", string(output))})
-		return
-	}
-
-	// Find an available port
-	exposedPort, err := getAvailablePort()
+	defer content.Close()
+	characterFile, err := io.ReadAll(content)
 	if err != nil {
-		log.Printf("Error finding available port: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to find an available port"})
-		return
-	}
-
-	// Run Docker container
-	log.Printf("Starting Docker container for agent: %s on port: %d", agentName, exposedPort)
-	dockerCmd := exec.Command(
-		"docker", "run", "-d",
-		"--name", agentName,
-		"-p", fmt.Sprintf("%d:3000", exposedPort),
-		"-v", fmt.Sprintf("%s:/app/characters", "./characters"),
-		dockerImage,
-		"pnpm", "start", fmt.Sprintf("--character=/app/characters/%s/%s", agentName, file.Filename),
-	)
-
-	output, err := dockerCmd.CombinedOutput()
-	if err != nil {
-		log.Printf("Error starting Docker container: %s", string(output))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to start Docker container: %s", string(output))})
+		log.Printf("Error reading file: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read file"})
 		return
 	}
 
-	log.Printf("Docker container started successfully: %s", string(output))
-
-	// Replace the time.Sleep with a polling mechanism
-	log.Printf("Waiting for agent container to become ready at http://localhost:%d/agents", exposedPort)
-	maxRetries := 60 // Maximum number of retries (60 attempts = 60 seconds with 1-second interval)
-	for i := 0; i < maxRetries; i++ {
-		agentEndpoint := fmt.Sprintf("http://localhost:%d/agents", exposedPort)
-		resp, err := http.Get(agentEndpoint)
-		if err != nil {
-			log.Printf("Attempt %d/%d: Container not ready yet: %v", i+1, maxRetries, err)
-			time.Sleep(time.Second)
-			continue
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode == http.StatusOK {
-			log.Printf("Container is ready after %d seconds", i+1)
-			break
-		}
-
-		log.Printf("Attempt %d/%d: Received status code %d, waiting...", i+1, maxRetries, resp.StatusCode)
-		time.Sleep(time.Second)
-	}
-
-	// Determine the domain
-	domain := c.DefaultPostForm("domain", "")
-	if domain == "" {
-		domain = os.Getenv("EREBRUS_DOMAIN")
-	}
-
-	// Call the AddServicesDirect function from the caddy package
-	log.Printf("Adding services for domain: %s", domain)
-	if err := caddy.AddServicesDirect(domain, agentName, exposedPort); err != nil {
-		log.Printf("Error adding services: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add services"})
+	createdAgent, err := CreateAgent(NewAgent{
+		CharacterFile: characterFile,
+		FileName:      file.Filename,
+		AvatarImg:     c.PostForm("avatar_img"),
+		CoverImg:      c.PostForm("cover_img"),
+		VoiceModel:    c.PostForm("voice_model"),
+		Organization:  c.PostForm("organization"),
+		DockerImage:   c.PostForm("docker_url"),
+		Domain:        c.PostForm("domain"),
+	})
+	if errors.Is(err, ErrInvalidCharacter) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-
-	// Allow the container to start and stabilize
-	agentEndpoint := fmt.Sprintf("http://localhost:%d/agents", exposedPort)
-	log.Println("Fetching agents from container at", agentEndpoint)
-	resp, err := http.Get(agentEndpoint)
 	if err != nil {
-		log.Printf("Error fetching agents: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch agents from container"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	defer resp.Body.Close()
-
-	var agentsResponse struct {
-		Agents []model.Agent `json:"agents"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&agentsResponse); err != nil {
-		log.Printf("Error parsing agents response: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to parse agents response"})
-		return
-	}
-
-	// Filter agents by the requested name
-	var createdAgent *model.Agent
-	for _, agent := range agentsResponse.Agents {
-		if strings.EqualFold(agent.Name, agentName) {
-			createdAgent = &agent
-			break
-		}
-	}
-
-	if createdAgent == nil {
-		log.Printf("Agent creation failed for: %s", agentName)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Agent creation failed"})
-		return
-	}
-	domain = agentName + "." + domain
-
-	createdAgent.Port = exposedPort
-	createdAgent.Domain = domain
-	createdAgent.Status = "active"
-	createdAgent.AvatarImg = avatarImg
-	createdAgent.CoverImg = coverImg
-	createdAgent.VoiceModel = voiceModel
-	createdAgent.Organization = organization
-	saveAgents(*createdAgent)
 
 	response := model.AgentResponse{
 		ID:           createdAgent.ID,
@@ -350,9 +176,7 @@ func addAgent(c *gin.Context) {
 		VoiceModel:   createdAgent.VoiceModel,
 		Organization: createdAgent.Organization,
 	}
-
-	log.Printf("Agent created successfully: %+v", response)
-	c.JSON(http.StatusOK, gin.H{"agent": response, "domain": domain})
+	c.JSON(http.StatusOK, gin.H{"agent": response, "domain": createdAgent.Domain})
 }
 
 // DELETE /agents/:agentId
@@ -363,77 +187,20 @@ func deleteAgent(c *gin.Context) {
 		return
 	}
 
-	// Load existing agents
-	agents, err := loadAgents()
-	if err != nil {
-		log.Printf("Error loading agents: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load agents"})
-		return
-	}
-
-	// Find the agent by ID and remove it
-	var indexToDelete int = -1
-	for i, agent := range agents {
-		if strings.EqualFold(agent.ID, agentID) {
-			indexToDelete = i
-			break
-		}
-	}
-
-	// Stop and remove the Docker container for the deleted agent
-	dockerCmd := exec.Command("docker", "stop", agents[indexToDelete].Name)
-	if output, err := dockerCmd.CombinedOutput(); err != nil {
-		log.Printf("Error stopping Docker container: %s", string(output))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to stop Docker container: %s", string(output))})
-		return
-	}
-
-	dockerRemoveCmd := exec.Command("docker", "rm", agents[indexToDelete].Name)
-	if output, err := dockerRemoveCmd.CombinedOutput(); err != nil {
-		log.Printf("Error removing Docker container: %s", string(output))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to remove Docker container: %s", string(output))})
-		return
-	}
-
-	// If the agent is not found, return an error
-	if indexToDelete == -1 {
+	err := DeleteAgent(agentID)
+	if errors.Is(err, ErrAgentNotFound) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Agent not found"})
 		return
 	}
-
-	//to delete from caddyfile and caddy.json
-	middleware.DeleteService(agents[indexToDelete].Name)
-
-	// Remove the agent from the list
-	agents = append(agents[:indexToDelete], agents[indexToDelete+1:]...)
-
-	// Save the updated list of agents
-	if err := saveAgentsList(agents); err != nil {
-		log.Printf("Error saving agents: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save agents"})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	// Respond with success
-	log.Printf("Agent %s deleted successfully", agentID)
 	c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("Agent %s deleted successfully", agentID)})
 }
 
-// Save the list of agents back to the file after deletion
-func saveAgentsList(agents []model.Agent) error {
-	// Open or create the file to save the agents list
-	file, err := os.Create(agentsFilePath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	// Encode the updated agents list into the file with indentation
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(agents)
-}
-
 func manageAgent(c *gin.Context) {
 	agentID := c.Param("agentId")
 	if agentID == "" {
@@ -442,79 +209,23 @@ func manageAgent(c *gin.Context) {
 	}
 
 	action := c.Query("action")
-	if action != "pause" && action != "resume" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid action. Use 'pause' or 'resume'"})
-		return
-	}
-
-	// Load existing agents
-	agents, err := loadAgents()
-	if err != nil {
-		log.Printf("Error loading agents: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load agents"})
+	_, err := ManageAgent(agentID, action)
+	switch {
+	case errors.Is(err, ErrInvalidAction):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
-	}
-
-	var dockerAction string
-	if action == "pause" {
-		dockerAction = "pause"
-	} else {
-		dockerAction = "unpause"
-	}
-
-	// Find the agent by ID and remove it
-	var agentIndex int = -1
-	for i, agent := range agents {
-		if strings.EqualFold(agent.ID, agentID) {
-			agentIndex = i
-			if dockerAction == "pause" {
-				agents[i].Status = "inactive"
-			} else {
-				agents[i].Status = "active"
-			}
-			break
-		}
-	}
-
-	// If the agent is not found, return an error
-	if agentIndex == -1 {
+	case errors.Is(err, ErrAgentNotFound):
 		c.JSON(http.StatusNotFound, gin.H{"error": "Agent not found"})
 		return
-	}
-
-	// Write the updated data back to the file
-	updatedData, err := json.MarshalIndent(agents, "", "  ")
-	if err != nil {
-		log.Printf("Error marshalling updated JSON: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update agents data"})
-		return
-	}
-
-	file, err := os.Create(agentsFilePath)
-	if err != nil {
-		log.Printf("Error creating agents.json: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save updated agents"})
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	defer file.Close()
-
-	if _, err := file.Write(updatedData); err != nil {
-		log.Printf("Error writing to agents.json: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to write agents data"})
-		return
-	}
-
-	// Execute the pause or resume action
-	actionCmd := exec.Command("docker", dockerAction, agents[agentIndex].Name)
-	actionOutput, err := actionCmd.CombinedOutput()
-	if err != nil {
-		log.Printf("Error performing action '%s' on Agent: %s, Output: %s", dockerAction, err, string(actionOutput))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to %s Agent: %s", dockerAction, string(actionOutput))})
-		return
+	dockerAction := action
+	if action == "resume" {
+		dockerAction = "unpause"
 	}
-
-	log.Printf("Successfully performed action '%s' on Agent '%s'", dockerAction, agentID)
 	c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("Agent '%s' %sed successfully", agentID, dockerAction)})
 }
 
@@ -570,6 +281,7 @@ func monitorAndRecoverAgents() {
 						}
 					}
 
+					publishAgentState(&agent)
 					log.Printf("Agent %s is restored", agent.Name)
 				}
 
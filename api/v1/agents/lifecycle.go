@@ -0,0 +1,307 @@
+package agents
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/NetSepio/nexus/api/v1/middleware"
+	caddy "github.com/NetSepio/nexus/api/v1/service"
+	"github.com/NetSepio/nexus/core"
+	"github.com/NetSepio/nexus/model"
+)
+
+var (
+	// ErrAgentNotFound is returned for unknown agent ids
+	ErrAgentNotFound = errors.New("Agent not found")
+	// ErrInvalidAction is returned by ManageAgent for actions other than pause and resume
+	ErrInvalidAction = errors.New("Invalid action. Use 'pause' or 'resume'")
+	// ErrInvalidCharacter is returned for character files that are not JSON or have no name
+	ErrInvalidCharacter = errors.New("Invalid JSON file")
+)
+
+// NewAgent is what an agent is created from, empty DockerImage and Domain default to
+// DOCKER_IMAGE_AGENT and EREBRUS_DOMAIN
+type NewAgent struct {
+	CharacterFile []byte
+	FileName      string
+	AvatarImg     string
+	CoverImg      string
+	VoiceModel    string
+	Organization  string
+	DockerImage   string
+	Domain        string
+}
+
+// publishAgentState publishes the status of agent on the node event bus
+func publishAgentState(agent *model.Agent) {
+	core.Publish(core.Event{Type: core.EventAgentState, Data: map[string]string{
+		"id":     agent.ID,
+		"name":   agent.Name,
+		"status": agent.Status,
+	}})
+}
+
+// ListAgents returns the stored agents
+func ListAgents() ([]model.Agent, error) {
+	return loadAgents()
+}
+
+// FindAgent returns the agent of id
+func FindAgent(id string) (*model.Agent, error) {
+	agents, err := loadAgents()
+	if err != nil {
+		return nil, err
+	}
+	for _, agent := range agents {
+		if strings.EqualFold(agent.ID, id) {
+			return &agent, nil
+		}
+	}
+	return nil, ErrAgentNotFound
+}
+
+// CreateAgent runs the container of a character, exposes it through caddy and stores the agent
+func CreateAgent(req NewAgent) (*model.Agent, error) {
+	var character model.CharacterFile
+	if err := json.NewDecoder(bytes.NewReader(req.CharacterFile)).Decode(&character); err != nil {
+		log.Printf("Invalid JSON format: %v", err)
+		return nil, ErrInvalidCharacter
+	}
+	// Ensure the "name" field is present
+	if character.Name == "" {
+		log.Printf("Missing 'name' field in JSON file")
+		return nil, fmt.Errorf("%w: 'name' field is required in the JSON file", ErrInvalidCharacter)
+	}
+
+	agentName := character.Name
+	fileName := filepath.Base(req.FileName)
+	characterFilePath := fmt.Sprintf("./characters/%s/%s", agentName, fileName)
+
+	// Save the file to the characters directory
+	log.Printf("Saving character file to %s", characterFilePath)
+	if err := os.MkdirAll(filepath.Dir(characterFilePath), 0755); err != nil {
+		return nil, fmt.Errorf("Failed to create characters directory: %s", err.Error())
+	}
+	if err := os.WriteFile(characterFilePath, req.CharacterFile, 0644); err != nil {
+		return nil, fmt.Errorf("Failed to save character file: %s", err.Error())
+	}
+
+	// Ensure the Docker image is present
+	dockerImage := req.DockerImage
+	if dockerImage == "" {
+		dockerImage = os.Getenv("DOCKER_IMAGE_AGENT")
+	}
+	log.Printf("Checking Docker image: %s", dockerImage)
+	pullCmd := exec.Command("docker", "pull", dockerImage)
+	if output, err := pullCmd.CombinedOutput(); err != nil {
+		log.Printf("Error pulling Docker image: %s", string(output))
+		return nil, fmt.Errorf("Failed to pull Docker image: %s", string(output))
+	}
+
+	// Find an available port
+	exposedPort, err := getAvailablePort()
+	if err != nil {
+		log.Printf("Error finding available port: %v", err)
+		return nil, errors.New("Failed to find an available port")
+	}
+
+	// Run Docker container
+	log.Printf("Starting Docker container for agent: %s on port: %d", agentName, exposedPort)
+	dockerCmd := exec.Command(
+		"docker", "run", "-d",
+		"--name", agentName,
+		"-p", fmt.Sprintf("%d:3000", exposedPort),
+		"-v", fmt.Sprintf("%s:/app/characters", "./characters"),
+		dockerImage,
+		"pnpm", "start", fmt.Sprintf("--character=/app/characters/%s/%s", agentName, fileName),
+	)
+
+	output, err := dockerCmd.CombinedOutput()
+	if err != nil {
+		log.Printf("Error starting Docker container: %s", string(output))
+		return nil, fmt.Errorf("Failed to start Docker container: %s", string(output))
+	}
+
+	log.Printf("Docker container started successfully: %s", string(output))
+
+	agentEndpoint := fmt.Sprintf("http://localhost:%d/agents", exposedPort)
+	waitForAgent(agentEndpoint)
+
+	// Determine the domain
+	domain := req.Domain
+	if domain == "" {
+		domain = os.Getenv("EREBRUS_DOMAIN")
+	}
+
+	log.Printf("Adding services for domain: %s", domain)
+	if err := caddy.AddServicesDirect(domain, agentName, exposedPort); err != nil {
+		log.Printf("Error adding services: %v", err)
+		return nil, errors.New("Failed to add services")
+	}
+
+	log.Println("Fetching agents from container at", agentEndpoint)
+	resp, err := http.Get(agentEndpoint)
+	if err != nil {
+		log.Printf("Error fetching agents: %v", err)
+		return nil, errors.New("Failed to fetch agents from container")
+	}
+	defer resp.Body.Close()
+
+	var agentsResponse struct {
+		Agents []model.Agent `json:"agents"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&agentsResponse); err != nil {
+		log.Printf("Error parsing agents response: %v", err)
+		return nil, errors.New("Failed to parse agents response")
+	}
+
+	// Filter agents by the requested name
+	var createdAgent *model.Agent
+	for _, agent := range agentsResponse.Agents {
+		if strings.EqualFold(agent.Name, agentName) {
+			createdAgent = &agent
+			break
+		}
+	}
+	if createdAgent == nil {
+		log.Printf("Agent creation failed for: %s", agentName)
+		return nil, errors.New("Agent creation failed")
+	}
+
+	createdAgent.Port = exposedPort
+	createdAgent.Domain = agentName + "." + domain
+	createdAgent.Status = "active"
+	createdAgent.AvatarImg = req.AvatarImg
+	createdAgent.CoverImg = req.CoverImg
+	createdAgent.VoiceModel = req.VoiceModel
+	createdAgent.Organization = req.Organization
+	if err := saveAgents(*createdAgent); err != nil {
+		log.Printf("Error saving agents: %v", err)
+		return nil, errors.New("Failed to save agents")
+	}
+
+	publishAgentState(createdAgent)
+	log.Printf("Agent created successfully: %+v", *createdAgent)
+	return createdAgent, nil
+}
+
+// waitForAgent polls the agents endpoint of a new container for up to a minute
+func waitForAgent(agentEndpoint string) {
+	log.Printf("Waiting for agent container to become ready at %s", agentEndpoint)
+	maxRetries := 60 // Maximum number of retries (60 attempts = 60 seconds with 1-second interval)
+	for i := 0; i < maxRetries; i++ {
+		resp, err := http.Get(agentEndpoint)
+		if err != nil {
+			log.Printf("Attempt %d/%d: Container not ready yet: %v", i+1, maxRetries, err)
+			time.Sleep(time.Second)
+			continue
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode == http.StatusOK {
+			log.Printf("Container is ready after %d seconds", i+1)
+			return
+		}
+
+		log.Printf("Attempt %d/%d: Received status code %d, waiting...", i+1, maxRetries, resp.StatusCode)
+		time.Sleep(time.Second)
+	}
+}
+
+// DeleteAgent removes the container, the caddy service and the stored agent of id
+func DeleteAgent(id string) error {
+	agent, err := FindAgent(id)
+	if err != nil {
+		return err
+	}
+
+	// Stop and remove the Docker container for the deleted agent
+	dockerCmd := exec.Command("docker", "stop", agent.Name)
+	if output, err := dockerCmd.CombinedOutput(); err != nil {
+		log.Printf("Error stopping Docker container: %s", string(output))
+		return fmt.Errorf("Failed to stop Docker container: %s", string(output))
+	}
+
+	dockerRemoveCmd := exec.Command("docker", "rm", agent.Name)
+	if output, err := dockerRemoveCmd.CombinedOutput(); err != nil {
+		log.Printf("Error removing Docker container: %s", string(output))
+		return fmt.Errorf("Failed to remove Docker container: %s", string(output))
+	}
+
+	//to delete from caddyfile and caddy.json
+	middleware.DeleteService(agent.Name)
+
+	// Remove the agent from the list
+	err = updateAgents(func(agents []model.Agent) []model.Agent {
+		updated := agents[:0]
+		for _, agent := range agents {
+			if !strings.EqualFold(agent.ID, id) {
+				updated = append(updated, agent)
+			}
+		}
+		return updated
+	})
+	if err != nil {
+		log.Printf("Error saving agents: %v", err)
+		return errors.New("Failed to save agents")
+	}
+
+	agent.Status = "deleted"
+	publishAgentState(agent)
+	log.Printf("Agent %s deleted successfully", id)
+	return nil
+}
+
+// ManageAgent pauses or resumes the container of id and stores the new status
+func ManageAgent(id string, action string) (*model.Agent, error) {
+	var dockerAction, status string
+	switch action {
+	case "pause":
+		dockerAction, status = "pause", "inactive"
+	case "resume":
+		dockerAction, status = "unpause", "active"
+	default:
+		return nil, ErrInvalidAction
+	}
+
+	agent, err := FindAgent(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Write the updated status back to the store
+	err = updateAgents(func(agents []model.Agent) []model.Agent {
+		for i := range agents {
+			if strings.EqualFold(agents[i].ID, id) {
+				agents[i].Status = status
+			}
+		}
+		return agents
+	})
+	if err != nil {
+		log.Printf("Error saving agents: %v", err)
+		return nil, errors.New("Failed to save updated agents")
+	}
+	agent.Status = status
+
+	// Execute the pause or resume action
+	actionCmd := exec.Command("docker", dockerAction, agent.Name)
+	actionOutput, err := actionCmd.CombinedOutput()
+	if err != nil {
+		log.Printf("Error performing action '%s' on Agent: %s, Output: %s", dockerAction, err, string(actionOutput))
+		return nil, fmt.Errorf("Failed to %s Agent: %s", dockerAction, string(actionOutput))
+	}
+
+	publishAgentState(agent)
+	log.Printf("Successfully performed action '%s' on Agent '%s'", dockerAction, id)
+	return agent, nil
+}
@@ -1,7 +1,7 @@
 package authenticate
 
 import (
-	"fmt"
+	"errors"
 	"net/http"
 	"os"
 
@@ -19,6 +19,8 @@ func ApplyRoutes(r *gin.RouterGroup) {
 	{
 		g.GET("", challengeid.GetChallengeId)
 		g.POST("", authenticate)
+		g.GET("/keys", keys)
+		challengeid.ApplyStoreRoutes(g)
 
 	}
 }
@@ -36,105 +38,50 @@ func authenticate(c *gin.Context) {
 		c.JSON(http.StatusForbidden, errResponse)
 		return
 	}
-	userAuthEULA := os.Getenv("AUTH_EULA")
-	message := userAuthEULA + req.ChallengeId
-
-	var (
-		isCorrect     bool
-		walletAddress string
-	)
-
-	switch req.ChainName {
-	case "ethereum", "peaq":
-		userAuthEULA := userAuthEULA
-		message := userAuthEULA + req.ChallengeId
-		walletAddress, isCorrect, err = CheckSignEthereum(req.Signature, req.ChallengeId, message)
-
-		if err == ErrChallengeIdNotFound {
-
-			log.WithFields(log.Fields{"err": err}).Errorf("Challenge Id not found")
-
-			c.JSON(http.StatusNotFound, ErrAuthenticate("Challenge Id not found"))
-
-			return
-		}
-
-		if err != nil {
-			fmt.Println("error", err)
-
-			log.WithFields(log.Fields{"err": err}).Errorf("failed to CheckSignature, error %v", err.Error())
-
-			c.JSON(http.StatusNotFound, ErrAuthenticate("failed to CheckSignature, error :"+err.Error()))
-			return
-		}
-
-	case "aptos":
-		userAuthEULA := userAuthEULA
-		message := fmt.Sprintf("APTOS\nmessage: %v\nnonce: %v", userAuthEULA, req.ChallengeId)
-		walletAddress, isCorrect, err = CheckSignAptos(req.Signature, req.ChallengeId, message, req.PubKey)
-
-		if err == ErrChallengeIdNotFound {
-			log.WithFields(log.Fields{"err": err}).Errorf("Challenge Id not found")
-
-			c.JSON(http.StatusNotFound, ErrAuthenticate("Challenge Id not found"))
-
-			return
-		}
-
-		if err != nil {
-
-			log.WithFields(log.Fields{"err": err}).Errorf("failed to CheckSignature, error %v", err.Error())
-
-			c.JSON(http.StatusNotFound, ErrAuthenticate("failed to CheckSignature, error :"+err.Error()))
-			return
-		}
-
-	case "sui":
-		walletAddress, isCorrect, err = CheckSignSui(req.Signature, req.ChallengeId)
-
-		if err == ErrChallengeIdNotFound {
-
-			log.WithFields(log.Fields{"err": err}).Errorf("Challenge Id not found")
-
-			c.JSON(http.StatusNotFound, ErrAuthenticate("Challenge Id not found"))
-			return
-		}
-
-		if err != nil {
-			log.WithFields(log.Fields{"err": err}).Errorf("failed to CheckSignature, error %v", err.Error())
-
-			c.JSON(http.StatusNotFound, ErrAuthenticate("failed to CheckSignature, error : "+err.Error()))
-			return
-		}
-
-	case "solana":
-		walletAddress, isCorrect, err = CheckSignSolana(req.Signature, req.ChallengeId, message, req.PubKey)
-
-		if err == ErrChallengeIdNotFound {
-			log.WithFields(log.Fields{"err": err}).Errorf("Challenge Id not found")
-			c.JSON(http.StatusNotFound, ErrAuthenticate("Challenge Id not found"))
-			return
-		}
-
-		if err != nil {
-			log.WithFields(log.Fields{
-				"err": err,
-			}).Errorf("failed to CheckSignature, error : %v", err.Error())
-			errResponse := ErrAuthenticate("failed to CheckSignature, error :" + err.Error())
-			c.JSON(http.StatusInternalServerError, errResponse)
-			return
-
-		}
+	// the challenge is consumed before the signature is checked, it can only be tried once
+	challenge, err := challengeid.Consume(req.ChallengeId)
+	if errors.Is(err, challengeid.ErrChallengeNotFound) {
+		log.WithFields(log.Fields{"err": err}).Errorf("Challenge Id not found")
+		c.JSON(http.StatusNotFound, ErrAuthenticate("Challenge Id not found"))
+		return
+	}
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("failed to consume challenge")
+		c.JSON(http.StatusInternalServerError, ErrAuthenticate(err.Error()))
+		return
+	}
+	if challenge.ChainName != req.ChainName {
+		c.JSON(http.StatusForbidden, ErrAuthenticate("challenge was issued for chain "+challenge.ChainName))
+		return
+	}
 
-	default:
-		info := "chain name must be between solana, peaq, aptos, sui, eclipse, ethereum"
+	verifier, ok := VerifierFor(req.ChainName)
+	if !ok {
+		info := "chain name must be between solana, peaq, aptos, sui, eclipse, ethereum, monad"
 		log.WithFields(log.Fields{
-			"err": err,
+			"chainName": req.ChainName,
 		}).Errorf("Invalid chain name, INFO : %s\n", info)
 		errResponse := ErrAuthenticate("failed to CheckSignature, error :" + "Invalid chain name, INFO : " + info)
-		c.JSON(http.StatusInternalServerError, errResponse)
+		c.JSON(http.StatusBadRequest, errResponse)
 		return
 	}
+
+	walletAddress := challenge.WalletAddress
+	err = verifier.Verify(Login{
+		WalletAddress: walletAddress,
+		ChallengeId:   req.ChallengeId,
+		Eula:          os.Getenv("AUTH_EULA"),
+		Message:       req.Message,
+		Signature:     req.Signature,
+		PubKey:        req.PubKey,
+	})
+	isCorrect := err == nil
+	if err != nil && !errors.Is(err, ErrSignatureMismatch) && !errors.Is(err, ErrMessageMismatch) {
+		log.WithFields(log.Fields{"err": err}).Errorf("failed to CheckSignature, error %v", err.Error())
+		c.JSON(http.StatusBadRequest, ErrAuthenticate("failed to CheckSignature, error :"+err.Error()))
+		return
+	}
+
 	if isCorrect {
 		customClaims := claims.New(walletAddress)
 		pasetoToken, err := auth.GenerateTokenPaseto(customClaims)
@@ -146,7 +93,6 @@ func authenticate(c *gin.Context) {
 			c.JSON(http.StatusInternalServerError, errResponse)
 			return
 		}
-		delete(challengeid.Data, req.ChallengeId)
 		payload := AuthenticatePayload{
 			Status:  200,
 			Success: true,
@@ -155,12 +101,27 @@ func authenticate(c *gin.Context) {
 		}
 		c.JSON(http.StatusAccepted, payload)
 	} else {
+		log.WithFields(log.Fields{
+			"err":           err,
+			"walletAddress": walletAddress,
+		}).Warn("login signature rejected")
 		errResponse := ErrAuthenticate("Forbidden")
 		c.JSON(http.StatusForbidden, errResponse)
 		return
 	}
 }
 
+// keys publishes the public keys tokens accepted by the node are signed with
+func keys(c *gin.Context) {
+	payload := KeysPayload{
+		Status:  200,
+		Success: true,
+		Message: "paseto public keys",
+		Keys:    auth.Keys(),
+	}
+	c.JSON(http.StatusOK, payload)
+}
+
 func ErrAuthenticate(errvalue string) AuthenticatePayload {
 	var payload AuthenticatePayload
 	payload.Success = false
@@ -2,6 +2,7 @@ package challengeid
 
 import (
 	"encoding/hex"
+	"errors"
 	"math/big"
 	"net/http"
 	"os"
@@ -9,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/NetSepio/nexus/api/v1/authenticate/siwe"
 	"github.com/NetSepio/nexus/core"
 
 	"github.com/gin-gonic/gin"
@@ -20,14 +22,16 @@ type FlowId struct {
 	WalletAddress string
 	FlowId        string `gorm:"primary_key"`
 }
+
+// MemoryDB is a challenge issued to a wallet
 type MemoryDB struct {
-	WalletAddress string
-	ChainName     string
-	Timestamp     time.Time
+	WalletAddress string    `json:"walletAddress" binding:"required"`
+	ChainName     string    `json:"chainName" binding:"required"`
+	Timestamp     time.Time `json:"timestamp" binding:"required"`
+	// ClientIP is the address the challenge was requested from, challenges are rate limited per client
+	ClientIP string `json:"clientIp,omitempty"`
 }
 
-var Data map[string]MemoryDB
-
 // Get walletAddress, chain and return eula, challengeId
 func GetChallengeId(c *gin.Context) {
 	walletAddress := c.Query("walletAddress")
@@ -55,7 +59,7 @@ func GetChallengeId(c *gin.Context) {
 
 	if err := ValidateAddress(chainName, walletAddress); err != nil {
 
-		info := "chain name = " + chainName + "; please pass chain name between solana, peaq, aptos, sui, eclipse, ethereum"
+		info := "chain name = " + chainName + "; please pass chain name between solana, peaq, aptos, sui, eclipse, ethereum, monad"
 
 		switch err {
 		case ErrInvalidChain:
@@ -72,7 +76,17 @@ func GetChallengeId(c *gin.Context) {
 		return
 	}
 
-	challengeId, err := GenerateChallengeId(walletAddress, chainName)
+	challengeId, err := GenerateChallengeId(walletAddress, chainName, c.ClientIP())
+	if errors.Is(err, ErrRateLimited) {
+		log.WithFields(log.Fields{
+			"err":           err,
+			"walletAddress": walletAddress,
+			"clientIp":      c.ClientIP(),
+		}).Warn("challenge rate limit reached")
+		response := core.MakeErrorResponse(429, err.Error(), nil, nil, nil)
+		c.JSON(http.StatusTooManyRequests, response)
+		return
+	}
 	if err != nil {
 		log.WithFields(log.Fields{
 			"err": err,
@@ -86,17 +100,49 @@ func GetChallengeId(c *gin.Context) {
 		ChallengeId: challengeId,
 		Eula:        userAuthEULA,
 	}
+	if IsEVMChain(chainName) {
+		payload.Message = siweMessage(walletAddress, userAuthEULA, challengeId)
+	}
 	c.JSON(200, payload)
 }
 
-func GenerateChallengeId(walletAddress string, chainName string) (string, error) {
+// IsEVMChain reports whether chain wallets sign with ethereum accounts
+func IsEVMChain(chain string) bool {
+	switch chain {
+	case "ethereum", "peaq", "monad":
+		return true
+	}
+	return false
+}
+
+// siweMessage returns the EIP-4361 message of a challenge, empty when sign-in with ethereum is not
+// configured and wallets sign the AUTH_EULA followed by the challenge id
+func siweMessage(walletAddress string, eula string, challengeId string) string {
+	// substrate accounts of peaq do not sign in with ethereum
+	if !ValidateAddressEtherium(walletAddress) {
+		return ""
+	}
+	cfg, err := siwe.ConfigFromEnv()
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Warn("sign-in with ethereum is not configured")
+		return ""
+	}
+	message := cfg.NewMessage(walletAddress, strings.TrimSpace(eula), siwe.Nonce(challengeId), time.Now(), TTL())
+	return message.String()
+}
+
+// GenerateChallengeId issues a single-use challenge for the wallet to the client at clientIP
+func GenerateChallengeId(walletAddress string, chainName string, clientIP string) (string, error) {
 	challengeId := uuid.NewString()
 	var dbdata MemoryDB
 	dbdata.WalletAddress = walletAddress
 	dbdata.Timestamp = time.Now()
 	dbdata.ChainName = chainName
-	Data = map[string]MemoryDB{
-		challengeId: dbdata,
+	dbdata.ClientIP = clientIP
+	if err := Get().Put(challengeId, dbdata); err != nil {
+		return "", err
 	}
 	return challengeId, nil
 }
@@ -106,7 +152,7 @@ func ValidateAddress(chain, address string) error {
 	// Convert chain name to lowercase for case-insensitive comparison
 
 	switch chain {
-	case "ethereum":
+	case "ethereum", "monad":
 		if !ValidateAddressEtherium(address) {
 			return ErrInvalidAddress
 		}
@@ -116,7 +162,8 @@ func ValidateAddress(chain, address string) error {
 		}
 
 	case "peaq":
-		if !ValidatePeaqAddress(address) {
+		// peaq accounts are substrate or EVM accounts
+		if !ValidatePeaqAddress(address) && !ValidateAddressEtherium(address) {
 			return ErrInvalidAddress
 		}
 
@@ -173,7 +220,8 @@ func ValidateAptosAddress(address string) bool {
 
 // ValidateSuiAddress checks if the given string is a valid Sui wallet address
 func ValidateSuiAddress(address string) bool {
-	if len(address) != 42 || !strings.HasPrefix(address, "0x") {
+	// sui addresses are the 32 bytes blake2b-256 of the flagged public key
+	if len(address) != 66 || !strings.HasPrefix(address, "0x") {
 		return false
 	}
 
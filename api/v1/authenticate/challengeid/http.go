@@ -0,0 +1,167 @@
+package challengeid
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/NetSepio/nexus/core"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// HTTPStore keeps the challenges in the store of another node, or of the gateway, serving the
+// challenge store routes so every node behind it verifies the same challenges
+type HTTPStore struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// NewHTTPStore returns a store backed by the challenge store routes under baseURL, token is sent
+// as a bearer token
+func NewHTTPStore(baseURL string, token string) (*HTTPStore, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("CHALLENGE_STORE_URL must be an http or https url, got %q", baseURL)
+	}
+	if token == "" {
+		return nil, errors.New("CHALLENGE_STORE_TOKEN is required by the http challenge store")
+	}
+	return &HTTPStore{
+		url:    strings.TrimSuffix(baseURL, "/"),
+		token:  token,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+func (s *HTTPStore) do(method string, path string, body any) (*http.Response, error) {
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequest(method, s.url+path, &payload)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.token)
+
+	return s.client.Do(req)
+}
+
+// Put saves a new challenge in the shared store
+func (s *HTTPStore) Put(id string, challenge MemoryDB) error {
+	resp, err := s.do(http.MethodPost, "/challenges/"+url.PathEscape(id), challenge)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		return nil
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	default:
+		return fmt.Errorf("challenge store answered %s", resp.Status)
+	}
+}
+
+// Consume removes and returns a challenge from the shared store
+func (s *HTTPStore) Consume(id string) (MemoryDB, error) {
+	resp, err := s.do(http.MethodPost, "/challenges/"+url.PathEscape(id)+"/consume", nil)
+	if err != nil {
+		return MemoryDB{}, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var challenge MemoryDB
+		err := json.NewDecoder(resp.Body).Decode(&challenge)
+		return challenge, err
+	case http.StatusNotFound:
+		return MemoryDB{}, ErrChallengeNotFound
+	default:
+		return MemoryDB{}, fmt.Errorf("challenge store answered %s", resp.Status)
+	}
+}
+
+// ApplyStoreRoutes serves the challenge store of the node to the other nodes when
+// CHALLENGE_STORE_TOKEN is set, the routes are not registered otherwise
+func ApplyStoreRoutes(r *gin.RouterGroup) {
+	if os.Getenv("CHALLENGE_STORE_TOKEN") == "" || strings.ToLower(os.Getenv("CHALLENGE_STORE")) == BackendHTTP {
+		return
+	}
+
+	g := r.Group("/challenges", storeAuth)
+	{
+		g.POST("/:id", putChallenge)
+		g.POST("/:id/consume", consumeChallenge)
+	}
+}
+
+// storeAuth only lets the nodes holding CHALLENGE_STORE_TOKEN in
+func storeAuth(c *gin.Context) {
+	token, _ := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	expected := os.Getenv("CHALLENGE_STORE_TOKEN")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(expected)) != 1 {
+		response := core.MakeErrorResponse(401, "invalid challenge store token", nil, nil, nil)
+		c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+		return
+	}
+	c.Next()
+}
+
+func putChallenge(c *gin.Context) {
+	var challenge MemoryDB
+	if err := c.ShouldBindJSON(&challenge); err != nil {
+		response := core.MakeErrorResponse(400, err.Error(), nil, nil, nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	err := Get().Put(c.Param("id"), challenge)
+	if errors.Is(err, ErrRateLimited) {
+		response := core.MakeErrorResponse(429, err.Error(), nil, nil, nil)
+		c.JSON(http.StatusTooManyRequests, response)
+		return
+	}
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Error("failed to store challenge")
+		response := core.MakeErrorResponse(500, err.Error(), nil, nil, nil)
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.Status(http.StatusCreated)
+}
+
+func consumeChallenge(c *gin.Context) {
+	challenge, err := Consume(c.Param("id"))
+	if errors.Is(err, ErrChallengeNotFound) {
+		response := core.MakeErrorResponse(404, err.Error(), nil, nil, nil)
+		c.JSON(http.StatusNotFound, response)
+		return
+	}
+	if err != nil {
+		response := core.MakeErrorResponse(500, err.Error(), nil, nil, nil)
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, challenge)
+}
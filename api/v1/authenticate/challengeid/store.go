@@ -0,0 +1,226 @@
+package challengeid
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrChallengeNotFound is returned for unknown, expired or already consumed challenges
+	ErrChallengeNotFound = errors.New("challenge id not found")
+	// ErrRateLimited is returned when a client requested too many challenges
+	ErrRateLimited = errors.New("too many challenges requested, try again later")
+)
+
+// Store keeps the issued challenges until they are consumed or expire
+type Store interface {
+	// Put saves a new challenge, ErrRateLimited when its client requested too many
+	Put(id string, challenge MemoryDB) error
+	// Consume removes and returns a challenge, a challenge is only ever returned once
+	Consume(id string) (MemoryDB, error)
+}
+
+// Backends of the challenge store
+const (
+	// BackendMemory keeps the challenges in the node
+	BackendMemory = "memory"
+	// BackendHTTP shares the challenges of several nodes through CHALLENGE_STORE_URL
+	BackendHTTP = "http"
+)
+
+const (
+	defaultTTL       = time.Hour
+	defaultRateLimit = 10
+	defaultWindow    = time.Minute
+)
+
+var (
+	storeMu sync.RWMutex
+	store   Store = NewMemoryStore(defaultTTL, defaultRateLimit, defaultWindow)
+	ttl           = defaultTTL
+)
+
+// Init selects the challenge store from CHALLENGE_STORE, defaults to the memory store
+func Init() error {
+	challengeTTL := durationEnv("CHALLENGE_TTL", defaultTTL)
+	window := durationEnv("CHALLENGE_RATE_WINDOW", defaultWindow)
+	limit := defaultRateLimit
+	if value := os.Getenv("CHALLENGE_RATE_LIMIT"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			log.WithFields(log.Fields{
+				"err":   err,
+				"value": value,
+			}).Warn("invalid CHALLENGE_RATE_LIMIT, using the default")
+		} else {
+			limit = n
+		}
+	}
+
+	var s Store
+	switch strings.ToLower(os.Getenv("CHALLENGE_STORE")) {
+	case BackendHTTP:
+		shared, err := NewHTTPStore(os.Getenv("CHALLENGE_STORE_URL"), os.Getenv("CHALLENGE_STORE_TOKEN"))
+		if err != nil {
+			return err
+		}
+		s = shared
+	default:
+		memory := NewMemoryStore(challengeTTL, limit, window)
+		memory.StartJanitor(time.Minute)
+		s = memory
+	}
+
+	storeMu.Lock()
+	defer storeMu.Unlock()
+	store = s
+	ttl = challengeTTL
+	return nil
+}
+
+// Get returns the challenge store
+func Get() Store {
+	storeMu.RLock()
+	defer storeMu.RUnlock()
+	return store
+}
+
+// TTL returns how long challenges can be answered
+func TTL() time.Duration {
+	storeMu.RLock()
+	defer storeMu.RUnlock()
+	return ttl
+}
+
+// Consume removes and returns a challenge from the store
+func Consume(id string) (MemoryDB, error) {
+	return Get().Consume(id)
+}
+
+func durationEnv(name string, fallback time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.WithFields(log.Fields{
+			"err":   err,
+			"value": value,
+		}).Warnf("invalid %s, using the default", name)
+		return fallback
+	}
+	return d
+}
+
+// rateKey returns who the rate limit of a challenge is kept for: the client that requested it,
+// so nobody can use up the challenges of another wallet. Challenges stored by nodes that do not
+// record the client fall back to the wallet, hex addresses are case insensitive.
+func rateKey(challenge MemoryDB) string {
+	if challenge.ClientIP != "" {
+		return "ip:" + challenge.ClientIP
+	}
+	if strings.HasPrefix(challenge.WalletAddress, "0x") {
+		return "wallet:" + strings.ToLower(challenge.WalletAddress)
+	}
+	return "wallet:" + challenge.WalletAddress
+}
+
+type issuance struct {
+	start time.Time
+	count int
+}
+
+// MemoryStore keeps the challenges in memory, expired ones are dropped by the janitor and never
+// returned by Consume
+type MemoryStore struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	limit      int
+	window     time.Duration
+	challenges map[string]MemoryDB
+	issued     map[string]*issuance
+}
+
+// NewMemoryStore returns a store keeping challenges for ttl and letting each client request limit
+// challenges per window, a zero limit disables rate limiting
+func NewMemoryStore(ttl time.Duration, limit int, window time.Duration) *MemoryStore {
+	return &MemoryStore{
+		ttl:        ttl,
+		limit:      limit,
+		window:     window,
+		challenges: make(map[string]MemoryDB),
+		issued:     make(map[string]*issuance),
+	}
+}
+
+// Put saves a new challenge
+func (s *MemoryStore) Put(id string, challenge MemoryDB) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.limit > 0 {
+		key := rateKey(challenge)
+		rate, ok := s.issued[key]
+		if !ok || challenge.Timestamp.Sub(rate.start) >= s.window {
+			rate = &issuance{start: challenge.Timestamp}
+			s.issued[key] = rate
+		}
+		if rate.count >= s.limit {
+			return ErrRateLimited
+		}
+		rate.count++
+	}
+
+	s.challenges[id] = challenge
+	return nil
+}
+
+// Consume removes and returns a challenge that has not expired
+func (s *MemoryStore) Consume(id string) (MemoryDB, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	challenge, ok := s.challenges[id]
+	if !ok {
+		return MemoryDB{}, ErrChallengeNotFound
+	}
+	delete(s.challenges, id)
+	if time.Since(challenge.Timestamp) > s.ttl {
+		return MemoryDB{}, ErrChallengeNotFound
+	}
+	return challenge, nil
+}
+
+// evict drops the expired challenges and the elapsed rate windows
+func (s *MemoryStore) evict(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, challenge := range s.challenges {
+		if now.Sub(challenge.Timestamp) > s.ttl {
+			delete(s.challenges, id)
+		}
+	}
+	for key, rate := range s.issued {
+		if now.Sub(rate.start) >= s.window {
+			delete(s.issued, key)
+		}
+	}
+}
+
+// StartJanitor evicts the expired challenges on every tick
+func (s *MemoryStore) StartJanitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		for now := range ticker.C {
+			s.evict(now)
+		}
+	}()
+}
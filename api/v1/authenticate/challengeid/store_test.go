@@ -0,0 +1,118 @@
+package challengeid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreSingleUse(t *testing.T) {
+	s := NewMemoryStore(time.Hour, 0, time.Minute)
+	challenge := MemoryDB{WalletAddress: "0xabc", ChainName: "ethereum", Timestamp: time.Now()}
+	if err := s.Put("id", challenge); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.Consume("id")
+	if err != nil || got.WalletAddress != "0xabc" {
+		t.Fatalf("expected the challenge, got %v, %v", got, err)
+	}
+	if _, err := s.Consume("id"); !errors.Is(err, ErrChallengeNotFound) {
+		t.Errorf("expected a consumed challenge gone, got %v", err)
+	}
+	if _, err := s.Consume("unknown"); !errors.Is(err, ErrChallengeNotFound) {
+		t.Errorf("expected an unknown challenge not found, got %v", err)
+	}
+}
+
+func TestMemoryStoreExpiry(t *testing.T) {
+	s := NewMemoryStore(time.Minute, 0, time.Minute)
+	now := time.Now()
+	if err := s.Put("expired", MemoryDB{WalletAddress: "0xabc", Timestamp: now.Add(-2 * time.Minute)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Put("fresh", MemoryDB{WalletAddress: "0xabc", Timestamp: now}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Consume("expired"); !errors.Is(err, ErrChallengeNotFound) {
+		t.Errorf("expected an expired challenge refused, got %v", err)
+	}
+
+	// the janitor drops expired challenges that are never answered
+	s.evict(now.Add(2 * time.Minute))
+	if _, err := s.Consume("fresh"); !errors.Is(err, ErrChallengeNotFound) {
+		t.Errorf("expected the evicted challenge gone, got %v", err)
+	}
+}
+
+func TestMemoryStoreRateLimit(t *testing.T) {
+	s := NewMemoryStore(time.Hour, 2, time.Minute)
+	now := time.Now()
+	put := func(id string, wallet string, ip string, at time.Time) error {
+		return s.Put(id, MemoryDB{WalletAddress: wallet, ClientIP: ip, Timestamp: at})
+	}
+
+	if err := put("1", "0xvictim", "203.0.113.1", now); err != nil {
+		t.Fatal(err)
+	}
+	if err := put("2", "0xother", "203.0.113.1", now); err != nil {
+		t.Fatal(err)
+	}
+	if err := put("3", "0xthird", "203.0.113.1", now); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected the client limited across wallets, got %v", err)
+	}
+
+	// another client still logs the same wallet in
+	if err := put("4", "0xvictim", "198.51.100.7", now); err != nil {
+		t.Errorf("expected another client allowed for the same wallet, got %v", err)
+	}
+
+	// the limit starts over with the next window
+	if err := put("5", "0xvictim", "203.0.113.1", now.Add(time.Minute)); err != nil {
+		t.Errorf("expected the client allowed again in the next window, got %v", err)
+	}
+
+	// challenges without a client fall back to the wallet, case insensitive for hex addresses
+	if err := put("6", "0xABC", "", now); err != nil {
+		t.Fatal(err)
+	}
+	if err := put("7", "0xabc", "", now); err != nil {
+		t.Fatal(err)
+	}
+	if err := put("8", "0xAbc", "", now); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected the wallet limited, got %v", err)
+	}
+}
+
+func TestInitHTTPStore(t *testing.T) {
+	previous := Get()
+	t.Cleanup(func() {
+		storeMu.Lock()
+		defer storeMu.Unlock()
+		store = previous
+	})
+
+	t.Setenv("CHALLENGE_STORE", BackendHTTP)
+	t.Setenv("CHALLENGE_STORE_TOKEN", "secret")
+	for _, value := range []string{"", "challenges.example.com", "ftp://challenges.example.com"} {
+		t.Setenv("CHALLENGE_STORE_URL", value)
+		if err := Init(); err == nil {
+			t.Errorf("expected CHALLENGE_STORE_URL %q refused", value)
+		}
+	}
+
+	t.Setenv("CHALLENGE_STORE_URL", "https://challenges.example.com/api/v1.0/authenticate")
+	t.Setenv("CHALLENGE_STORE_TOKEN", "")
+	if err := Init(); err == nil {
+		t.Error("expected a missing CHALLENGE_STORE_TOKEN refused")
+	}
+
+	t.Setenv("CHALLENGE_STORE_TOKEN", "secret")
+	if err := Init(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := Get().(*HTTPStore); !ok {
+		t.Errorf("expected the http store, got %T", Get())
+	}
+}
@@ -5,6 +5,8 @@ import "errors"
 type GetChallengeIdPayload struct {
 	Eula        string `json:"eula,omitempty"`
 	ChallengeId string `json:"challangeId"`
+	// Message is the EIP-4361 message evm wallets sign instead of the eula and challenge id
+	Message string `json:"message,omitempty"`
 }
 
 var (
@@ -0,0 +1,74 @@
+package authenticate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/NetSepio/nexus/api/v1/authenticate/siwe"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// ErrContractWalletsDisabled is returned when RPC_URL is not set
+var ErrContractWalletsDisabled = errors.New("contract wallets need RPC_URL")
+
+// eip1271MagicValue is returned by isValidSignature for valid signatures
+var eip1271MagicValue = []byte{0x16, 0x26, 0xba, 0x7e}
+
+var eip1271ABI, _ = abi.JSON(strings.NewReader(`[{"type":"function","name":"isValidSignature","stateMutability":"view","inputs":[{"name":"hash","type":"bytes32"},{"name":"signature","type":"bytes"}],"outputs":[{"name":"magicValue","type":"bytes4"}]}]`))
+
+// ContractWallets checks signatures of smart contract wallets such as Safe
+type ContractWallets interface {
+	// ChainID returns the chain the wallets are deployed on
+	ChainID() (int64, error)
+	// IsValidSignature returns whether wallet accepts signature for hash, false for accounts
+	// without code
+	IsValidSignature(wallet common.Address, hash common.Hash, signature []byte) (bool, error)
+}
+
+// RPCContractWallets calls EIP-1271 isValidSignature through RPC_URL
+type RPCContractWallets struct{}
+
+func (RPCContractWallets) ChainID() (int64, error) {
+	return siwe.RPCChainID()
+}
+
+func (RPCContractWallets) IsValidSignature(wallet common.Address, hash common.Hash, signature []byte) (bool, error) {
+	rpcURL := os.Getenv("RPC_URL")
+	if rpcURL == "" {
+		return false, ErrContractWalletsDisabled
+	}
+	client, err := ethclient.Dial(rpcURL)
+	if err != nil {
+		return false, err
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	code, err := client.CodeAt(ctx, wallet, nil)
+	if err != nil {
+		return false, err
+	}
+	if len(code) == 0 {
+		return false, nil
+	}
+
+	data, err := eip1271ABI.Pack("isValidSignature", hash, signature)
+	if err != nil {
+		return false, err
+	}
+	result, err := client.CallContract(ctx, ethereum.CallMsg{To: &wallet, Data: data}, nil)
+	if err != nil {
+		// wallets revert on invalid signatures
+		return false, nil
+	}
+	return len(result) >= 4 && bytes.Equal(result[:4], eip1271MagicValue), nil
+}
@@ -7,18 +7,22 @@ import (
 	"net/http"
 	"strings"
 
-	gopaseto "aidanwoods.dev/go-paseto"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/NetSepio/nexus/core"
 	"github.com/NetSepio/nexus/util/pkg/auth"
 	"github.com/NetSepio/nexus/util/pkg/claims"
+	"github.com/NetSepio/nexus/util/pkg/policy"
 	"github.com/gin-gonic/gin"
 )
 
 var (
 	ErrAuthHeaderMissing = errors.New("authorization header is required")
+	ErrAuthHeaderInvalid = errors.New("authorization header must be Bearer <token>")
 )
 
+// PASETO rejects requests without a valid token and stores its walletAddress and principal in
+// the context
 func PASETO(c *gin.Context) {
 	var headers GenericAuthHeaders
 	err := c.BindHeader(&headers)
@@ -33,31 +37,43 @@ func PASETO(c *gin.Context) {
 	}
 	if headers.Authorization == "" {
 		log.WithFields(log.Fields{
-			"err": err,
+			"err": ErrAuthHeaderMissing,
 		}).Error("Autherisation header is missing")
-		c.Abort()
+		response := core.MakeErrorResponse(401, ErrAuthHeaderMissing.Error(), nil, nil, nil)
+		c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+		return
+	}
+	pasetoToken, ok := strings.CutPrefix(headers.Authorization, "Bearer ")
+	if !ok || pasetoToken == "" {
+		log.WithFields(log.Fields{
+			"err": ErrAuthHeaderInvalid,
+		}).Error("Autherisation header is invalid")
+		response := core.MakeErrorResponse(401, ErrAuthHeaderInvalid.Error(), nil, nil, nil)
+		c.AbortWithStatusJSON(http.StatusUnauthorized, response)
 		return
 	}
-	token := headers.Authorization
-	splitToken := strings.Split(token, "Bearer ")
-	pasetoToken := splitToken[1]
-	parser := gopaseto.NewParser()
-	parser.AddRule(gopaseto.NotExpired())
-	publickey := auth.Getpublickey()
-	parsedToken, err := parser.ParseV4Public(publickey, pasetoToken, nil)
+	// tokens signed by the node or by the gateway
+	parsedToken, err := auth.Verify(pasetoToken)
 	if err != nil {
 		err = fmt.Errorf("failed to scan claims for paseto token, %s", err)
 		log.WithFields(log.Fields{
 			"err": err,
 		}).Error("failed to bindfailed to scan claims for paseto token")
-		c.AbortWithStatus(http.StatusUnauthorized)
+		response := core.MakeErrorResponse(401, "invalid or expired token", nil, nil, nil)
+		c.AbortWithStatusJSON(http.StatusUnauthorized, response)
 		return
-	} else {
-		jsonvalue := parsedToken.ClaimsJSON()
-		ClaimsValue := claims.CustomClaims{}
-		json.Unmarshal(jsonvalue, &ClaimsValue)
-		c.Set("walletAddress", ClaimsValue.WalletAddress)
-		c.Next()
 	}
 
+	ClaimsValue := claims.CustomClaims{}
+	if err := json.Unmarshal(parsedToken.ClaimsJSON(), &ClaimsValue); err != nil || ClaimsValue.WalletAddress == "" {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Error("paseto token has no wallet address")
+		response := core.MakeErrorResponse(401, "invalid or expired token", nil, nil, nil)
+		c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+		return
+	}
+	c.Set("walletAddress", ClaimsValue.WalletAddress)
+	c.Set("principal", policy.New(ClaimsValue.WalletAddress, ClaimsValue.Roles, ClaimsValue.Scopes))
+	c.Next()
 }
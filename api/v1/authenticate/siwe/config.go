@@ -0,0 +1,190 @@
+package siwe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// clockSkew is how far in the future issued at and not before may be
+const clockSkew = time.Minute
+
+// rpcRetryDelay is how long a failure to fetch the chain id of RPC_URL is returned before trying again
+const rpcRetryDelay = 30 * time.Second
+
+// Config is what the messages signed for the node are checked against
+type Config struct {
+	// Domain is the host the message is bound to, SIWE_DOMAIN or the host of DOMAIN
+	Domain string
+	// URI is the scheme, host and path prefix of the message URI, SIWE_URI or DOMAIN
+	URI string
+	// ChainIDs are the accepted chain ids, SIWE_CHAIN_IDS or the chain of RPC_URL
+	ChainIDs []int64
+}
+
+var (
+	rpcChainMu       sync.Mutex
+	rpcChainID       int64
+	rpcChainErr      error
+	rpcChainFailedAt time.Time
+)
+
+// RPCChainID returns the chain id of RPC_URL, it is only fetched once. A failure is returned
+// for rpcRetryDelay so logins do not all wait on an unreachable RPC_URL.
+func RPCChainID() (int64, error) {
+	rpcChainMu.Lock()
+	defer rpcChainMu.Unlock()
+	if rpcChainID != 0 {
+		return rpcChainID, nil
+	}
+	if rpcChainErr != nil && time.Since(rpcChainFailedAt) < rpcRetryDelay {
+		return 0, rpcChainErr
+	}
+
+	chainID, err := fetchChainID(os.Getenv("RPC_URL"))
+	if err != nil {
+		rpcChainErr = err
+		rpcChainFailedAt = time.Now()
+		return 0, err
+	}
+	rpcChainID, rpcChainErr = chainID, nil
+	return rpcChainID, nil
+}
+
+func fetchChainID(rpcURL string) (int64, error) {
+	if rpcURL == "" {
+		return 0, errors.New("RPC_URL is required when SIWE_CHAIN_IDS is not set")
+	}
+	client, err := ethclient.Dial(rpcURL)
+	if err != nil {
+		return 0, err
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return chainID.Int64(), nil
+}
+
+// ConfigFromEnv returns the config of the node
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{
+		Domain: os.Getenv("SIWE_DOMAIN"),
+		URI:    os.Getenv("SIWE_URI"),
+	}
+	if cfg.URI == "" {
+		cfg.URI = os.Getenv("DOMAIN")
+	}
+	if cfg.Domain == "" {
+		u, err := url.Parse(os.Getenv("DOMAIN"))
+		if err != nil || u.Host == "" {
+			return Config{}, errors.New("SIWE_DOMAIN or DOMAIN is required for sign-in with ethereum")
+		}
+		cfg.Domain = u.Host
+	}
+
+	for _, value := range strings.Split(os.Getenv("SIWE_CHAIN_IDS"), ",") {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		chainID, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid SIWE_CHAIN_IDS: %w", err)
+		}
+		cfg.ChainIDs = append(cfg.ChainIDs, chainID)
+	}
+	if len(cfg.ChainIDs) == 0 {
+		chainID, err := RPCChainID()
+		if err != nil {
+			return Config{}, err
+		}
+		cfg.ChainIDs = []int64{chainID}
+	}
+	return cfg, nil
+}
+
+// NewMessage returns the message a wallet signs for a challenge, it expires with the challenge
+func (cfg Config) NewMessage(address string, statement string, nonce string, issuedAt time.Time, ttl time.Duration) Message {
+	expiration := issuedAt.Add(ttl)
+	return Message{
+		Domain:         cfg.Domain,
+		Address:        address,
+		Statement:      statement,
+		URI:            cfg.URI,
+		Version:        Version,
+		ChainID:        cfg.ChainIDs[0],
+		Nonce:          nonce,
+		IssuedAt:       issuedAt,
+		ExpirationTime: &expiration,
+	}
+}
+
+// Validate checks that m was issued by the node for address, statement and nonce and is valid at now
+func (cfg Config) Validate(m *Message, address string, statement string, nonce string, now time.Time) error {
+	if m.Domain != cfg.Domain {
+		return fmt.Errorf("domain %s is not %s", m.Domain, cfg.Domain)
+	}
+	if !strings.EqualFold(m.Address, address) {
+		return fmt.Errorf("address %s is not the wallet of the challenge", m.Address)
+	}
+	if strings.TrimSpace(m.Statement) != strings.TrimSpace(statement) {
+		return errors.New("statement is not the AUTH_EULA")
+	}
+	if !servesURI(cfg.URI, m.URI) {
+		return fmt.Errorf("uri %s is not served by %s", m.URI, cfg.URI)
+	}
+	if !cfg.acceptsChain(m.ChainID) {
+		return fmt.Errorf("chain id %d is not accepted", m.ChainID)
+	}
+	if m.Nonce != nonce {
+		return errors.New("nonce is not the challenge id")
+	}
+	if m.IssuedAt.After(now.Add(clockSkew)) {
+		return errors.New("message is issued in the future")
+	}
+	if m.ExpirationTime != nil && !now.Before(*m.ExpirationTime) {
+		return errors.New("message has expired")
+	}
+	if m.NotBefore != nil && m.NotBefore.After(now.Add(clockSkew)) {
+		return errors.New("message is not valid yet")
+	}
+	return nil
+}
+
+// servesURI reports whether uri has the scheme and host of base and a path under the path of base
+func servesURI(base string, uri string) bool {
+	b, err := url.Parse(base)
+	if err != nil || b.Host == "" {
+		return false
+	}
+	u, err := url.Parse(uri)
+	if err != nil || u.Host == "" || u.User != nil {
+		return false
+	}
+	if !strings.EqualFold(u.Scheme, b.Scheme) || !strings.EqualFold(u.Host, b.Host) {
+		return false
+	}
+	prefix := strings.TrimSuffix(b.Path, "/")
+	return u.Path == prefix || strings.HasPrefix(u.Path, prefix+"/")
+}
+
+func (cfg Config) acceptsChain(chainID int64) bool {
+	for _, id := range cfg.ChainIDs {
+		if id == chainID {
+			return true
+		}
+	}
+	return false
+}
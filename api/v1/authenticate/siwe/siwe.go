@@ -0,0 +1,156 @@
+package siwe
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Version is the only EIP-4361 message version
+const Version = "1"
+
+const header = " wants you to sign in with your Ethereum account:"
+
+// ErrInvalidMessage is returned for messages that are not EIP-4361 messages
+var ErrInvalidMessage = errors.New("invalid sign-in with ethereum message")
+
+// Message is an EIP-4361 Sign-In with Ethereum message
+type Message struct {
+	Domain         string
+	Address        string
+	Statement      string
+	URI            string
+	Version        string
+	ChainID        int64
+	Nonce          string
+	IssuedAt       time.Time
+	ExpirationTime *time.Time
+	NotBefore      *time.Time
+	RequestID      string
+	Resources      []string
+}
+
+// Nonce returns the nonce of a challenge id, EIP-4361 nonces are alphanumeric
+func Nonce(challengeId string) string {
+	return strings.ReplaceAll(challengeId, "-", "")
+}
+
+// String returns the message the wallet signs
+func (m Message) String() string {
+	var b strings.Builder
+	b.WriteString(m.Domain + header + "\n")
+	b.WriteString(m.Address + "\n\n")
+	if m.Statement != "" {
+		b.WriteString(m.Statement + "\n")
+	}
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "URI: %s\n", m.URI)
+	fmt.Fprintf(&b, "Version: %s\n", m.Version)
+	fmt.Fprintf(&b, "Chain ID: %d\n", m.ChainID)
+	fmt.Fprintf(&b, "Nonce: %s\n", m.Nonce)
+	fmt.Fprintf(&b, "Issued At: %s", m.IssuedAt.UTC().Format(time.RFC3339))
+	if m.ExpirationTime != nil {
+		fmt.Fprintf(&b, "\nExpiration Time: %s", m.ExpirationTime.UTC().Format(time.RFC3339))
+	}
+	if m.NotBefore != nil {
+		fmt.Fprintf(&b, "\nNot Before: %s", m.NotBefore.UTC().Format(time.RFC3339))
+	}
+	if m.RequestID != "" {
+		fmt.Fprintf(&b, "\nRequest ID: %s", m.RequestID)
+	}
+	if len(m.Resources) > 0 {
+		b.WriteString("\nResources:")
+		for _, resource := range m.Resources {
+			b.WriteString("\n- " + resource)
+		}
+	}
+	return b.String()
+}
+
+// Parse parses an EIP-4361 message
+func Parse(message string) (*Message, error) {
+	lines := strings.Split(message, "\n")
+	if len(lines) < 4 {
+		return nil, ErrInvalidMessage
+	}
+
+	var m Message
+	domain, ok := strings.CutSuffix(lines[0], header)
+	if !ok || domain == "" {
+		return nil, ErrInvalidMessage
+	}
+	// the scheme is optional in front of the domain
+	if _, host, ok := strings.Cut(domain, "://"); ok {
+		domain = host
+	}
+	m.Domain = domain
+	m.Address = lines[1]
+	if lines[2] != "" {
+		return nil, ErrInvalidMessage
+	}
+
+	// the statement is optional, it is followed by an empty line when present
+	rest := lines[3:]
+	if rest[0] != "" {
+		if len(rest) < 2 || rest[1] != "" {
+			return nil, ErrInvalidMessage
+		}
+		m.Statement = rest[0]
+		rest = rest[2:]
+	} else {
+		rest = rest[1:]
+	}
+
+	for i := 0; i < len(rest); i++ {
+		if rest[i] == "Resources:" {
+			for _, line := range rest[i+1:] {
+				resource, ok := strings.CutPrefix(line, "- ")
+				if !ok {
+					return nil, ErrInvalidMessage
+				}
+				m.Resources = append(m.Resources, resource)
+			}
+			break
+		}
+
+		key, value, ok := strings.Cut(rest[i], ": ")
+		if !ok {
+			return nil, ErrInvalidMessage
+		}
+		var err error
+		switch key {
+		case "URI":
+			m.URI = value
+		case "Version":
+			m.Version = value
+		case "Chain ID":
+			m.ChainID, err = strconv.ParseInt(value, 10, 64)
+		case "Nonce":
+			m.Nonce = value
+		case "Issued At":
+			m.IssuedAt, err = time.Parse(time.RFC3339, value)
+		case "Expiration Time":
+			var t time.Time
+			t, err = time.Parse(time.RFC3339, value)
+			m.ExpirationTime = &t
+		case "Not Before":
+			var t time.Time
+			t, err = time.Parse(time.RFC3339, value)
+			m.NotBefore = &t
+		case "Request ID":
+			m.RequestID = value
+		default:
+			return nil, ErrInvalidMessage
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidMessage, key, err)
+		}
+	}
+
+	if m.URI == "" || m.Version != Version || m.ChainID == 0 || len(m.Nonce) < 8 || m.IssuedAt.IsZero() {
+		return nil, ErrInvalidMessage
+	}
+	return &m, nil
+}
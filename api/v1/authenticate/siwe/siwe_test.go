@@ -0,0 +1,143 @@
+package siwe
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const (
+	wallet    = "0x71C7656EC7ab88b098defB751B7401B5f6d8976F"
+	statement = "I Accept the Terms of Service"
+)
+
+func testConfig() Config {
+	return Config{Domain: "node.example.com", URI: "https://node.example.com/app/", ChainIDs: []int64{3338, 1}}
+}
+
+func TestMessageRoundTrip(t *testing.T) {
+	issuedAt := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	m := testConfig().NewMessage(wallet, statement, Nonce("3b241101-e2bb-4255-8caf-4136c566a962"), issuedAt, time.Hour)
+	m.Resources = []string{"https://node.example.com/terms"}
+
+	parsed, err := Parse(m.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.String() != m.String() || parsed.Nonce != "3b241101e2bb42558caf4136c566a962" || parsed.ChainID != 3338 {
+		t.Errorf("expected the message parsed back, got\n%s", parsed.String())
+	}
+
+	if _, err := Parse(strings.Replace(m.String(), "Version: 1", "Version: 2", 1)); !errors.Is(err, ErrInvalidMessage) {
+		t.Errorf("expected another version refused, got %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cfg := testConfig()
+	issuedAt := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	now := issuedAt.Add(time.Minute)
+	nonce := "3b241101e2bb42558caf4136c566a962"
+
+	for _, tc := range []struct {
+		name   string
+		change func(m *Message)
+		ok     bool
+	}{
+		{"valid", func(m *Message) {}, true},
+		{"address in another case", func(m *Message) { m.Address = strings.ToLower(wallet) }, true},
+		{"uri under the path", func(m *Message) { m.URI = "https://NODE.example.com/app/login" }, true},
+		{"other domain", func(m *Message) { m.Domain = "evil.io" }, false},
+		{"other wallet", func(m *Message) { m.Address = "0x0000000000000000000000000000000000000001" }, false},
+		{"other statement", func(m *Message) { m.Statement = "something else" }, false},
+		{"uri host suffix", func(m *Message) { m.URI = "https://node.example.com.evil.io/app/" }, false},
+		{"uri path prefix", func(m *Message) { m.URI = "https://node.example.com/application" }, false},
+		{"uri scheme", func(m *Message) { m.URI = "http://node.example.com/app/" }, false},
+		{"uri user info", func(m *Message) { m.URI = "https://node.example.com@evil.io/app/" }, false},
+		{"other chain", func(m *Message) { m.ChainID = 5 }, false},
+		{"other nonce", func(m *Message) { m.Nonce = "abcdefgh12345678" }, false},
+		{"issued in the future", func(m *Message) { m.IssuedAt = now.Add(2 * time.Minute) }, false},
+		{"expired", func(m *Message) { expired := now; m.ExpirationTime = &expired }, false},
+		{"not valid yet", func(m *Message) { later := now.Add(time.Hour); m.NotBefore = &later }, false},
+	} {
+		m := cfg.NewMessage(wallet, statement, nonce, issuedAt, time.Hour)
+		tc.change(&m)
+		if err := cfg.Validate(&m, wallet, statement, nonce, now); (err == nil) != tc.ok {
+			t.Errorf("%s: expected ok=%v, got %v", tc.name, tc.ok, err)
+		}
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("DOMAIN", "https://node.example.com:9080/")
+	t.Setenv("SIWE_DOMAIN", "")
+	t.Setenv("SIWE_URI", "")
+	t.Setenv("SIWE_CHAIN_IDS", "3338, 1")
+
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Domain != "node.example.com:9080" || cfg.URI != "https://node.example.com:9080/" || len(cfg.ChainIDs) != 2 || cfg.ChainIDs[1] != 1 {
+		t.Errorf("unexpected config %+v", cfg)
+	}
+
+	t.Setenv("SIWE_CHAIN_IDS", "3338,x")
+	if _, err := ConfigFromEnv(); err == nil {
+		t.Error("expected invalid chain ids refused")
+	}
+}
+
+func TestRPCChainIDCachesFailures(t *testing.T) {
+	var calls atomic.Int32
+	var healthy atomic.Bool
+	rpc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if !healthy.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":"0xd0a"}`, req.ID)
+	}))
+	defer rpc.Close()
+
+	t.Setenv("RPC_URL", rpc.URL)
+	reset := func() {
+		rpcChainMu.Lock()
+		defer rpcChainMu.Unlock()
+		rpcChainID, rpcChainErr, rpcChainFailedAt = 0, nil, time.Time{}
+	}
+	reset()
+	t.Cleanup(reset)
+
+	if _, err := RPCChainID(); err == nil {
+		t.Fatal("expected the unavailable rpc to fail")
+	}
+	healthy.Store(true)
+	if _, err := RPCChainID(); err == nil || calls.Load() != 1 {
+		t.Errorf("expected the failure returned without calling the rpc again, got %v after %d calls", err, calls.Load())
+	}
+
+	// once the retry delay elapsed the chain id is fetched and kept
+	rpcChainMu.Lock()
+	rpcChainFailedAt = time.Now().Add(-rpcRetryDelay)
+	rpcChainMu.Unlock()
+	for range 2 {
+		if id, err := RPCChainID(); err != nil || id != 3338 {
+			t.Errorf("expected chain id 3338, got %d, %v", id, err)
+		}
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected the chain id fetched once more, got %d calls", calls.Load())
+	}
+}
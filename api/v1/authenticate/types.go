@@ -1,10 +1,14 @@
 package authenticate
 
+import "github.com/NetSepio/nexus/util/pkg/auth"
+
 type AuthenticateRequest struct {
 	ChallengeId string `json:"challengeId" binding:"required"`
 	Signature   string `json:"signature" binding:"required"`
 	PubKey      string `json:"pubKey" binding:"omitempty"`
-	ChainName   string `json:"chainName" binding:"required"`
+	// Message is the full message signed when the wallet added fields to it, such as aptos
+	Message   string `json:"message" binding:"omitempty"`
+	ChainName string `json:"chainName" binding:"required"`
 }
 
 type AuthenticatePayload struct {
@@ -13,3 +17,10 @@ type AuthenticatePayload struct {
 	Message string `json:"message"`
 	Token   string `json:"token"`
 }
+
+type KeysPayload struct {
+	Status  int64      `json:"status"`
+	Success bool       `json:"success"`
+	Message string     `json:"message"`
+	Keys    []auth.Key `json:"keys"`
+}
@@ -4,165 +4,347 @@ import (
 	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/elliptic"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/big"
 	"strings"
+	"time"
 
 	"github.com/NetSepio/nexus/api/v1/authenticate/challengeid"
-	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/NetSepio/nexus/api/v1/authenticate/siwe"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/minio/blake2b-simd"
 	"github.com/mr-tron/base58"
-	"golang.org/x/crypto/nacl/sign"
+	"golang.org/x/crypto/blake2b"
 	"golang.org/x/crypto/sha3"
 )
 
-var ErrChallengeIdNotFound = errors.New("challenge id not found")
+var ErrChallengeIdNotFound = challengeid.ErrChallengeNotFound
 
-func CheckSignAptos(signature string, challangeId string, message string, pubKey string) (string, bool, error) {
-	signatureInBytes, err := hexutil.Decode(signature)
-	if err != nil {
-		return "", false, err
-	}
-
-	sha3_i := sha3.New256()
-	signatureInBytes = append(signatureInBytes, []byte(message)...)
-	pubBytes, err := hexutil.Decode(pubKey)
-	if err != nil {
-		return "", false, err
-	}
-	sha3_i.Write(pubBytes)
-	sha3_i.Write([]byte{0})
-	hash := sha3_i.Sum(nil)
-	addr := hexutil.Encode(hash)
+var (
+	// ErrSignatureMismatch is returned when a signature was not made by the wallet of the challenge
+	ErrSignatureMismatch = errors.New("signature does not match the wallet address")
+	// ErrMessageMismatch is returned when the signed message is not the one of the challenge
+	ErrMessageMismatch = errors.New("signed message does not match the challenge")
+)
 
-	dbData, exists := challengeid.Data[challangeId]
-	if !exists {
-		return "", false, ErrChallengeIdNotFound
-	}
+// Login is what a wallet returns for a challenge
+type Login struct {
+	WalletAddress string
+	ChallengeId   string
+	// Eula is the AUTH_EULA the wallet accepts by signing
+	Eula string
+	// Message is the full message returned by the wallet, only needed when the wallet adds fields
+	// to the message it was asked to sign
+	Message   string
+	Signature string
+	// PubKey is required by chains whose address is a hash of the public key and whose signature
+	// does not carry it
+	PubKey string
+}
 
-	if !strings.EqualFold(addr, dbData.WalletAddress) {
-		return "", false, err
-	}
+// Verifier checks that a login was signed by the wallet the challenge was issued to
+type Verifier interface {
+	Verify(login Login) error
+}
 
-	msgGot, matches := sign.Open(nil, signatureInBytes, (*[32]byte)(pubBytes))
-	if !matches || string(msgGot) != message {
-		return "", false, err
-	}
-	return dbData.WalletAddress, true, nil
+// verifiers are keyed by the chain names accepted by challengeid.ValidateAddress
+var verifiers = map[string]Verifier{
+	"ethereum": EthereumVerifier{},
+	"peaq":     EthereumVerifier{},
+	"monad":    EthereumVerifier{},
+	"solana":   SolanaVerifier{},
+	"eclipse":  SolanaVerifier{},
+	"sui":      SuiVerifier{},
+	"aptos":    AptosVerifier{},
+}
 
+// VerifierFor returns the verifier of chain
+func VerifierFor(chain string) (Verifier, bool) {
+	v, ok := verifiers[chain]
+	return v, ok
 }
 
-func CheckSignEthereum(signature string, flowId string, message string) (string, bool, error) {
+// decodeHex decodes hex with or without the 0x prefix
+func decodeHex(s string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X"))
+}
 
-	newMsg := fmt.Sprintf("\x19Ethereum Signed Message:\n%v%v", len(message), message)
+// EthereumVerifier checks personal_sign signatures of the AUTH_EULA followed by the challenge id,
+// or of an EIP-4361 message when the wallet sends one. Signatures of accounts with code are
+// checked with EIP-1271.
+type EthereumVerifier struct {
+	// Contracts checks contract wallet signatures, RPCContractWallets when nil
+	Contracts ContractWallets
+}
 
-	// fmt.Println("newMsg : ", newMsg)
+func (v EthereumVerifier) Verify(login Login) error {
+	message := login.Eula + login.ChallengeId
+	var signIn *siwe.Message
+	if login.Message != "" {
+		m, err := siweMessage(login)
+		if err != nil {
+			return err
+		}
+		signIn, message = m, login.Message
+	}
+	hash := crypto.Keccak256Hash([]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(message), message)))
 
-	newMsgHash := crypto.Keccak256Hash([]byte(newMsg))
-	signatureInBytes, err := hexutil.Decode(signature)
+	signature, err := decodeHex(login.Signature)
 	if err != nil {
-		return "", false, err
+		return err
 	}
-	// check if the signature is in the [R || S || V] format
-	if len(signatureInBytes) != 65 {
-		return "", false, errors.New("invalid signature length")
+	recoverErr := recoverWallet(hash, signature, login.WalletAddress)
+	if recoverErr == nil {
+		return nil
 	}
-	if signatureInBytes[64] == 27 || signatureInBytes[64] == 28 {
-		signatureInBytes[64] -= 27
-	}
-	pubKey, err := crypto.SigToPub(newMsgHash.Bytes(), signatureInBytes)
 
+	contracts := v.Contracts
+	if contracts == nil {
+		contracts = RPCContractWallets{}
+	}
+	valid, err := contracts.IsValidSignature(common.HexToAddress(login.WalletAddress), hash, signature)
+	if errors.Is(err, ErrContractWalletsDisabled) {
+		return recoverErr
+	}
 	if err != nil {
-		return "", false, err
+		return err
 	}
+	if !valid {
+		return ErrSignatureMismatch
+	}
+	// a contract wallet only exists on the chain of RPC_URL
+	if signIn != nil {
+		chainID, err := contracts.ChainID()
+		if err != nil {
+			return err
+		}
+		if signIn.ChainID != chainID {
+			return fmt.Errorf("%w: contract wallets sign in on chain %d", ErrMessageMismatch, chainID)
+		}
+	}
+	return nil
+}
 
-	//Get address from public key
-	walletAddress := crypto.PubkeyToAddress(*pubKey)
-
-	flowIdData := challengeid.Data[flowId]
-	if (challengeid.MemoryDB{}) == flowIdData {
-		return "", false, ErrChallengeIdNotFound
+// recoverWallet checks that an [R || S || V] signature of hash was made by wallet
+func recoverWallet(hash common.Hash, signature []byte, wallet string) error {
+	if len(signature) != 65 {
+		return errors.New("invalid signature length")
 	}
-	if strings.EqualFold(flowIdData.WalletAddress, walletAddress.String()) {
-		return flowIdData.WalletAddress, true, nil
-	} else {
-		return "", false, errors.New("mismatch wallet_address")
+	signature = append([]byte{}, signature...)
+	if signature[64] == 27 || signature[64] == 28 {
+		signature[64] -= 27
+	}
+	pubKey, err := crypto.SigToPub(hash.Bytes(), signature)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(crypto.PubkeyToAddress(*pubKey).Hex(), wallet) {
+		return ErrSignatureMismatch
 	}
+	return nil
 }
 
-func CheckSignSui(signature string, challangeId string) (string, bool, error) {
-	// Decode signature
-	signatureBytes, err := base64.StdEncoding.DecodeString(signature)
+// siweMessage parses the EIP-4361 message of login and checks it against the node config
+func siweMessage(login Login) (*siwe.Message, error) {
+	m, err := siwe.Parse(login.Message)
 	if err != nil {
-		return "", false, err
+		return nil, err
+	}
+	cfg, err := siwe.ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(m, login.WalletAddress, login.Eula, siwe.Nonce(login.ChallengeId), time.Now()); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMessageMismatch, err)
 	}
+	return m, nil
+}
 
-	// Assuming ED25519 signature format
-	size := 32
+// SolanaVerifier checks ed25519 signatures of the AUTH_EULA followed by the challenge id, the
+// address is the base58 public key and signatures are base58 or hex encoded
+type SolanaVerifier struct{}
 
-	publicKey := signatureBytes[len(signatureBytes)-size:]
-	pubKey := &ecdsa.PublicKey{
-		Curve: elliptic.P256(),                       // Curve is not used in serialization
-		X:     new(big.Int).SetBytes(publicKey[:]),   // Set X coordinate
-		Y:     new(big.Int).SetBytes(publicKey[32:]), // Set Y coordinate
+func (SolanaVerifier) Verify(login Login) error {
+	publicKey, err := base58.Decode(login.WalletAddress)
+	if err != nil || len(publicKey) != ed25519.PublicKeySize {
+		return errors.New("invalid solana address")
 	}
-	if pubKey.X == nil || pubKey.Y == nil {
-		return "", false, err
+	if login.PubKey != "" && login.PubKey != login.WalletAddress {
+		return ErrSignatureMismatch
 	}
-	// Serialize the public key into bytes
-	pubKeyBytes := pubKey.X.Bytes()
 
-	// Pad X coordinate bytes to ensure they are the same length as the curve's bit size
-	paddingLen := (pubKey.Curve.Params().BitSize + 7) / 8
-	pubKeyBytes = append(make([]byte, paddingLen-len(pubKeyBytes)), pubKeyBytes...)
+	var signature []byte
+	if len(login.Signature) == 2*ed25519.SignatureSize {
+		signature, err = hex.DecodeString(login.Signature)
+	} else {
+		signature, err = base58.Decode(login.Signature)
+	}
+	if err != nil || len(signature) != ed25519.SignatureSize {
+		return errors.New("invalid signature encoding")
+	}
 
-	// Concatenate the signature scheme flag (0x00 for Ed25519) with the serialized public key bytes
-	concatenatedBytes := append([]byte{0x00}, pubKeyBytes...)
+	if !ed25519.Verify(publicKey, []byte(login.Eula+login.ChallengeId), signature) {
+		return ErrSignatureMismatch
+	}
+	return nil
+}
 
-	// Compute the BLAKE2b hash
-	hash := blake2b.Sum256(concatenatedBytes)
+// Sui signature schemes, the flag byte prefixing public keys and serialized signatures
+const (
+	suiFlagEd25519   = 0x00
+	suiFlagSecp256k1 = 0x01
+	suiFlagSecp256r1 = 0x02
+)
 
-	// The resulting hash is the Sui address
-	suiAddress := "0x" + hex.EncodeToString(hash[:])
+// suiPersonalMessageIntent is the intent of signPersonalMessage: scope PersonalMessage, version
+// V0, app Sui
+var suiPersonalMessageIntent = []byte{3, 0, 0}
+
+// SuiVerifier checks signPersonalMessage signatures of the AUTH_EULA followed by the challenge
+// id. The signature is base64 of flag || signature || public key and signs the blake2b-256 of
+// the intent followed by the BCS encoded message.
+type SuiVerifier struct{}
+
+// SuiMessageDigest returns the digest a Sui wallet signs for a personal message
+func SuiMessageDigest(message []byte) [32]byte {
+	// BCS encodes a vector<u8> as its ULEB128 length followed by the bytes
+	length := binary.AppendUvarint(nil, uint64(len(message)))
+
+	data := make([]byte, 0, len(suiPersonalMessageIntent)+len(length)+len(message))
+	data = append(data, suiPersonalMessageIntent...)
+	data = append(data, length...)
+	data = append(data, message...)
+	return blake2b.Sum256(data)
+}
 
-	dbData, exists := challengeid.Data[challangeId]
-	if !exists {
-		return "", false, ErrChallengeIdNotFound
+// SuiAddress returns the address of a public key for the scheme of flag
+func SuiAddress(flag byte, publicKey []byte) string {
+	hash := blake2b.Sum256(append([]byte{flag}, publicKey...))
+	return "0x" + hex.EncodeToString(hash[:])
+}
+
+func (SuiVerifier) Verify(login Login) error {
+	serialized, err := base64.StdEncoding.DecodeString(login.Signature)
+	if err != nil || len(serialized) < 1+64 {
+		return errors.New("invalid signature encoding")
+	}
+	flag, signature, publicKey := serialized[0], serialized[1:65], serialized[65:]
+
+	if !strings.EqualFold(SuiAddress(flag, publicKey), login.WalletAddress) {
+		return ErrSignatureMismatch
 	}
 
-	if !strings.EqualFold(suiAddress, dbData.WalletAddress) {
-		return "", false, err
+	digest := SuiMessageDigest([]byte(login.Eula + login.ChallengeId))
+	var valid bool
+	switch flag {
+	case suiFlagEd25519:
+		valid = len(publicKey) == ed25519.PublicKeySize && ed25519.Verify(publicKey, digest[:], signature)
+	case suiFlagSecp256k1:
+		hash := sha256.Sum256(digest[:])
+		valid = len(publicKey) == 33 && crypto.VerifySignature(publicKey, hash[:], signature)
+	case suiFlagSecp256r1:
+		hash := sha256.Sum256(digest[:])
+		x, y := elliptic.UnmarshalCompressed(elliptic.P256(), publicKey)
+		if x == nil {
+			return errors.New("invalid secp256r1 public key")
+		}
+		r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+		valid = ecdsa.Verify(&ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, hash[:], r, s)
+	default:
+		return fmt.Errorf("unsupported sui signature scheme %d", flag)
 	}
 
-	return dbData.WalletAddress, true, nil
+	if !valid {
+		return ErrSignatureMismatch
+	}
+	return nil
 }
 
-func CheckSignSolana(signature string, challangeId string, message string, pubKey string) (string, bool, error) {
+// AptosVerifier checks signMessage signatures of the AIP-62 full message, by default
+// "APTOS\nmessage: <AUTH_EULA>\nnonce: <challenge id>". Wallets that add the address,
+// application or chainId lines send the full message they signed.
+type AptosVerifier struct{}
 
-	bytes, err := base58.Decode(pubKey)
-	if err != nil {
-		return "", false, err
+// AptosAddress returns the address of an ed25519 public key, the sha3-256 of the key followed by
+// the single key scheme byte
+func AptosAddress(publicKey []byte) string {
+	hash := sha3.Sum256(append(append([]byte{}, publicKey...), 0x00))
+	return "0x" + hex.EncodeToString(hash[:])
+}
+
+// aptosFullMessage returns the message to verify, the default one unless login.Message is a full
+// message binding the same wallet, AUTH_EULA and challenge id
+func aptosFullMessage(login Login) (string, error) {
+	if login.Message == "" {
+		return fmt.Sprintf("APTOS\nmessage: %s\nnonce: %s", login.Eula, login.ChallengeId), nil
 	}
-	messageAsBytes := []byte(message)
 
-	signedMessageAsBytes, err := hex.DecodeString(signature)
+	body, ok := strings.CutPrefix(login.Message, "APTOS\n")
+	if !ok {
+		return "", ErrMessageMismatch
+	}
+	// the message itself may span lines, nonce is always last
+	body, nonce, ok := strings.Cut(body, "\nnonce: ")
+	if !ok || nonce != login.ChallengeId {
+		return "", ErrMessageMismatch
+	}
+	header, message, ok := strings.Cut(body, "message: ")
+	if !ok || message != login.Eula {
+		return "", ErrMessageMismatch
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(header, "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(line, ": ")
+		switch key {
+		case "address":
+			if !sameAptosAddress(value, login.WalletAddress) {
+				return "", ErrMessageMismatch
+			}
+		case "application", "chainId":
+		default:
+			return "", ErrMessageMismatch
+		}
+	}
 
-	if err != nil {
+	return login.Message, nil
+}
 
-		return "", false, err
+// sameAptosAddress compares addresses ignoring case and the leading zeros of the short form
+func sameAptosAddress(a, b string) bool {
+	trim := func(s string) string {
+		return strings.TrimLeft(strings.ToLower(strings.TrimPrefix(s, "0x")), "0")
 	}
+	return trim(a) == trim(b)
+}
 
-	dbData, exists := challengeid.Data[challangeId]
-	if !exists {
-		return "", false, ErrChallengeIdNotFound
+func (AptosVerifier) Verify(login Login) error {
+	publicKey, err := decodeHex(login.PubKey)
+	if err != nil || len(publicKey) != ed25519.PublicKeySize {
+		return errors.New("invalid aptos public key")
+	}
+	signature, err := decodeHex(login.Signature)
+	if err != nil || len(signature) != ed25519.SignatureSize {
+		return errors.New("invalid signature encoding")
 	}
 
-	ed25519.Verify(bytes, messageAsBytes, signedMessageAsBytes)
-
-	return dbData.WalletAddress, true, nil
+	// accounts that rotated their authentication key are not supported
+	if !sameAptosAddress(AptosAddress(publicKey), login.WalletAddress) {
+		return ErrSignatureMismatch
+	}
 
+	message, err := aptosFullMessage(login)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(publicKey, []byte(message), signature) {
+		return ErrSignatureMismatch
+	}
+	return nil
 }
@@ -0,0 +1,295 @@
+package authenticate
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/NetSepio/nexus/api/v1/authenticate/siwe"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	testEula        = "I Accept the Erebrus Terms of Service https://erebrus.io/terms.html for accessing the application. "
+	testChallengeId = "3b241101-e2bb-4255-8caf-4136c566a962"
+	otherChallenge  = "9f4e0c5a-0000-4000-8000-000000000000"
+
+	ethereumWallet    = "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23"
+	ethereumSignature = "0xd3226d9fbde3c2342d6d487e0a10baf438459606a21429e693c8dc4dca3aeefd7d7e2cf8cc831502acfc327c6f2c29d635f13113dd14bdd794ca01bf8103c3aa1c"
+
+	solanaWallet          = "9C6hybhQ6Aycep9jaUnP6uL9ZYvDjUp1aSkFWPUFJtpj"
+	solanaSignatureHex    = "ef77758bab4b9f8468940bae4e09b1aae13ab0ffbc1fb7cf58d577d48ed7448c2608c67d43efe9cd7b58a09d1ac24b95e0f9c0ca296007ee774e1253c8477804"
+	solanaSignatureBase58 = "5ngqiQq1k5hyoXo5rMPxhVecCrwfmGCgsJx54zYyLsMgyKrf6XCtseird9fwNp9d7R66ni8YV1Ba62CMe6JoL6qy"
+
+	suiWallet             = "0x4ff42fed057af14e647c0e05b706caf2cdd2276132054dd0ea6b4c5dba933290"
+	suiSignature          = "AKVKGGV/TB8AFaFR4aYkpmY/1+ISe8MalbWna7TyRyWGxm12O/bVwGh7ajRberVGANJD8IjTUE6zt35f3ViooAJDzcAj0i1fnhB9GgaTRX010dEOt9IcchGS9W9d5AZl0w=="
+	suiSecp256k1Wallet    = "0xd6a527f10a1813de6a6038ac54b4c21fbd019d22d163c9bde84a5282e93d20b2"
+	suiSecp256k1Signature = "AdaIbUCsNQYTPSpEPIf1DqzUwWzPJ+MUHytiJZKjO5GbVrIZdznPKIn6v0UF4fLSxTLuWg45UIDqRfD88F44bnMCVdKs2TzNJoLHSdWXr3Qmcm0xZUJiBHCL5KqOgeK7jNQ="
+
+	aptosWallet            = "0x1117e2d0051b1e0c1f73c4124381950c36b0ef23554e62144f17960c81bb3d25"
+	aptosPubKey            = "0x51998ccfdfffd83d9724490818ca22a97a030d18bb2a9d74668f72b81c3fa588"
+	aptosSignature         = "0x80c0f118a0527da594ac6639333571c8cefbaae43a3567022e6851eebc3bb6f6b57a70ccc90ab1c0f5cb0f3d9756dc79ca57a1fe1f1197c7ca08d8457f58fc0e"
+	aptosFullSignature     = "0xc65dc6a17cb95836be20b5678b7797c5f19c0770a9b9438618d98639f782ad4fcd8b3ad4371fa42ebb8d80986f146795c1884cc564b70592597c14e4a526690a"
+	aptosFullMessageSigned = "APTOS\naddress: " + aptosWallet + "\napplication: https://erebrus.io\nchainId: 1\nmessage: " + testEula + "\nnonce: " + testChallengeId
+)
+
+func TestVerifiers(t *testing.T) {
+	tests := []struct {
+		name    string
+		chain   string
+		login   Login
+		wantErr error
+		invalid bool
+	}{
+		{
+			name:  "ethereum personal_sign",
+			chain: "ethereum",
+			login: Login{WalletAddress: ethereumWallet, Signature: ethereumSignature},
+		},
+		{
+			name:  "ethereum lower case address",
+			chain: "peaq",
+			login: Login{WalletAddress: "0x2c7536e3605d9c16a7a3d7b1898e529396a65c23", Signature: ethereumSignature},
+		},
+		{
+			name:    "ethereum other challenge",
+			chain:   "ethereum",
+			login:   Login{WalletAddress: ethereumWallet, ChallengeId: otherChallenge, Signature: ethereumSignature},
+			wantErr: ErrSignatureMismatch,
+		},
+		{
+			name:    "ethereum short signature",
+			chain:   "ethereum",
+			login:   Login{WalletAddress: ethereumWallet, Signature: "0x1234"},
+			invalid: true,
+		},
+		{
+			name:  "solana hex signature",
+			chain: "solana",
+			login: Login{WalletAddress: solanaWallet, Signature: solanaSignatureHex},
+		},
+		{
+			name:  "solana base58 signature",
+			chain: "eclipse",
+			login: Login{WalletAddress: solanaWallet, Signature: solanaSignatureBase58, PubKey: solanaWallet},
+		},
+		{
+			name:    "solana other challenge",
+			chain:   "solana",
+			login:   Login{WalletAddress: solanaWallet, ChallengeId: otherChallenge, Signature: solanaSignatureHex},
+			wantErr: ErrSignatureMismatch,
+		},
+		{
+			name:    "solana other wallet",
+			chain:   "solana",
+			login:   Login{WalletAddress: "4Nd1mBQtrMJVYVfKf2PJy9NZUZdTAsp7D4xWLs4gDB4T", Signature: solanaSignatureHex},
+			wantErr: ErrSignatureMismatch,
+		},
+		{
+			name:    "solana public key of another wallet",
+			chain:   "solana",
+			login:   Login{WalletAddress: solanaWallet, Signature: solanaSignatureHex, PubKey: "4Nd1mBQtrMJVYVfKf2PJy9NZUZdTAsp7D4xWLs4gDB4T"},
+			wantErr: ErrSignatureMismatch,
+		},
+		{
+			name:  "sui ed25519",
+			chain: "sui",
+			login: Login{WalletAddress: suiWallet, Signature: suiSignature},
+		},
+		{
+			name:  "sui secp256k1",
+			chain: "sui",
+			login: Login{WalletAddress: suiSecp256k1Wallet, Signature: suiSecp256k1Signature},
+		},
+		{
+			name:    "sui other challenge",
+			chain:   "sui",
+			login:   Login{WalletAddress: suiWallet, ChallengeId: otherChallenge, Signature: suiSignature},
+			wantErr: ErrSignatureMismatch,
+		},
+		{
+			name:    "sui signature of another wallet",
+			chain:   "sui",
+			login:   Login{WalletAddress: suiSecp256k1Wallet, Signature: suiSignature},
+			wantErr: ErrSignatureMismatch,
+		},
+		{
+			name:    "sui not base64",
+			chain:   "sui",
+			login:   Login{WalletAddress: suiWallet, Signature: "not base64"},
+			invalid: true,
+		},
+		{
+			name:  "aptos default message",
+			chain: "aptos",
+			login: Login{WalletAddress: aptosWallet, Signature: aptosSignature, PubKey: aptosPubKey},
+		},
+		{
+			name:  "aptos full message",
+			chain: "aptos",
+			login: Login{WalletAddress: aptosWallet, Signature: aptosFullSignature, PubKey: aptosPubKey, Message: aptosFullMessageSigned},
+		},
+		{
+			name:    "aptos full message without it",
+			chain:   "aptos",
+			login:   Login{WalletAddress: aptosWallet, Signature: aptosFullSignature, PubKey: aptosPubKey},
+			wantErr: ErrSignatureMismatch,
+		},
+		{
+			name:    "aptos full message of another challenge",
+			chain:   "aptos",
+			login:   Login{WalletAddress: aptosWallet, ChallengeId: otherChallenge, Signature: aptosFullSignature, PubKey: aptosPubKey, Message: aptosFullMessageSigned},
+			wantErr: ErrMessageMismatch,
+		},
+		{
+			name:    "aptos public key of another wallet",
+			chain:   "aptos",
+			login:   Login{WalletAddress: "0x" + "00000000000000000000000000000000000000000000000000000000000000a1", Signature: aptosSignature, PubKey: aptosPubKey},
+			wantErr: ErrSignatureMismatch,
+		},
+		{
+			name:    "aptos missing public key",
+			chain:   "aptos",
+			login:   Login{WalletAddress: aptosWallet, Signature: aptosSignature},
+			invalid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier, ok := VerifierFor(tt.chain)
+			if !ok {
+				t.Fatalf("no verifier for %s", tt.chain)
+			}
+
+			login := tt.login
+			login.Eula = testEula
+			if login.ChallengeId == "" {
+				login.ChallengeId = testChallengeId
+			}
+
+			err := verifier.Verify(login)
+			switch {
+			case tt.invalid:
+				if err == nil || errors.Is(err, ErrSignatureMismatch) || errors.Is(err, ErrMessageMismatch) {
+					t.Errorf("Verify() error = %v, want a decoding error", err)
+				}
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+				}
+			case err != nil:
+				t.Errorf("Verify() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifierForUnknownChain(t *testing.T) {
+	if _, ok := VerifierFor("bitcoin"); ok {
+		t.Fatal("VerifierFor(bitcoin) returned a verifier")
+	}
+}
+
+const (
+	siweMessageSigned = "nexus.example wants you to sign in with your Ethereum account:\n" + ethereumWallet + "\n\n" +
+		"I Accept the Erebrus Terms of Service https://erebrus.io/terms.html for accessing the application.\n\n" +
+		"URI: https://nexus.example\nVersion: 1\nChain ID: 3338\nNonce: 3b241101e2bb42558caf4136c566a962\n" +
+		"Issued At: 2025-01-01T00:00:00Z\nExpiration Time: 2100-01-01T00:00:00Z"
+	siweSignature = "0xc3e494ccbab7be02b3ba2f569a18a2356d57fb7882493c7afdc3dcb736834ccb0c4ed290af39bd08fb382748fc638b70eb02edb057e813744c756092baa5e0631b"
+
+	safeWallet    = "0x5aFE3855358E112B5647B952709E6165e1c1eEEe"
+	safeSignature = "0x0102030405"
+)
+
+// fakeSafe accepts safeSignature for safeWallet on chain 3338
+type fakeSafe struct{}
+
+func (fakeSafe) ChainID() (int64, error) {
+	return 3338, nil
+}
+
+func (fakeSafe) IsValidSignature(wallet common.Address, hash common.Hash, signature []byte) (bool, error) {
+	return wallet == common.HexToAddress(safeWallet) && hex.EncodeToString(signature) == "0102030405", nil
+}
+
+func TestEthereumSignIn(t *testing.T) {
+	t.Setenv("SIWE_DOMAIN", "nexus.example")
+	t.Setenv("SIWE_URI", "https://nexus.example/")
+	t.Setenv("SIWE_CHAIN_IDS", "3338,10143")
+
+	safeMessage := func(chainID int64) string {
+		m, err := siwe.Parse(strings.ReplaceAll(siweMessageSigned, ethereumWallet, safeWallet))
+		if err != nil {
+			t.Fatal(err)
+		}
+		m.ChainID = chainID
+		return m.String()
+	}
+
+	tests := []struct {
+		name        string
+		login       Login
+		wantErr     error
+		challengeId string
+	}{
+		{
+			name:  "siwe message",
+			login: Login{WalletAddress: ethereumWallet, Message: siweMessageSigned, Signature: siweSignature},
+		},
+		{
+			name:    "siwe message of another domain",
+			login:   Login{WalletAddress: ethereumWallet, Message: strings.Replace(siweMessageSigned, "nexus.example", "evil.example", 1), Signature: siweSignature},
+			wantErr: ErrMessageMismatch,
+		},
+		{
+			name:        "siwe message of another challenge",
+			login:       Login{WalletAddress: ethereumWallet, Message: siweMessageSigned, Signature: siweSignature},
+			challengeId: otherChallenge,
+			wantErr:     ErrMessageMismatch,
+		},
+		{
+			name:    "siwe message of another wallet",
+			login:   Login{WalletAddress: safeWallet, Message: siweMessageSigned, Signature: siweSignature},
+			wantErr: ErrMessageMismatch,
+		},
+		{
+			name:  "contract wallet",
+			login: Login{WalletAddress: safeWallet, Signature: safeSignature},
+		},
+		{
+			name:  "contract wallet siwe message",
+			login: Login{WalletAddress: safeWallet, Message: safeMessage(3338), Signature: safeSignature},
+		},
+		{
+			name:    "contract wallet siwe message of another chain",
+			login:   Login{WalletAddress: safeWallet, Message: safeMessage(10143), Signature: safeSignature},
+			wantErr: ErrMessageMismatch,
+		},
+		{
+			name:    "contract wallet rejecting the signature",
+			login:   Login{WalletAddress: safeWallet, Signature: siweSignature},
+			wantErr: ErrSignatureMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			login := tt.login
+			login.Eula = testEula
+			login.ChallengeId = testChallengeId
+			if tt.challengeId != "" {
+				login.ChallengeId = tt.challengeId
+			}
+
+			err := EthereumVerifier{Contracts: fakeSafe{}}.Verify(login)
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("Verify() error = %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
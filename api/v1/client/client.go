@@ -2,10 +2,14 @@ package client
 
 import (
 	"net/http"
+	"slices"
+	"time"
 
+	"github.com/NetSepio/nexus/api/v1/middleware"
 	"github.com/NetSepio/nexus/core"
 	"github.com/NetSepio/nexus/model"
 	"github.com/NetSepio/nexus/util"
+	"github.com/NetSepio/nexus/util/pkg/policy"
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
 	"github.com/skip2/go-qrcode"
@@ -13,7 +17,7 @@ import (
 
 // ApplyRoutes applies router to gin Route
 func ApplyRoutes(r *gin.RouterGroup) {
-	g := r.Group("/client")
+	g := r.Group("/client", middleware.Scope(policy.ScopeClientsWrite))
 	{
 		g.GET("", readClients)
 		g.GET("/:id", readClient)
@@ -21,9 +25,26 @@ func ApplyRoutes(r *gin.RouterGroup) {
 		g.PATCH("/:id", updateClient)
 		g.DELETE("/:id", deleteClient)
 		g.GET("/:id/config", configClient)
+		g.POST("/:id/reset", middleware.Require(policy.ScopeNodeAdmin), resetClientUsage)
+		g.POST("/:id/topup", middleware.Require(policy.ScopeNodeAdmin), topUpClient)
+		g.POST("/:id/rotate/psk", rotateClientPresharedKey)
+		g.POST("/:id/rotate/keypair", rotateClientKeypair)
 	}
 }
 
+// TopUp request body for adding bytes to the client monthly allowance
+type TopUp struct {
+	Bytes int64 `json:"bytes" binding:"required,gt=0"`
+}
+
+// Rotation request body for staging new client keys, both fields are optional
+type Rotation struct {
+	// PublicKey submitted by the client, a keypair is generated when empty
+	PublicKey string `json:"publicKey"`
+	// Overlap before the new keys are used, such as 10m, defaults to KEY_ROTATION_OVERLAP
+	Overlap string `json:"overlap"`
+}
+
 // swagger:route POST /client Client createClient
 //
 // Create client
@@ -33,6 +54,7 @@ func ApplyRoutes(r *gin.RouterGroup) {
 //  201: clientSucessResponse
 //  400: badRequestResponse
 //	401: unauthorizedResponse
+//	403: forbiddenResponse
 //  500: serverErrorResponse
 
 func registerClient(c *gin.Context) {
@@ -47,14 +69,27 @@ func registerClient(c *gin.Context) {
 		return
 	}
 
+	// the client belongs to the wallet of the token, only admins register clients for others
+	principal := middleware.Principal(c)
+	if !principal.IsAdmin() && data.WalletAddress != "" && !policy.SameWallet(data.WalletAddress, principal.Wallet) {
+		middleware.Forbidden(c, "walletAddress must be the wallet of the token")
+		return
+	}
+	if !principal.IsAdmin() {
+		// limits and pools are set by admins only
+		core.KeepClientLimits(&data, &model.Client{})
+	}
+	data.CreatedBy = principal.Wallet
+	data.UpdatedBy = principal.Wallet
+
 	client, err := core.RegisterClient(&data)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"err": err,
 		}).Error("failed to create client")
 
-		response := core.MakeErrorResponse(500, err.Error(), nil, nil, nil)
-		c.JSON(http.StatusInternalServerError, response)
+		response := core.MakeErrorResponse(core.ErrorStatus(err), err.Error(), nil, nil, nil)
+		c.JSON(int(response.Status), response)
 		return
 	}
 	server, err := core.ReadServer()
@@ -64,7 +99,8 @@ func registerClient(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, response)
 		return
 	}
-	response := core.MakeSucessResponse(201, "client created", server, client, nil)
+	// clients only need the public part of the server
+	response := core.MakeSucessResponse(201, "client created", core.PublicServer(server), client, nil)
 
 	c.JSON(http.StatusOK, response)
 }
@@ -79,18 +115,11 @@ func registerClient(c *gin.Context) {
 //	 200: clientSucessResponse
 //	 400: badRequestResponse
 //		401: unauthorizedResponse
+//		403: forbiddenResponse
 //	 500: serverErrorResponse
 func readClient(c *gin.Context) {
-	id := c.Param("id")
-
-	client, err := core.ReadClient(id)
-	if err != nil {
-		log.WithFields(log.Fields{
-			"err": err,
-		}).Error("failed to read client")
-
-		response := core.MakeErrorResponse(500, err.Error(), nil, nil, nil)
-		c.JSON(http.StatusInternalServerError, response)
+	client, ok := authorizeClient(c, c.Param("id"), false)
+	if !ok {
 		return
 	}
 
@@ -109,6 +138,7 @@ func readClient(c *gin.Context) {
 //	 200: clientSucessResponse
 //	 400: badRequestResponse
 //		401: unauthorizedResponse
+//		403: forbiddenResponse
 //	 500: serverErrorResponse
 func updateClient(c *gin.Context) {
 	var data model.Client
@@ -123,14 +153,29 @@ func updateClient(c *gin.Context) {
 		return
 	}
 
+	current, ok := authorizeClient(c, id, true)
+	if !ok {
+		return
+	}
+	principal := middleware.Principal(c)
+	if !principal.IsAdmin() {
+		if data.WalletAddress != current.WalletAddress && data.WalletAddress != "" && !policy.SameWallet(data.WalletAddress, principal.Wallet) {
+			middleware.Forbidden(c, "walletAddress must be the wallet of the token")
+			return
+		}
+		// limits and pools are set by admins only
+		core.KeepClientLimits(&data, current)
+	}
+	data.UpdatedBy = principal.Wallet
+
 	client, err := core.UpdateClient(id, &data)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"err": err,
 		}).Error("failed to update client")
 
-		response := core.MakeErrorResponse(500, err.Error(), nil, nil, nil)
-		c.JSON(http.StatusInternalServerError, response)
+		response := core.MakeErrorResponse(core.ErrorStatus(err), err.Error(), nil, nil, nil)
+		c.JSON(int(response.Status), response)
 		return
 	}
 
@@ -149,16 +194,20 @@ func updateClient(c *gin.Context) {
 //	 200: sucessResponse
 //	 400: badRequestResponse
 //		401: unauthorizedResponse
+//		403: forbiddenResponse
 //	 500: serverErrorResponse
 func deleteClient(c *gin.Context) {
 	id := c.Param("id")
+	if _, ok := authorizeClient(c, id, true); !ok {
+		return
+	}
 	err := core.DeleteClient(id)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"err": err,
 		}).Error("failed to remove client")
-		response := core.MakeErrorResponse(500, err.Error(), nil, nil, nil)
-		c.JSON(http.StatusInternalServerError, response)
+		response := core.MakeErrorResponse(core.ErrorStatus(err), err.Error(), nil, nil, nil)
+		c.JSON(int(response.Status), response)
 		return
 	}
 
@@ -175,6 +224,7 @@ func deleteClient(c *gin.Context) {
 //	 200: clientsSucessResponse
 //	 400: badRequestResponse
 //		401: unauthorizedResponse
+//		403: forbiddenResponse
 //	 500: serverErrorResponse
 func readClients(c *gin.Context) {
 	clients, err := core.ReadClients()
@@ -188,12 +238,23 @@ func readClients(c *gin.Context) {
 		return
 	}
 
+	// admins and read-only wallets see every client, tenants only their own
+	principal := middleware.Principal(c)
+	if !principal.ReadsAll() {
+		clients = slices.DeleteFunc(clients, func(client *model.Client) bool {
+			return !core.OwnsClient(principal.Wallet, client)
+		})
+	}
+
 	response := core.MakeSucessResponse(200, "clients details", nil, nil, clients)
 
 	c.JSON(http.StatusOK, response)
 }
 
 func configClient(c *gin.Context) {
+	if _, ok := authorizeClient(c, c.Param("id"), false); !ok {
+		return
+	}
 	configData, err := core.ReadClientConfig(c.Param("id"))
 	if err != nil {
 		log.WithFields(log.Fields{
@@ -222,3 +283,195 @@ func configClient(c *gin.Context) {
 	c.Data(http.StatusOK, "image/png", png)
 
 }
+
+// swagger:route POST /client/{id}/reset Client resetClientUsage
+//
+// # Reset client usage
+//
+// Zero the daily and monthly usage of the client, re-enabling it when it was disabled for its quota.
+// responses:
+//
+//	 200: clientSucessResponse
+//	 400: badRequestResponse
+//		401: unauthorizedResponse
+//		403: forbiddenResponse
+//	 500: serverErrorResponse
+func resetClientUsage(c *gin.Context) {
+	client, err := core.ResetClientUsage(c.Param("id"))
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Error("failed to reset client usage")
+
+		response := core.MakeErrorResponse(core.ErrorStatus(err), err.Error(), nil, nil, nil)
+		c.JSON(int(response.Status), response)
+		return
+	}
+
+	response := core.MakeSucessResponse(200, "client usage reset", nil, client, nil)
+
+	c.JSON(http.StatusOK, response)
+}
+
+// swagger:route POST /client/{id}/topup Client topUpClient
+//
+// # Top up client
+//
+// Add bytes to the client allowance for the current month.
+// responses:
+//
+//	 200: clientSucessResponse
+//	 400: badRequestResponse
+//		401: unauthorizedResponse
+//		403: forbiddenResponse
+//	 500: serverErrorResponse
+func topUpClient(c *gin.Context) {
+	var data TopUp
+	if err := c.ShouldBindJSON(&data); err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Error("failed to bind")
+
+		response := core.MakeErrorResponse(400, err.Error(), nil, nil, nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	client, err := core.TopUpClient(c.Param("id"), data.Bytes)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Error("failed to top up client")
+
+		response := core.MakeErrorResponse(core.ErrorStatus(err), err.Error(), nil, nil, nil)
+		c.JSON(int(response.Status), response)
+		return
+	}
+
+	response := core.MakeSucessResponse(200, "client topped up", nil, client, nil)
+
+	c.JSON(http.StatusOK, response)
+}
+
+// swagger:route POST /client/{id}/rotate/psk Client rotateClientPresharedKey
+//
+// # Rotate client preshared key
+//
+// Stage a new preshared key for the client, used once the overlap window elapsed.
+// responses:
+//
+//	 200: clientSucessResponse
+//	 400: badRequestResponse
+//		401: unauthorizedResponse
+//		403: forbiddenResponse
+//	 500: serverErrorResponse
+func rotateClientPresharedKey(c *gin.Context) {
+	if _, ok := authorizeClient(c, c.Param("id"), true); !ok {
+		return
+	}
+	_, overlap, ok := bindRotation(c)
+	if !ok {
+		return
+	}
+
+	client, err := core.RotateClientPresharedKey(c.Param("id"), middleware.Wallet(c), overlap)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Error("failed to rotate client preshared key")
+
+		response := core.MakeErrorResponse(core.ErrorStatus(err), err.Error(), nil, nil, nil)
+		c.JSON(int(response.Status), response)
+		return
+	}
+
+	response := core.MakeSucessResponse(200, "client preshared key rotated", nil, client, nil)
+
+	c.JSON(http.StatusOK, response)
+}
+
+// swagger:route POST /client/{id}/rotate/keypair Client rotateClientKeypair
+//
+// # Rotate client keypair
+//
+// Stage a new public key for the client, used once the overlap window elapsed. When no public key
+// is submitted a keypair is generated and its private key is only returned in this response.
+// responses:
+//
+//	 200: clientSucessResponse
+//	 400: badRequestResponse
+//		401: unauthorizedResponse
+//		403: forbiddenResponse
+//	 500: serverErrorResponse
+func rotateClientKeypair(c *gin.Context) {
+	if _, ok := authorizeClient(c, c.Param("id"), true); !ok {
+		return
+	}
+	data, overlap, ok := bindRotation(c)
+	if !ok {
+		return
+	}
+
+	client, err := core.RotateClientKeypair(c.Param("id"), data.PublicKey, middleware.Wallet(c), overlap)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Error("failed to rotate client keypair")
+
+		response := core.MakeErrorResponse(core.ErrorStatus(err), err.Error(), nil, nil, nil)
+		c.JSON(int(response.Status), response)
+		return
+	}
+
+	response := core.MakeSucessResponse(200, "client keypair rotated", nil, client, nil)
+
+	c.JSON(http.StatusOK, response)
+}
+
+// bindRotation reads the optional rotation body, it writes the error response itself
+func bindRotation(c *gin.Context) (Rotation, time.Duration, bool) {
+	var data Rotation
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&data); err != nil {
+			log.WithFields(log.Fields{
+				"err": err,
+			}).Error("failed to bind")
+
+			response := core.MakeErrorResponse(400, err.Error(), nil, nil, nil)
+			c.JSON(http.StatusBadRequest, response)
+			return data, 0, false
+		}
+	}
+
+	overlap, err := core.ParseOverlap(data.Overlap)
+	if err != nil {
+		response := core.MakeErrorResponse(400, err.Error(), nil, nil, nil)
+		c.JSON(http.StatusBadRequest, response)
+		return data, 0, false
+	}
+
+	return data, overlap, true
+}
+
+// authorizeClient reads the client when the token may read it, or manage it when manage is set,
+// it writes the error response itself
+func authorizeClient(c *gin.Context, id string, manage bool) (*model.Client, bool) {
+	client, err := core.ReadClient(id)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Error("failed to read client")
+
+		response := core.MakeErrorResponse(core.ErrorStatus(err), err.Error(), nil, nil, nil)
+		c.JSON(int(response.Status), response)
+		return nil, false
+	}
+
+	principal := middleware.Principal(c)
+	if (manage && !core.CanManageClient(principal, client)) || (!manage && !core.CanReadClient(principal, client)) {
+		middleware.Forbidden(c, "client belongs to another wallet")
+		return nil, false
+	}
+
+	return client, true
+}
@@ -72,6 +72,20 @@ type UnauthorizedResponse struct {
 	}
 }
 
+// swagger:response forbiddenResponse
+// Response when the wallet of the token may not access the resource.
+type ForbiddenResponse struct {
+	// in:body
+	Body struct {
+		// example: 403
+		Status int64
+		// example: false
+		Sucess bool
+		// example: client belongs to another wallet
+		Error string
+	}
+}
+
 // swagger:response serverErrorResponse
 // Response when the operation failed with Server Error.
 type ServerErrorResponse struct {
@@ -86,7 +100,7 @@ type ServerErrorResponse struct {
 	}
 }
 
-// swagger:parameters readClient updateClient deleteClient configClient emailClient
+// swagger:parameters readClient updateClient deleteClient configClient emailClient resetClientUsage topUpClient rotateClientPresharedKey rotateClientKeypair
 type ClientIDParam struct {
 	//The Identifier of the Client
 	// in: path
@@ -100,6 +114,20 @@ type ClientCreateReqparam struct {
 	Body ClientReq `json:"client"`
 }
 
+// swagger:parameters topUpClient
+type ClientTopUpReqparam struct {
+	// Requestbody used to add bytes to the client monthly allowance.
+	// in: body
+	Body TopUpReq `json:"topup"`
+}
+
+// swagger:parameters rotateClientPresharedKey rotateClientKeypair
+type ClientRotationReqparam struct {
+	// Optional requestbody used to stage new client keys.
+	// in: body
+	Body RotationReq `json:"rotation"`
+}
+
 // swagger:parameters updateClient
 type ClientUpdateReqparam struct {
 	// Requestbody  used for create and update client operations.
@@ -134,10 +162,10 @@ type Client struct {
 	//IP addresses allowed to connect
 	// example: ["0.0.0.0/0","::/0"]
 	AllowedIPs []string `json:"allowedIPs"`
-	//Address range client must will assigned
-	// example: ["10.0.0.2/32"]
+	//Addresses assigned to the client, one per server network
+	// example: ["10.0.0.2/32","fd9f::1/128"]
 	Address []string `json:"address"`
-	//Private key for the client
+	//Private key generated by the node, only returned when the client is created
 	// example: KFOyCoR9Eq+LpqT9VzJCilXYmFwhMFw7UDkdRRxoWVg=
 	PrivateKey string `json:"privateKey"`
 	//Public key for the client
@@ -155,6 +183,30 @@ type Client struct {
 	//Time the client is last updated
 	// example: 1642409076544
 	Updated int64 `json:"updated"`
+	//Upload rate limit in kbit/s, zero means unlimited
+	// example: 10240
+	UploadRate int64 `json:"uploadRate"`
+	//Download rate limit in kbit/s, zero means unlimited
+	// example: 51200
+	DownloadRate int64 `json:"downloadRate"`
+	//Daily quota in bytes, zero means unlimited
+	// example: 1073741824
+	DailyQuota int64 `json:"dailyQuota"`
+	//Monthly quota in bytes, zero means unlimited
+	// example: 107374182400
+	MonthlyQuota int64 `json:"monthlyQuota"`
+	//Expiry time in unix milliseconds, zero means never
+	// example: 1767225600000
+	ExpiresAt int64 `json:"expiresAt"`
+	//Bytes used today
+	// example: 52428800
+	DailyUsage int64 `json:"dailyUsage"`
+	//Bytes used this month
+	// example: 52428800
+	MonthlyUsage int64 `json:"monthlyUsage"`
+	//Why the node disabled the client, quota or expired
+	// example: quota
+	DisabledReason string `json:"disabledReason"`
 }
 
 // swagger:model
@@ -180,10 +232,15 @@ type ClientReq struct {
 	// required: true
 	// example: ["0.0.0.0/0","::/0"]
 	AllowedIPs []string `json:"allowedIPs"`
-	//Address range client must will assigned
-	// required: true
-	// example: ["10.0.0.0/24"]
+	//Networks to allocate from or /32 and /128 addresses to reserve, one address per server network is assigned when empty
+	// example: ["10.0.0.0/24","fd9f::10:0:0:0/64"]
 	Address []string `json:"address"`
+	//Address pool to allocate from, defaults to the first pool sharing a tag with the client
+	// example: tenant-a
+	Pool string `json:"pool"`
+	//WireGuard public key of the client, the node generates a keypair when empty
+	// example: YeT/lG9L4AeYOHNrkohnmXfljx3/JgThulskllayxi4=
+	PublicKey string `json:"publicKey"`
 	//Denoting person creates the client
 	// required: true
 	// example: jonsnow@mail.com
@@ -231,7 +288,7 @@ type ClientUpdateReq struct {
 	// required: true
 	// example: ["10.0.0.2/32"]
 	Address []string `json:"address"`
-	//Private key for the client
+	//Ignored, the private key is never stored
 	// example: KFOyCoR9Eq+LpqT9VzJCilXYmFwhMFw7UDkdRRxoWVg=
 	PrivateKey string `json:"privateKey"`
 	//Public key for the client
@@ -251,3 +308,23 @@ type ClientUpdateReq struct {
 	// example: 1642409076544
 	Updated int64 `json:"updated"`
 }
+
+// swagger:model
+// model for client top-up.
+type TopUpReq struct {
+	//Bytes added to the allowance of the current month
+	// required: true
+	// example: 10737418240
+	Bytes int64 `json:"bytes"`
+}
+
+// swagger:model
+// model for staging new client keys.
+type RotationReq struct {
+	//Public key submitted by the client for keypair rotation, a keypair is generated when empty
+	// example: YeT/lG9L4AeYOHNrkohnmXfljx3/JgThulskllayxi4=
+	PublicKey string `json:"publicKey"`
+	//Time before the new keys are used, defaults to KEY_ROTATION_OVERLAP
+	// example: 10m
+	Overlap string `json:"overlap"`
+}
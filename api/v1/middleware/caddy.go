@@ -1,135 +1,144 @@
 package middleware
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
+	"net"
+	"net/http"
+	"net/url"
 	"os"
-	// "os/exec"
-	"path/filepath"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
 
-	"github.com/NetSepio/nexus/api/v1/service/template"
 	"github.com/NetSepio/nexus/api/v1/service/util"
 	"github.com/NetSepio/nexus/model"
+	"github.com/NetSepio/nexus/storage"
+	"github.com/NetSepio/nexus/util/pkg/dnsprovider"
+	"github.com/NetSepio/nexus/util/pkg/policy"
 )
 
-// IsValid check if model is valid
-func IsValidService(name string, port int, ipAddress string) (int, string, error) {
-	// Check if the name is empty
-	fmt.Printf("Checking service name: %s, port: %d\n", name, port)
-	if name == "" {
-		fmt.Println("Service name is empty")
-		return -1, "Services Name is required", nil
+var (
+	// ErrInvalidService is returned when a service fails validation
+	ErrInvalidService = errors.New("invalid service")
+	// ErrServiceExists is returned when the name or domain of a service is taken
+	ErrServiceExists = errors.New("service already exists")
+	// ErrUpstreamInUse is returned when another service proxies to the same ip address and port
+	ErrUpstreamInUse = errors.New("port and ip address combination already in use")
+	// ErrServiceNotFound is returned when no service has the name
+	ErrServiceNotFound = errors.New("service doesn't exist")
+	// ErrServiceLimit is returned when the owner reached the number of services of a wallet
+	ErrServiceLimit = errors.New("service limit of the wallet reached")
+)
+
+// ServiceErrorStatus returns the HTTP status of a service error, conflicts are 409
+func ServiceErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrInvalidService):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrServiceExists), errors.Is(err, ErrUpstreamInUse):
+		return http.StatusConflict
+	case errors.Is(err, ErrServiceNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrServiceLimit):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
 	}
+}
 
-	// Check the name field length
-	fmt.Printf("Service name length: %d\n", len(name))
-	if len(name) < 4 || len(name) > 50 {
-		fmt.Println("Service name length is invalid")
-		return -1, "Services Name field must be between 4-12 chars", nil
+// IsValid check if model is valid, err is a service error when the name or upstream is taken
+func IsValidService(service model.Service) (int, string, error) {
+	if msg := validateService(service); msg != "" {
+		return -1, msg, nil
 	}
 
 	// Read existing services
-	fmt.Println("Reading web services...")
 	Services, err := ReadServices()
 	if err != nil {
-		if err.Error() == "caddy file is empty while reading file" {
-			fmt.Println("Caddy file is empty, proceeding to create a new Services")
-		} else {
-			fmt.Printf("Error reading web services: %v\n", err)
-			return -1, "", err
-		}
-	} else {
-		fmt.Printf("Read web services successfully: %+v\n", Services)
+		return -1, "", err
 	}
 
 	// Check if the name or port is already in use
-	if Services != nil {
-		for _, service := range Services.Services {
-			fmt.Printf("Checking service: %+v\n", service)
-			if service.Name == name {
-				fmt.Println("Service name already exists")
-				return -1, "Service Already exists", nil
-			} else if service.IpAddress == ipAddress && service.Port == strconv.Itoa(port) {
-				fmt.Println("Port and IP address combination is already in use")
-				return -1, "Port and IP address combination already in use", nil
-			}
-		}
+	if err := serviceConflict(Services.Services, service, ""); err != nil {
+		return -1, err.Error(), err
 	}
 
-	// Validate the format of the name
-	// if !util.IsLetter(name) {
-	// 	fmt.Println("Service name is not alphanumeric")
-	// 	return -1, "Services Name should be Alphanumeric", nil
-	// }
-
-	fmt.Println("Service name and port are valid")
 	return 1, "", nil
 }
 
-// ReadServices fetches all the Web Tunnel services
-func ReadServices() (*model.ServicesList, error) {
-	// Get the CADDY_CONF_DIR environment variable
-	caddyConfDir := os.Getenv("CADDY_CONF_DIR")
-	if caddyConfDir == "" {
-		return nil, fmt.Errorf("CADDY_CONF_DIR environment variable is not set")
+// validateService returns why service is invalid, empty when it is valid
+func validateService(service model.Service) string {
+	port, err := strconv.Atoi(service.Port)
+	if err != nil || port < 1 || port > 65535 {
+		return "Invalid Port"
 	}
 
-	// Ensure the directory exists
-	if _, err := os.Stat(caddyConfDir); os.IsNotExist(err) {
-		err = os.MkdirAll(caddyConfDir, 0755) // Create the directory with proper permissions
-		if err != nil {
-			return nil, fmt.Errorf("failed to create directory %s: %w", caddyConfDir, err)
-		}
+	// the name is the subdomain of the service
+	if service.Name == "" {
+		return "Services Name is required"
+	}
+	if !isDNSLabel(service.Name) {
+		return "Services Name must be a DNS label of letters, digits and hyphens, at most 63 chars"
+	}
+	if reservedName(service.Name) {
+		return "Services Name is reserved"
 	}
 
-	// Construct the file path
-	filePath := filepath.Join(caddyConfDir, "caddy.json")
-	fmt.Println("filePath : ", filePath)
+	// Validate the routing, auth, tls and rate limit options
+	return validateServiceOptions(service)
+}
 
-	// Check if the file exists
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		// Create the file at the specified location
-		file, err := os.Create(filePath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create file at %s: %w", filePath, err)
+// serviceConflict returns the conflict of service with services, skipping the service called except
+func serviceConflict(services []model.Service, service model.Service, except string) error {
+	for _, s := range services {
+		if except != "" && strings.EqualFold(s.Name, except) {
+			continue
 		}
-		defer file.Close()
-
-		// Initialize with empty JSON structure
-		if _, writeErr := file.WriteString(`{"services": []}`); writeErr != nil {
-			return nil, fmt.Errorf("failed to write initial JSON to file: %w", writeErr)
+		if strings.EqualFold(s.Name, service.Name) || (service.Domain != "" && strings.EqualFold(s.Domain, service.Domain)) {
+			return fmt.Errorf("%w: %s", ErrServiceExists, service.Name)
+		}
+		if s.IpAddress == service.IpAddress && s.Port == service.Port {
+			return fmt.Errorf("%w: %s", ErrUpstreamInUse, net.JoinHostPort(service.IpAddress, service.Port))
 		}
 	}
+	return nil
+}
 
-	// Open the file
-	file, err := os.Open(filePath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open file: %w", err)
-	}
-	defer file.Close()
+// CanManageService reports whether the principal may update or delete service, services
+// without an owner are managed by admins only
+func CanManageService(p policy.Principal, service *model.Service) bool {
+	return p.IsAdmin() || (service.Owner != "" && policy.SameWallet(service.Owner, p.Wallet))
+}
 
-	// Read the file contents
-	b, err := io.ReadAll(file)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read file: %w", err)
+// ServiceLimit returns the number of services the wallet of the principal may own, 0 for no limit
+func ServiceLimit(p policy.Principal) int {
+	if p.IsAdmin() {
+		return 0
 	}
-
-	// Handle empty file
-	if len(b) == 0 {
-		fmt.Println("Caddy file is empty while reading file", &model.ServicesList{Services: []model.Service{}})
-		return &model.ServicesList{Services: []model.Service{}}, nil
+	limit, err := strconv.Atoi(os.Getenv("MAX_SERVICES_PER_WALLET"))
+	if err != nil || limit < 0 {
+		return defaultServiceLimit
 	}
+	return limit
+}
 
-	// Parse the JSON contents
-	var Services model.ServicesList
-	err = json.Unmarshal(b, &Services)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+// defaultServiceLimit is the number of services of a wallet when MAX_SERVICES_PER_WALLET is unset
+const defaultServiceLimit = 5
+
+// ServiceDomain returns the domain of a service, its name under the host of DOMAIN
+func ServiceDomain(name string) string {
+	domain := os.Getenv("DOMAIN")
+	if u, err := url.Parse(domain); err == nil && u.Host != "" {
+		domain = u.Hostname()
 	}
+	return strings.ToLower(name) + "." + strings.Trim(domain, "./")
+}
 
-	return &Services, nil
+// ReadServices fetches all the Web Tunnel services
+func ReadServices() (*model.ServicesList, error) {
+	return storage.Get().ReadServices()
 }
 
 // ReadWebTunnel fetches a Web Tunnel
@@ -141,13 +150,8 @@ func ReadService(tunnelName string) (*model.Service, error) {
 
 	var data model.Service
 	for _, Service := range Services.Services {
-		// print all the services
-		if Service.Name == tunnelName {
-			data.Name = Service.Name
-			data.Port = Service.Port
-			data.CreatedAt = Service.CreatedAt
-			data.Domain = Service.Domain
-			data.Status = Service.Status
+		if strings.EqualFold(Service.Name, tunnelName) {
+			data = Service
 			break
 		}
 	}
@@ -155,51 +159,174 @@ func ReadService(tunnelName string) (*model.Service, error) {
 	return &data, nil
 }
 
-func AddServices(newService model.Service) error {
+// servicesMu serializes read-modify-write cycles of the services list and the Caddyfile
+var servicesMu sync.Mutex
+
+// AddServices saves a service and configures the proxy with it, limit is the number of
+// services its owner may have, 0 for no limit
+func AddServices(newService model.Service, limit int) error {
+	servicesMu.Lock()
+	defer servicesMu.Unlock()
+
 	// Read existing services
 	servicesList, err := ReadServices()
 	if err != nil {
-		if err.Error() == "caddy file is empty while reading file" {
-			util.LogError("Caddy file is empty, proceeding to create a new Services", nil)
-			servicesList = &model.ServicesList{Services: []model.Service{}} // Initialize an empty Services struct
-		} else {
-			return err
+		return err
+	}
+
+	// check again under the lock, a concurrent request may have taken the name
+	if err := serviceConflict(servicesList.Services, newService, ""); err != nil {
+		return err
+	}
+	if limit > 0 && newService.Owner != "" {
+		owned := 0
+		for _, service := range servicesList.Services {
+			if policy.SameWallet(service.Owner, newService.Owner) {
+				owned++
+			}
+		}
+		if owned >= limit {
+			return fmt.Errorf("%w: %d", ErrServiceLimit, limit)
 		}
 	}
 
-	// Ensure the services list is initialized
-	if servicesList == nil || servicesList.Services == nil {
-		servicesList = &model.ServicesList{Services: []model.Service{}}
+	if err := hashServicePassword(&newService); err != nil {
+		return err
 	}
+	newService.Health = nil
 
 	// Append the new service
+	previous := slices.Clone(servicesList.Services)
 	servicesList.Services = append(servicesList.Services, newService)
 
-	// Marshal the updated services list to JSON
-	updatedJSON, err := json.MarshalIndent(servicesList, "", "   ")
-	if err != nil {
-		util.LogError("JSON Marshal error: ", err)
-		return err
+	return applyServices(servicesList, previous)
+}
+
+// ServiceChanges is an update of a service, shared by the REST and gRPC routes. Empty strings and
+// nil fields keep their value, the options named in Clear are removed.
+type ServiceChanges struct {
+	IpAddress     string
+	Port          string
+	Paths         []string
+	Upstreams     []string
+	LoadBalancing string
+	HealthCheck   *model.ServiceHealthCheck
+	Headers       *model.ServiceHeaders
+	Auth          *model.ServiceAuth
+	TLS           *model.ServiceTLS
+	RateLimit     *model.ServiceRateLimit
+	// Clear names the options to remove: paths, upstreams, loadBalancing, healthCheck, headers,
+	// auth, tls and rateLimit
+	Clear []string
+}
+
+// MergeService returns service with changes applied, the options are cleared before the new
+// values are set
+func MergeService(service model.Service, changes ServiceChanges) (model.Service, error) {
+	for _, option := range changes.Clear {
+		switch option {
+		case "paths":
+			service.Paths = nil
+		case "upstreams":
+			service.Upstreams = nil
+		case "loadBalancing":
+			service.LoadBalancing = ""
+		case "healthCheck":
+			service.HealthCheck = nil
+		case "headers":
+			service.Headers = nil
+		case "auth":
+			service.Auth = nil
+		case "tls":
+			service.TLS = nil
+		case "rateLimit":
+			service.RateLimit = nil
+		default:
+			return service, fmt.Errorf("%w: %s cannot be cleared", ErrInvalidService, option)
+		}
 	}
 
-	//to save/update in /etc/caddy and service_conf_dir
-	err = SaveToFile(updatedJSON)
-	if err != nil {
-		util.LogError("failed to save/update data in config files: ", err)
-		return err
+	if changes.IpAddress != "" {
+		service.IpAddress = changes.IpAddress
+	}
+	if changes.Port != "" {
+		service.Port = changes.Port
+	}
+	if changes.Paths != nil {
+		service.Paths = changes.Paths
+	}
+	if changes.Upstreams != nil {
+		service.Upstreams = changes.Upstreams
+	}
+	if changes.LoadBalancing != "" {
+		service.LoadBalancing = changes.LoadBalancing
+	}
+	if changes.HealthCheck != nil {
+		service.HealthCheck = changes.HealthCheck
+	}
+	if changes.Headers != nil {
+		service.Headers = changes.Headers
+	}
+	if changes.Auth != nil {
+		service.Auth = changes.Auth
+	}
+	if changes.TLS != nil {
+		service.TLS = changes.TLS
+	}
+	if changes.RateLimit != nil {
+		service.RateLimit = changes.RateLimit
 	}
+	return service, nil
+}
 
-	// Update the Caddy configuration
-	err = UpdateCaddyConfig()
+// UpdateService replaces the service called name with service, keeping its name, domain, owner
+// and creation time, a basic auth without password keeps the current one
+func UpdateService(name string, service model.Service) (*model.Service, error) {
+	servicesMu.Lock()
+	defer servicesMu.Unlock()
+
+	servicesList, err := ReadServices()
 	if err != nil {
-		util.LogError("Caddy configuration update error: ", err)
-		return err
+		return nil, err
+	}
+	index := slices.IndexFunc(servicesList.Services, func(s model.Service) bool { return strings.EqualFold(s.Name, name) })
+	if index == -1 {
+		return nil, fmt.Errorf("%w: %s", ErrServiceNotFound, name)
 	}
+	current := servicesList.Services[index]
+
+	service.Name = current.Name
+	service.Domain = current.Domain
+	service.Owner = current.Owner
+	service.CreatedAt = current.CreatedAt
+	service.DNSRecord = current.DNSRecord
+	if service.Auth != nil && service.Auth.Type == "basic" && service.Auth.Password == "" &&
+		current.Auth != nil && current.Auth.Type == "basic" {
+		service.Auth.Password = current.Auth.Password
+	}
+	if msg := validateService(service); msg != "" {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidService, msg)
+	}
+	if err := serviceConflict(servicesList.Services, service, current.Name); err != nil {
+		return nil, err
+	}
+	if err := hashServicePassword(&service); err != nil {
+		return nil, err
+	}
+	service.Health = nil
 
-	return nil
+	previous := slices.Clone(servicesList.Services)
+	servicesList.Services[index] = service
+	if err := applyServices(servicesList, previous); err != nil {
+		return nil, err
+	}
+	return &service, nil
 }
 
 func DeleteService(serviceName string) error {
+	servicesMu.Lock()
+	defer servicesMu.Unlock()
+
 	services, err := ReadServices()
 	if err != nil {
 		return err
@@ -207,7 +334,7 @@ func DeleteService(serviceName string) error {
 
 	var updatedServices []model.Service
 	for _, service := range services.Services {
-		if service.Name == serviceName {
+		if strings.EqualFold(service.Name, serviceName) {
 			continue
 		}
 		updatedServices = append(updatedServices, service)
@@ -217,89 +344,119 @@ func DeleteService(serviceName string) error {
 		Services: updatedServices,
 	}
 
-	jsonData, err := json.MarshalIndent(newServices, "", "   ")
+	return applyServices(newServices, services.Services)
+}
+
+// applyServices saves the services and configures the proxy with them, the
+// previous services are saved back when the proxy rejects the new ones. The DNS
+// records of added domains are created first and kept on their service, the ones
+// of removed services are deleted once the proxy runs without them
+func applyServices(services *model.ServicesList, previous []model.Service) error {
+	records, err := dnsprovider.FromEnv()
+	if err != nil {
+		util.LogError("DNS provider configuration error: ", err)
+		return err
+	}
+	added, removed := changedDomains(previous, services.Services)
+	created, err := createRecords(records, services.Services, added)
 	if err != nil {
-		util.LogError("JSON Marshal error: ", err)
 		return err
 	}
 
-	err = SaveToFile(jsonData)
+	//to save/update in /etc/caddy and service_conf_dir
+	err = storage.Get().WriteServices(services)
 	if err != nil {
 		util.LogError("failed to save/update data in config files: ", err)
+		deleteRecords(records, created)
 		return err
 	}
 
-	err = UpdateCaddyConfig()
+	// Update the Caddy configuration
+	err = Proxy().Apply(services.Services)
 	if err != nil {
+		util.LogError("Caddy configuration update error: ", err)
+		if err := storage.Get().WriteServices(&model.ServicesList{Services: previous}); err != nil {
+			util.LogError("failed to restore the services: ", err)
+		}
+		deleteRecords(records, created)
 		return err
 	}
 
+	deleteRecords(records, removed)
 	return nil
 }
 
-// UpdateCaddyConfig updates Caddyfile
-func UpdateCaddyConfig() error {
-	Services, err := ReadServices()
-	if err != nil {
-		return err
+// changedDomains returns the services missing from previous by domain, and the ones of previous
+// missing from services
+func changedDomains(previous []model.Service, services []model.Service) (added []string, removed []model.Service) {
+	hasDomain := func(list []model.Service, domain string) bool {
+		return slices.ContainsFunc(list, func(s model.Service) bool { return strings.EqualFold(s.Domain, domain) })
 	}
-
-	path := filepath.Join(os.Getenv("CADDY_CONF_DIR"), os.Getenv("CADDY_INTERFACE_NAME"))
-	if util.FileExists(path) {
-		os.Remove(path)
+	for _, s := range services {
+		if s.Domain != "" && !hasDomain(previous, s.Domain) {
+			added = append(added, s.Domain)
+		}
 	}
-
-	for _, Services := range Services.Services {
-		_, err := template.CaddyConfigTempl(Services)
-		if err != nil {
-			util.LogError("Caddy update error: ", err)
-			return err
+	for _, s := range previous {
+		if s.Domain != "" && !hasDomain(services, s.Domain) {
+			removed = append(removed, s)
 		}
 	}
-
-	return nil
+	return added, removed
 }
 
-func SaveToFile(updatedJSON []byte) error {
-	// Write the updated configuration back to the file
-	caddyConfigPath := filepath.Join(os.Getenv("CADDY_CONF_DIR"), "caddy.json")
-
-	fmt.Println("caddyConfigPath : ", caddyConfigPath)
-	err := util.WriteFile(caddyConfigPath, updatedJSON)
-	if err != nil {
-		util.LogError("File write error: ", err)
-		return err
+// createRecords points the domains of the services with an added domain at the node and keeps
+// the records on the services. Names that already have records are refused, the records
+// created are deleted again when one fails.
+func createRecords(records *dnsprovider.Manager, services []model.Service, domains []string) ([]model.Service, error) {
+	if records == nil {
+		return nil, nil
 	}
-
-	// Write the updated configuration to the SERVICE_CONF_DIR in $HOME
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		util.LogError("Error getting home directory: ", err)
-		return err
+	var created []model.Service
+	for i := range services {
+		if !slices.ContainsFunc(domains, func(d string) bool { return strings.EqualFold(d, services[i].Domain) }) {
+			continue
+		}
+		record, err := records.Create(services[i].Domain)
+		if err != nil {
+			util.LogError("DNS record creation error: ", err)
+			deleteRecords(records, created)
+			if errors.Is(err, dnsprovider.ErrRecordExists) {
+				return nil, fmt.Errorf("%w: %s already has dns records", ErrServiceExists, services[i].Domain)
+			}
+			return nil, err
+		}
+		if record != nil {
+			services[i].DNSRecord = &model.ServiceRecord{Name: record.Name, Type: record.Type, Value: record.Value, TTL: record.TTL}
+			created = append(created, services[i])
+		}
 	}
+	return created, nil
+}
 
-	serviceConfDir := filepath.Join(homeDir, os.Getenv("SERVICE_CONF_DIR"))
-	err = os.MkdirAll(serviceConfDir, 0755) // Ensure the directory exists
-	if err != nil {
-		util.LogError("Error creating SERVICE_CONF_DIR: ", err)
-		return err
+// deleteRecords removes the records the node created for services, failures are logged since
+// the services are already gone
+func deleteRecords(records *dnsprovider.Manager, services []model.Service) {
+	if records == nil {
+		return
 	}
+	for _, service := range services {
+		r := service.DNSRecord
+		if r == nil {
+			continue
+		}
+		if err := records.Delete(dnsprovider.Record{Name: r.Name, Type: r.Type, Value: r.Value, TTL: r.TTL}); err != nil {
+			util.LogError("DNS record deletion error: ", err)
+		}
+	}
+}
 
-	serviceConfigPath := filepath.Join(serviceConfDir, "caddy.json")
-	fmt.Println("serviceConfigPath: ", serviceConfigPath)
-	err = util.WriteFile(serviceConfigPath, updatedJSON)
+// UpdateCaddyConfig configures the proxy with every saved service
+func UpdateCaddyConfig() error {
+	Services, err := ReadServices()
 	if err != nil {
-		util.LogError("File write error for SERVICE_CONF_DIR: ", err)
 		return err
 	}
 
-	// // Restart the Caddy service
-	// cmd := exec.Command("sudo", "systemctl", "restart", "caddy")
-	// err = cmd.Run()
-	// if err != nil {
-	// 	util.LogError("Failed to restart Caddy service: ", err)
-	// 	return err
-	// }
-
-	return nil
+	return Proxy().Apply(Services.Services)
 }
@@ -0,0 +1,413 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/NetSepio/nexus/api/v1/service/template"
+	"github.com/NetSepio/nexus/api/v1/service/util"
+	"github.com/NetSepio/nexus/model"
+	log "github.com/sirupsen/logrus"
+)
+
+// caddyServer is the name of the http server holding the routes of the services when no
+// server of the running configuration listens on :443
+const caddyServer = "nexus"
+
+// caddyRouteID prefixes the @id of the routes of the services
+const caddyRouteID = "nexus-"
+
+// caddyTLSPolicy prefixes the @id of the tls automation policies of the services
+const caddyTLSPolicy = "nexus-tls"
+
+// CaddyAdmin pushes the routes of the services to the Caddy admin API, keeping
+// the rest of the running configuration as it is
+type CaddyAdmin struct {
+	// URL of the admin endpoint, such as http://localhost:2019
+	URL    string
+	Client *http.Client
+	// Fallback applies the services when the admin endpoint cannot be reached
+	Fallback ProxyBackend
+}
+
+// caddyRoute is a route of the http app, matching the domain of one service
+type caddyRoute struct {
+	ID       string           `json:"@id"`
+	Match    []map[string]any `json:"match"`
+	Handle   []map[string]any `json:"handle"`
+	Terminal bool             `json:"terminal"`
+}
+
+// Apply loads the running configuration with the routes of services replaced, and
+// loads the previous configuration back when Caddy rejects it
+func (ca *CaddyAdmin) Apply(services []model.Service) error {
+	previous, err := ca.config()
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && ca.Fallback != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Warn("caddy admin API unreachable, applying the services through the fallback")
+		return ca.Fallback.Apply(services)
+	}
+	if err != nil {
+		return err
+	}
+
+	config := map[string]any{}
+	if err := json.Unmarshal(previous, &config); err != nil {
+		return fmt.Errorf("caddy admin: invalid config: %w", err)
+	}
+	if config == nil {
+		config = map[string]any{}
+	}
+	setCaddyRoutes(config, services)
+
+	body, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	if err := ca.load(body); err != nil {
+		util.LogError("Caddy admin load error, restoring the previous config: ", err)
+		if !bytes.Equal(bytes.TrimSpace(previous), []byte("null")) {
+			if err := ca.load(previous); err != nil {
+				util.LogError("failed to restore the Caddy config: ", err)
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// config returns the running configuration, null when Caddy runs without one
+func (ca *CaddyAdmin) config() ([]byte, error) {
+	resp, err := ca.client().Get(strings.TrimSuffix(ca.URL, "/") + "/config/")
+	if err != nil {
+		return nil, fmt.Errorf("caddy admin: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("caddy admin: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("caddy admin: reading config: %s: %s", resp.Status, bytes.TrimSpace(body))
+	}
+	return body, nil
+}
+
+// load replaces the running configuration, Caddy only switches once the new one started
+func (ca *CaddyAdmin) load(config []byte) error {
+	resp, err := ca.client().Post(strings.TrimSuffix(ca.URL, "/")+"/load", "application/json", bytes.NewReader(config))
+	if err != nil {
+		return fmt.Errorf("caddy admin: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("caddy admin: loading config: %s: %s", resp.Status, bytes.TrimSpace(body))
+	}
+	return nil
+}
+
+func (ca *CaddyAdmin) client() *http.Client {
+	if ca.Client != nil {
+		return ca.Client
+	}
+	return &http.Client{Timeout: 10 * time.Second}
+}
+
+// setCaddyRoutes replaces the routes and tls policies of the services in config. The routes go
+// to the server already listening on :443, such as the srv0 of a Caddyfile, since Caddy refuses
+// two servers on one address, and to a server of their own otherwise.
+func setCaddyRoutes(config map[string]any, services []model.Service) {
+	apps := childMap(config, "apps")
+	servers := childMap(childMap(apps, "http"), "servers")
+	automation := childMap(childMap(apps, "tls"), "automation")
+
+	var policies []any
+	if current, ok := automation["policies"].([]any); ok {
+		for _, policy := range current {
+			if p, ok := policy.(map[string]any); ok && strings.HasPrefix(fmt.Sprint(p["@id"]), caddyTLSPolicy) {
+				continue
+			}
+			policies = append(policies, policy)
+		}
+	}
+	for _, service := range services {
+		policies = append(policies, serviceTLSPolicy(service))
+	}
+	automation["policies"] = policies
+
+	routes := make([]any, 0, len(services))
+	for _, service := range services {
+		routes = append(routes, serviceRoute(service))
+	}
+
+	owner := httpsServer(servers)
+	for name, server := range servers {
+		if s, ok := server.(map[string]any); ok && name != owner {
+			s["routes"] = withoutServiceRoutes(s["routes"])
+		}
+	}
+
+	if owner != "" {
+		delete(servers, caddyServer)
+		// the services come first, the routes of the operator may end with a catch-all
+		server := servers[owner].(map[string]any)
+		server["routes"] = append(routes, withoutServiceRoutes(server["routes"])...)
+		return
+	}
+
+	if len(services) == 0 {
+		delete(servers, caddyServer)
+		return
+	}
+	servers[caddyServer] = map[string]any{
+		"listen": []string{":443"},
+		"routes": routes,
+		"logs":   map[string]any{},
+	}
+}
+
+// httpsServer returns the name of the server other than caddyServer listening on port 443, empty
+// when there is none
+func httpsServer(servers map[string]any) string {
+	names := make([]string, 0, len(servers))
+	for name := range servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		server, ok := servers[name].(map[string]any)
+		if !ok || name == caddyServer {
+			continue
+		}
+		listen, _ := server["listen"].([]any)
+		for _, address := range listen {
+			if strings.HasSuffix(fmt.Sprint(address), ":443") {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// withoutServiceRoutes returns routes without the routes of the services
+func withoutServiceRoutes(routes any) []any {
+	current, _ := routes.([]any)
+	kept := make([]any, 0, len(current))
+	for _, route := range current {
+		if r, ok := route.(map[string]any); ok && strings.HasPrefix(fmt.Sprint(r["@id"]), caddyRouteID) {
+			continue
+		}
+		kept = append(kept, route)
+	}
+	return kept
+}
+
+// serviceRoute returns the route of a service, its handlers run in order: rate limit,
+// authentication, compression and the reverse proxy to its upstreams
+func serviceRoute(service model.Service) caddyRoute {
+	match := map[string]any{"host": []string{service.Domain}}
+	if len(service.Paths) > 0 {
+		paths := make([]string, 0, len(service.Paths))
+		for _, path := range service.Paths {
+			paths = append(paths, template.PathPattern(path))
+		}
+		match["path"] = paths
+	}
+
+	var handle []map[string]any
+	if rl := service.RateLimit; rl != nil {
+		handle = append(handle, map[string]any{
+			"handler": "rate_limit",
+			"rate_limits": map[string]any{
+				service.Name: map[string]any{"key": "{http.request.remote.host}", "max_events": rl.Requests, "window": rl.Window},
+			},
+		})
+	}
+	if auth := service.Auth; auth != nil && auth.Type == "basic" {
+		handle = append(handle, map[string]any{
+			"handler": "authentication",
+			"providers": map[string]any{
+				"http_basic": map[string]any{
+					"accounts": []map[string]any{{"username": auth.Username, "password": base64.StdEncoding.EncodeToString([]byte(auth.Password))}},
+					"hash":     map[string]any{"algorithm": "bcrypt"},
+				},
+			},
+		})
+	} else if auth != nil && auth.Type == "wallet" {
+		// forward_auth, the request continues when the API answers 2xx
+		handle = append(handle, map[string]any{
+			"handler":   "reverse_proxy",
+			"upstreams": []map[string]any{{"dial": template.AuthUpstream()}},
+			"rewrite":   map[string]any{"method": "GET", "uri": template.AuthURI(service.Name)},
+			"handle_response": []map[string]any{{
+				"match":  map[string]any{"status_code": []int{2}},
+				"routes": []map[string]any{{"handle": []map[string]any{{"handler": "vars"}}}},
+			}},
+		})
+	}
+	handle = append(handle, map[string]any{"handler": "encode", "encodings": map[string]any{"gzip": map[string]any{}, "zstd": map[string]any{}}})
+
+	var upstreams []map[string]any
+	for _, upstream := range template.Upstreams(service) {
+		upstreams = append(upstreams, map[string]any{"dial": upstream})
+	}
+	proxy := map[string]any{"handler": "reverse_proxy", "upstreams": upstreams}
+	if service.LoadBalancing != "" {
+		proxy["load_balancing"] = map[string]any{"selection_policy": map[string]any{"policy": service.LoadBalancing}}
+	}
+	if hc := service.HealthCheck; hc != nil {
+		active := map[string]any{"uri": hc.Path}
+		if hc.Interval != "" {
+			active["interval"] = hc.Interval
+		}
+		if hc.Timeout != "" {
+			active["timeout"] = hc.Timeout
+		}
+		if hc.ExpectStatus != 0 {
+			active["expect_status"] = hc.ExpectStatus
+		}
+		proxy["health_checks"] = map[string]any{"active": active}
+	}
+	headers := map[string]any{}
+	if h := service.Headers; h != nil {
+		headers["request"] = headerOps(h.Request)
+		headers["response"] = headerOps(h.Response)
+	}
+	if auth := service.Auth; auth != nil && auth.Type == "wallet" {
+		// the node token of the visitor was checked by forward_auth and must not reach the upstream
+		request, ok := headers["request"].(map[string]any)
+		if !ok {
+			request = headerOps(nil)
+			headers["request"] = request
+		}
+		request["delete"] = append(request["delete"].([]string), "Authorization")
+	}
+	if len(headers) > 0 {
+		proxy["headers"] = headers
+	}
+	handle = append(handle, proxy)
+
+	return caddyRoute{
+		ID:       caddyRouteID + service.Name,
+		Match:    []map[string]any{match},
+		Handle:   handle,
+		Terminal: true,
+	}
+}
+
+// headerOps returns the set and delete operations of header rules, an empty value deletes the header
+func headerOps(rules map[string]string) map[string]any {
+	set := map[string][]string{}
+	var remove []string
+	for name, value := range rules {
+		if value == "" {
+			remove = append(remove, name)
+			continue
+		}
+		set[name] = []string{caddyPlaceholders(value)}
+	}
+	return map[string]any{"set": set, "delete": remove}
+}
+
+// caddyShorthands are the placeholders the Caddyfile expands to the ones of the JSON config
+var caddyShorthands = map[string]string{
+	"dir":                               "http.request.uri.path.dir",
+	"file":                              "http.request.uri.path.file",
+	"host":                              "http.request.host",
+	"hostport":                          "http.request.hostport",
+	"port":                              "http.request.port",
+	"method":                            "http.request.method",
+	"path":                              "http.request.uri.path",
+	"query":                             "http.request.uri.query",
+	"remote":                            "http.request.remote",
+	"remote_host":                       "http.request.remote.host",
+	"remote_port":                       "http.request.remote.port",
+	"scheme":                            "http.request.scheme",
+	"uri":                               "http.request.uri",
+	"uuid":                              "http.request.uuid",
+	"tls_cipher":                        "http.request.tls.cipher_suite",
+	"tls_version":                       "http.request.tls.version",
+	"tls_client_fingerprint":            "http.request.tls.client.fingerprint",
+	"tls_client_issuer":                 "http.request.tls.client.issuer",
+	"tls_client_serial":                 "http.request.tls.client.serial",
+	"tls_client_subject":                "http.request.tls.client.subject",
+	"tls_client_certificate_pem":        "http.request.tls.client.certificate_pem",
+	"tls_client_certificate_der_base64": "http.request.tls.client.certificate_der_base64",
+	"upstream_hostport":                 "http.reverse_proxy.upstream.hostport",
+	"client_ip":                         "http.vars.client_ip",
+}
+
+// caddyShorthandPrefixes are the placeholder prefixes the Caddyfile expands
+var caddyShorthandPrefixes = [][2]string{
+	{"header.", "http.request.header."},
+	{"cookie.", "http.request.cookie."},
+	{"labels.", "http.request.host.labels."},
+	{"path.", "http.request.uri.path."},
+	{"query.", "http.request.uri.query."},
+	{"re.", "http.regexp."},
+	{"vars.", "http.vars."},
+	{"rp.", "http.reverse_proxy."},
+	{"resp.", "http.intercept."},
+	{"err.", "http.error."},
+	{"file_match.", "http.matchers.file."},
+}
+
+var placeholderPattern = regexp.MustCompile(`\{[^{}\s]+\}`)
+
+// caddyPlaceholders replaces the Caddyfile shorthands of value, such as {remote_host}, with the
+// placeholders of the JSON config, so both backends send the same headers
+func caddyPlaceholders(value string) string {
+	return placeholderPattern.ReplaceAllStringFunc(value, func(placeholder string) string {
+		name := placeholder[1 : len(placeholder)-1]
+		if full, ok := caddyShorthands[name]; ok {
+			return "{" + full + "}"
+		}
+		for _, prefix := range caddyShorthandPrefixes {
+			if rest, ok := strings.CutPrefix(name, prefix[0]); ok {
+				return "{" + prefix[1] + rest + "}"
+			}
+		}
+		return placeholder
+	})
+}
+
+// serviceTLSPolicy returns the tls automation policy of the domain of a service
+func serviceTLSPolicy(service model.Service) map[string]any {
+	issuer := map[string]any{"module": "acme", "email": template.TLSEmail(service)}
+	if service.TLS != nil && service.TLS.Issuer == "internal" {
+		issuer = map[string]any{"module": "internal"}
+	} else if service.TLS != nil && service.TLS.CA != "" {
+		issuer["ca"] = service.TLS.CA
+	}
+	return map[string]any{
+		"@id":      caddyTLSPolicy + "-" + service.Name,
+		"subjects": []string{service.Domain},
+		"issuers":  []map[string]any{issuer},
+	}
+}
+
+// childMap returns the object under key, creating it when missing
+func childMap(parent map[string]any, key string) map[string]any {
+	child, ok := parent[key].(map[string]any)
+	if !ok {
+		child = map[string]any{}
+		parent[key] = child
+	}
+	return child
+}
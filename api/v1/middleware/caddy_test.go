@@ -0,0 +1,162 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NetSepio/nexus/model"
+	"github.com/NetSepio/nexus/storage"
+	"github.com/NetSepio/nexus/util/pkg/dnsprovider/dnstest"
+)
+
+func TestAddAndUpdateServices(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("CADDY_CONF_DIR", dir)
+	t.Setenv("SERVICE_CONF_DIR", "services")
+	t.Setenv("STORAGE_BACKEND", "file")
+	t.Setenv("DOMAIN", "node.example.com")
+	t.Setenv("PROXY_BACKEND", "admin")
+	admin := httptest.NewServer(&fakeCaddy{config: []byte("null")})
+	defer admin.Close()
+	t.Setenv("CADDY_ADMIN_URL", admin.URL)
+	dns, err := dnstest.NewServer("node.example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dns.Close()
+	t.Setenv("DNS_PROVIDER", "rfc2136")
+	t.Setenv("DNS_SERVER", dns.Addr)
+	t.Setenv("DNS_ZONE", "node.example.com")
+	t.Setenv("DNS_TARGET", "203.0.113.7")
+	if err := storage.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	owner := "0x8811Ffaa9565B5be4a030f3da4c5F1B9eC1d2177"
+	add := func(name string, port string, limit int) error {
+		return AddServices(model.Service{Name: name, IpAddress: "127.0.0.1", Port: port, Domain: ServiceDomain(name), Owner: owner}, limit)
+	}
+
+	if err := add("app1", "3000", 2); err != nil {
+		t.Fatal(err)
+	}
+	for _, conflict := range []struct {
+		name, port string
+		err        error
+	}{
+		{"APP1", "3005", ErrServiceExists},
+		{"app2", "3000", ErrUpstreamInUse},
+	} {
+		err := add(conflict.name, conflict.port, 2)
+		if !errors.Is(err, conflict.err) || ServiceErrorStatus(err) != http.StatusConflict {
+			t.Errorf("%s: expected a 409 %v, got %v", conflict.name, conflict.err, err)
+		}
+	}
+	if err := add("app2", "3001", 2); err != nil {
+		t.Fatal(err)
+	}
+	if got := dns.Lookup("app2.node.example.com", "A"); len(got) != 1 || got[0] != "203.0.113.7" {
+		t.Errorf("expected an A record of app2, got %v", got)
+	}
+	// a name of the zone with records of its own is not taken over
+	if err := dns.Add("docs.node.example.com. 300 IN A 198.51.100.1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := add("docs", "3003", 0); !errors.Is(err, ErrServiceExists) || ServiceErrorStatus(err) != http.StatusConflict {
+		t.Errorf("expected a 409 for a name with records, got %v", err)
+	}
+	if got := dns.Lookup("docs.node.example.com", "A"); len(got) != 1 || got[0] != "198.51.100.1" {
+		t.Errorf("expected the record of docs kept, got %v", got)
+	}
+	if service, _ := ReadService("docs"); service.Name != "" {
+		t.Errorf("expected docs not added, got %+v", service)
+	}
+	if err := add("app3", "3002", 2); !errors.Is(err, ErrServiceLimit) {
+		t.Errorf("expected ErrServiceLimit, got %v", err)
+	}
+
+	current, _ := ReadService("app1")
+	current.Port = "3001"
+	if _, err := UpdateService("app1", *current); !errors.Is(err, ErrUpstreamInUse) {
+		t.Errorf("expected ErrUpstreamInUse, got %v", err)
+	}
+	current.Port = "3002"
+	updated, err := UpdateService("app1", *current)
+	if err != nil || updated.Port != "3002" || updated.Owner != owner || updated.Domain != "app1.node.example.com" || updated.DNSRecord == nil {
+		t.Fatalf("unexpected update %+v, %v", updated, err)
+	}
+	if _, err := UpdateService("nope", *current); ServiceErrorStatus(err) != http.StatusNotFound {
+		t.Errorf("expected a 404, got %v", err)
+	}
+	// only the record created for app2 is deleted with it
+	if err := dns.Add("app2.node.example.com. 300 IN A 198.51.100.2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := DeleteService("app2"); err != nil {
+		t.Fatal(err)
+	}
+	if got := dns.Lookup("app2.node.example.com", "A"); len(got) != 1 || got[0] != "198.51.100.2" {
+		t.Errorf("expected only the record of app2 deleted, got %v", got)
+	}
+
+	for _, name := range []string{"www", "-app", "my app", "a.b"} {
+		if value, _, _ := IsValidService(model.Service{Name: name, IpAddress: "127.0.0.1", Port: "4000"}); value != -1 {
+			t.Errorf("%q: expected an invalid name", name)
+		}
+	}
+}
+
+func TestMergeService(t *testing.T) {
+	current := model.Service{
+		Name:          "app",
+		IpAddress:     "10.0.0.2",
+		Port:          "3000",
+		Paths:         []string{"/api"},
+		Upstreams:     []string{"10.0.0.2:3000", "10.0.0.3:3000"},
+		LoadBalancing: "round_robin",
+		HealthCheck:   &model.ServiceHealthCheck{Path: "/health"},
+		Headers:       &model.ServiceHeaders{Request: map[string]string{"X-App": "1"}},
+		Auth:          &model.ServiceAuth{Type: "wallet"},
+		TLS:           &model.ServiceTLS{Issuer: "internal"},
+		RateLimit:     &model.ServiceRateLimit{Requests: 10, Window: "1m"},
+	}
+
+	// empty changes keep every field
+	merged, err := MergeService(current, ServiceChanges{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged.Port != "3000" || len(merged.Upstreams) != 2 || merged.Auth == nil || merged.RateLimit == nil {
+		t.Fatalf("expected the service unchanged, got %+v", merged)
+	}
+
+	merged, err = MergeService(current, ServiceChanges{
+		Port:      "4000",
+		Upstreams: []string{"10.0.0.4:4000"},
+		TLS:       &model.ServiceTLS{Issuer: "acme"},
+		Clear:     []string{"paths", "loadBalancing", "healthCheck", "headers", "auth", "rateLimit", "tls"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged.Port != "4000" || merged.IpAddress != "10.0.0.2" || len(merged.Upstreams) != 1 {
+		t.Errorf("expected the new port and upstreams, got %+v", merged)
+	}
+	if merged.Paths != nil || merged.LoadBalancing != "" || merged.HealthCheck != nil || merged.Headers != nil || merged.Auth != nil || merged.RateLimit != nil {
+		t.Errorf("expected the options cleared, got %+v", merged)
+	}
+	// a cleared option set in the same update takes the new value
+	if merged.TLS == nil || merged.TLS.Issuer != "acme" {
+		t.Errorf("expected the new tls, got %+v", merged.TLS)
+	}
+	if current.Auth == nil || current.TLS.Issuer != "internal" {
+		t.Error("the current service was changed")
+	}
+
+	if _, err := MergeService(current, ServiceChanges{Clear: []string{"name"}}); !errors.Is(err, ErrInvalidService) {
+		t.Errorf("expected an invalid service error, got %v", err)
+	}
+}
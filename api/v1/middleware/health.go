@@ -0,0 +1,219 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NetSepio/nexus/api/v1/service/template"
+	"github.com/NetSepio/nexus/model"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// maxHealthSamples is the length of the latency history of a service
+	maxHealthSamples = 60
+	// maxHealthTransitions is the number of status transitions kept per service
+	maxHealthTransitions = 20
+	// defaultHealthTimeout bounds the checks of services without a health check timeout
+	defaultHealthTimeout = 5 * time.Second
+)
+
+// HealthUnknown is the status of a service the health checker did not check yet
+const HealthUnknown = "unknown"
+
+// healthMu guards serviceHealth, the latest health of every service by lower case name
+var (
+	healthMu      sync.RWMutex
+	serviceHealth = map[string]*model.ServiceHealth{}
+)
+
+// StartHealthChecker checks the upstreams of every service right away and on each tick
+func StartHealthChecker(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		checkServices()
+		for range ticker.C {
+			checkServices()
+		}
+	}()
+}
+
+// checkServices checks every saved service concurrently and forgets the removed ones
+func checkServices() {
+	services, err := ReadServices()
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Error("failed to read the services to check")
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, service := range services.Services {
+		wg.Add(1)
+		go func(service model.Service) {
+			defer wg.Done()
+			recordHealth(service, checkService(service), time.Now())
+		}(service)
+	}
+	wg.Wait()
+
+	healthMu.Lock()
+	defer healthMu.Unlock()
+	for name := range serviceHealth {
+		if !slices.ContainsFunc(services.Services, func(s model.Service) bool { return strings.ToLower(s.Name) == name }) {
+			delete(serviceHealth, name)
+		}
+	}
+}
+
+// WithHealth returns service with its latest health and status, unknown until the checker
+// reached it, listings never wait for upstreams
+func WithHealth(service model.Service) model.Service {
+	healthMu.RLock()
+	health, ok := serviceHealth[strings.ToLower(service.Name)]
+	if ok {
+		health = copyHealth(health)
+	}
+	healthMu.RUnlock()
+
+	if !ok {
+		health = &model.ServiceHealth{Status: HealthUnknown}
+	}
+	service.Health = health
+	service.Status = health.Status
+	return service
+}
+
+// checkService checks every upstream of service, over http when it has a health check
+func checkService(service model.Service) []model.UpstreamHealth {
+	timeout := defaultHealthTimeout
+	if hc := service.HealthCheck; hc != nil && hc.Timeout != "" {
+		if d, err := time.ParseDuration(hc.Timeout); err == nil {
+			timeout = d
+		}
+	}
+
+	upstreams := template.Upstreams(service)
+	results := make([]model.UpstreamHealth, len(upstreams))
+	for i, upstream := range upstreams {
+		start := time.Now()
+		var err error
+		if service.HealthCheck != nil {
+			err = checkHTTP(upstream, service.HealthCheck, timeout)
+		} else {
+			err = checkTCP(upstream, timeout)
+		}
+		results[i] = model.UpstreamHealth{Address: upstream, Up: err == nil, LatencyMs: time.Since(start).Milliseconds()}
+		if err != nil {
+			results[i].Error = err.Error()
+		}
+	}
+	return results
+}
+
+// checkTCP connects to the upstream
+func checkTCP(upstream string, timeout time.Duration) error {
+	conn, err := net.DialTimeout("tcp", upstream, timeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// checkHTTP requests the health check path of the upstream, a 2xx answer is healthy
+// unless the health check expects another status
+func checkHTTP(upstream string, hc *model.ServiceHealthCheck, timeout time.Duration) error {
+	client := &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Get("http://" + upstream + hc.Path)
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return fmt.Errorf("no answer within %s", timeout)
+		}
+		return err
+	}
+	resp.Body.Close()
+
+	if (hc.ExpectStatus != 0 && resp.StatusCode != hc.ExpectStatus) || (hc.ExpectStatus == 0 && resp.StatusCode/100 != 2) {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// recordHealth stores the results of a check of service and returns a copy of its health
+func recordHealth(service model.Service, upstreams []model.UpstreamHealth, now time.Time) *model.ServiceHealth {
+	up := 0
+	var latency int64
+	for _, u := range upstreams {
+		if u.Up {
+			up++
+			latency = max(latency, u.LatencyMs)
+		}
+	}
+	status := "degraded"
+	switch up {
+	case len(upstreams):
+		status = "active"
+	case 0:
+		status = "inactive"
+	}
+	check := "tcp"
+	if service.HealthCheck != nil {
+		check = "http"
+	}
+	checked := now.UTC().Format(time.RFC3339)
+
+	healthMu.Lock()
+	defer healthMu.Unlock()
+
+	name := strings.ToLower(service.Name)
+	health, ok := serviceHealth[name]
+	if !ok {
+		health = &model.ServiceHealth{Status: status, Since: checked}
+		serviceHealth[name] = health
+	}
+	if health.Status != status {
+		log.WithFields(log.Fields{
+			"service": service.Name,
+			"from":    health.Status,
+			"to":      status,
+		}).Warn("service health changed")
+		health.Transitions = append(health.Transitions, model.HealthTransition{Time: checked, From: health.Status, To: status})
+		if len(health.Transitions) > maxHealthTransitions {
+			health.Transitions = health.Transitions[len(health.Transitions)-maxHealthTransitions:]
+		}
+		health.Status = status
+		health.Since = checked
+	}
+	health.Check = check
+	health.LastChecked = checked
+	health.LatencyMs = latency
+	health.Upstreams = upstreams
+	health.History = append(health.History, model.HealthSample{Time: checked, Status: status, LatencyMs: latency})
+	if len(health.History) > maxHealthSamples {
+		health.History = health.History[len(health.History)-maxHealthSamples:]
+	}
+
+	return copyHealth(health)
+}
+
+// copyHealth returns a copy of health sharing nothing with the checker
+func copyHealth(health *model.ServiceHealth) *model.ServiceHealth {
+	c := *health
+	c.Upstreams = slices.Clone(health.Upstreams)
+	c.History = slices.Clone(health.History)
+	c.Transitions = slices.Clone(health.Transitions)
+	return &c
+}
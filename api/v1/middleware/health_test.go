@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/NetSepio/nexus/model"
+)
+
+func TestServiceHealth(t *testing.T) {
+	var unhealthy atomic.Bool
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" || unhealthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer backend.Close()
+
+	// a port no one listens on
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed := l.Addr().String()
+	l.Close()
+
+	host, port, _ := net.SplitHostPort(backend.Listener.Addr().String())
+	service := model.Service{Name: "Health", IpAddress: host, Port: port, HealthCheck: &model.ServiceHealthCheck{Path: "/health", Timeout: "1s"}}
+
+	// services the checker did not reach are not checked on read
+	start := time.Now()
+	if got := WithHealth(model.Service{Name: "Unchecked", IpAddress: "203.0.113.1", Port: "80"}); got.Status != HealthUnknown || got.Health.LastChecked != "" {
+		t.Fatalf("expected an unknown health, got %+v", got.Health)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatal("the upstreams of an unchecked service were dialed")
+	}
+
+	recordHealth(service, checkService(service), time.Now())
+	got := WithHealth(service)
+	if got.Status != "active" || got.Health.Check != "http" || len(got.Health.History) != 1 {
+		t.Fatalf("expected an active http check, got %+v", got.Health)
+	}
+
+	unhealthy.Store(true)
+	h := recordHealth(service, checkService(service), time.Now())
+	if h.Status != "inactive" || len(h.Transitions) != 1 || h.Transitions[0].From != "active" || h.Upstreams[0].Error == "" {
+		t.Fatalf("expected a transition to inactive, got %+v", h)
+	}
+
+	// tcp checks of services without a health check
+	service.HealthCheck = nil
+	service.Upstreams = []string{backend.Listener.Addr().String(), closed}
+	h = recordHealth(service, checkService(service), time.Now())
+	if h.Status != "degraded" || h.Check != "tcp" || !h.Upstreams[0].Up || h.Upstreams[1].Up {
+		t.Fatalf("expected a degraded tcp check, got %+v", h)
+	}
+	if got := WithHealth(model.Service{Name: "health"}); len(got.Health.History) != 3 || len(got.Health.Transitions) != 2 {
+		t.Fatalf("expected the history of the service, got %+v", got.Health)
+	}
+}
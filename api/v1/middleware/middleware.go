@@ -1,8 +1,12 @@
 package middleware
 
 import (
+	"net/http"
 	"os"
 
+	"github.com/NetSepio/nexus/core"
+	"github.com/NetSepio/nexus/util/pkg/policy"
+	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -20,3 +24,47 @@ func CheckGatewayAccess(decryptedWalletAddress any) bool {
 	return true
 
 }
+
+// Wallet returns the wallet address set by the PASETO middleware
+func Wallet(c *gin.Context) string {
+	return c.GetString("walletAddress")
+}
+
+// Principal returns the principal set by the PASETO middleware, it has no scope when unset
+func Principal(c *gin.Context) policy.Principal {
+	value, _ := c.Get("principal")
+	p, _ := value.(policy.Principal)
+	return p
+}
+
+// Require aborts requests whose token does not grant scope
+func Require(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := policy.Authorize(Principal(c), scope); err != nil {
+			Forbidden(c, err.Error())
+			return
+		}
+		c.Next()
+	}
+}
+
+// Scope lets every token read and requires scope for any other method
+func Scope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+		Require(scope)(c)
+	}
+}
+
+// Forbidden aborts the request with a 403 error response
+func Forbidden(c *gin.Context, message string) {
+	log.WithFields(log.Fields{
+		"wallet": Wallet(c),
+		"path":   c.FullPath(),
+	}).Warn(message)
+	response := core.MakeErrorResponse(403, message, nil, nil, nil)
+	c.AbortWithStatusJSON(http.StatusForbidden, response)
+}
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/NetSepio/nexus/api/v1/service/template"
+	"github.com/NetSepio/nexus/api/v1/service/util"
+	"github.com/NetSepio/nexus/model"
+)
+
+// ProxyBackend configures the reverse proxy in front of the services
+type ProxyBackend interface {
+	// Apply replaces the routes of every service, the previous configuration
+	// stays in place when it fails
+	Apply(services []model.Service) error
+}
+
+// Proxy returns the backend selected by PROXY_BACKEND, the Caddyfile unless it is admin. The
+// Caddy admin API falls back to the Caddyfile when it cannot be reached and CADDY_CONF_DIR is set.
+func Proxy() ProxyBackend {
+	caddyfile := &Caddyfile{
+		Path: filepath.Join(os.Getenv("CADDY_CONF_DIR"), os.Getenv("CADDY_INTERFACE_NAME")),
+	}
+	if os.Getenv("PROXY_BACKEND") != "admin" {
+		return caddyfile
+	}
+
+	url := os.Getenv("CADDY_ADMIN_URL")
+	if url == "" {
+		url = "http://localhost:2019"
+	}
+	admin := &CaddyAdmin{URL: url}
+	if os.Getenv("CADDY_CONF_DIR") != "" {
+		admin.Fallback = caddyfile
+	}
+	return admin
+}
+
+// Caddyfile renders one site block per service into a Caddyfile and reloads Caddy with it
+type Caddyfile struct {
+	Path string
+}
+
+// Apply atomically replaces the Caddyfile and restores the previous one when Caddy rejects it
+func (cf *Caddyfile) Apply(services []model.Service) error {
+	if filepath.Dir(cf.Path) == "." {
+		return fmt.Errorf("CADDY_CONF_DIR environment variable is not set")
+	}
+	if err := os.MkdirAll(filepath.Dir(cf.Path), 0755); err != nil {
+		return fmt.Errorf("error creating directory %s: %w", filepath.Dir(cf.Path), err)
+	}
+
+	var caddyfile bytes.Buffer
+	for _, service := range services {
+		config, err := template.CaddyConfigTempl(service)
+		if err != nil {
+			util.LogError("Caddy update error: ", err)
+			return err
+		}
+		caddyfile.Write(config)
+	}
+
+	previous, err := os.ReadFile(cf.Path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := util.WriteFile(cf.Path, caddyfile.Bytes()); err != nil {
+		return err
+	}
+
+	if err := cf.reload(); err != nil {
+		util.LogError("Caddy reload error, restoring the previous Caddyfile: ", err)
+		if previous != nil {
+			if err := util.WriteFile(cf.Path, previous); err != nil {
+				util.LogError("failed to restore the Caddyfile: ", err)
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// reload asks the running Caddy to load the Caddyfile, skipped when caddy is not installed
+func (cf *Caddyfile) reload() error {
+	caddy, err := exec.LookPath("caddy")
+	if err != nil {
+		return nil
+	}
+	output, err := exec.Command(caddy, "reload", "--config", cf.Path, "--adapter", "caddyfile").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("caddy reload: %w: %s", err, bytes.TrimSpace(output))
+	}
+	return nil
+}
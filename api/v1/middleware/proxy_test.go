@@ -0,0 +1,279 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/NetSepio/nexus/api/v1/service/template"
+	"github.com/NetSepio/nexus/model"
+)
+
+// fakeCaddy stands in for the Caddy admin API, it rejects configs routing rejected.example.com
+// and, like Caddy, configs with two servers on one address
+type fakeCaddy struct {
+	mu     sync.Mutex
+	config []byte
+	loads  int
+}
+
+func (f *fakeCaddy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/config/":
+		w.Write(f.config)
+	case r.Method == http.MethodPost && r.URL.Path == "/load":
+		body, _ := io.ReadAll(r.Body)
+		f.loads++
+		if strings.Contains(string(body), "rejected.example.com") {
+			http.Error(w, `{"error":"loading new config: provision failed"}`, http.StatusBadRequest)
+			return
+		}
+		if err := uniqueListeners(body); err != "" {
+			http.Error(w, err, http.StatusBadRequest)
+			return
+		}
+		f.config = body
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+type fakeConfig struct {
+	Apps struct {
+		HTTP struct {
+			Servers map[string]struct {
+				Listen []string     `json:"listen"`
+				Routes []caddyRoute `json:"routes"`
+			} `json:"servers"`
+		} `json:"http"`
+	} `json:"apps"`
+}
+
+// uniqueListeners returns the error of Caddy when two servers listen on one address
+func uniqueListeners(body []byte) string {
+	var config fakeConfig
+	if err := json.Unmarshal(body, &config); err != nil {
+		return err.Error()
+	}
+	listeners := map[string]string{}
+	for name, server := range config.Apps.HTTP.Servers {
+		for _, address := range server.Listen {
+			if other, ok := listeners[address]; ok {
+				return `{"error":"server ` + name + `: listener address repeated: ` + address + ` (already claimed by server '` + other + `')"}`
+			}
+			listeners[address] = name
+		}
+	}
+	return ""
+}
+
+// routes returns the dial addresses of the services routed by server, by host, and the ids of
+// the routes not managed by nexus
+func (f *fakeCaddy) routes(t *testing.T, server string) (map[string]string, []string) {
+	var config fakeConfig
+	if err := json.Unmarshal(f.config, &config); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := config.Apps.HTTP.Servers["other"]; !ok {
+		t.Fatal("the server not managed by nexus was dropped")
+	}
+
+	routes := map[string]string{}
+	var others []string
+	for _, route := range config.Apps.HTTP.Servers[server].Routes {
+		if !strings.HasPrefix(route.ID, caddyRouteID) {
+			others = append(others, route.ID)
+			continue
+		}
+		host := route.Match[0]["host"].([]any)[0].(string)
+		dial := route.Handle[1]["upstreams"].([]any)[0].(map[string]any)["dial"].(string)
+		routes[host] = dial
+	}
+	return routes, others
+}
+
+func TestCaddyAdminApply(t *testing.T) {
+	caddy := &fakeCaddy{config: []byte(`{"apps":{"http":{"servers":{"other":{"listen":[":8080"]}}}}}`)}
+	admin := httptest.NewServer(caddy)
+	defer admin.Close()
+	backend := &CaddyAdmin{URL: admin.URL}
+
+	services := []model.Service{
+		{Name: "app1", IpAddress: "127.0.0.1", Port: "3000", Domain: "app1.example.com"},
+		{Name: "app2", IpAddress: "10.0.0.2", Port: "8080", Domain: "app2.example.com"},
+	}
+	if err := backend.Apply(services); err != nil {
+		t.Fatal(err)
+	}
+	routes, _ := caddy.routes(t, caddyServer)
+	if len(routes) != 2 || routes["app1.example.com"] != "127.0.0.1:3000" || routes["app2.example.com"] != "10.0.0.2:8080" {
+		t.Fatalf("unexpected routes %v", routes)
+	}
+
+	// a rejected config leaves the previous routes running
+	applied, loads := caddy.config, caddy.loads
+	rejected := append(services, model.Service{Name: "bad", IpAddress: "127.0.0.1", Port: "3001", Domain: "rejected.example.com"})
+	if err := backend.Apply(rejected); err == nil {
+		t.Fatal("expected the rejected config to fail")
+	}
+	if string(caddy.config) != string(applied) || caddy.loads != loads+2 {
+		t.Fatal("the previous config was not restored")
+	}
+
+	if err := backend.Apply(nil); err != nil {
+		t.Fatal(err)
+	}
+	if routes, _ := caddy.routes(t, caddyServer); len(routes) != 0 {
+		t.Fatalf("expected no routes, got %v", routes)
+	}
+}
+
+func TestCaddyAdminApplyHTTPSServer(t *testing.T) {
+	// the config a Caddyfile serving a site over https adapts to
+	caddy := &fakeCaddy{config: []byte(`{"apps":{"http":{"servers":{
+		"other":{"listen":[":8080"]},
+		"srv0":{"listen":[":443"],"routes":[{"@id":"site","match":[{"host":["example.com"]}],"handle":[{"handler":"file_server"}],"terminal":true}]}
+	}}}}`)}
+	admin := httptest.NewServer(caddy)
+	defer admin.Close()
+	backend := &CaddyAdmin{URL: admin.URL}
+
+	services := []model.Service{{Name: "app1", IpAddress: "10.0.0.2", Port: "3000", Domain: "app1.example.com"}}
+	if err := backend.Apply(services); err != nil {
+		t.Fatal(err)
+	}
+	routes, others := caddy.routes(t, "srv0")
+	if len(routes) != 1 || routes["app1.example.com"] != "10.0.0.2:3000" || len(others) != 1 || others[0] != "site" {
+		t.Fatalf("unexpected routes %v and %v", routes, others)
+	}
+	if routes, _ := caddy.routes(t, caddyServer); len(routes) != 0 {
+		t.Fatalf("expected no server of its own, got %v", routes)
+	}
+
+	// the routes are replaced, not added again
+	services = append(services, model.Service{Name: "app2", IpAddress: "10.0.0.3", Port: "3000", Domain: "app2.example.com"})
+	if err := backend.Apply(services); err != nil {
+		t.Fatal(err)
+	}
+	if routes, others := caddy.routes(t, "srv0"); len(routes) != 2 || len(others) != 1 {
+		t.Fatalf("unexpected routes %v and %v", routes, others)
+	}
+
+	if err := backend.Apply(nil); err != nil {
+		t.Fatal(err)
+	}
+	if routes, others := caddy.routes(t, "srv0"); len(routes) != 0 || len(others) != 1 {
+		t.Fatalf("expected the site route only, got %v and %v", routes, others)
+	}
+}
+
+// recordingBackend records the services it applied
+type recordingBackend struct {
+	services []model.Service
+}
+
+func (r *recordingBackend) Apply(services []model.Service) error {
+	r.services = services
+	return nil
+}
+
+func TestCaddyAdminFallback(t *testing.T) {
+	admin := httptest.NewServer(http.NotFoundHandler())
+	url := admin.URL
+	admin.Close()
+
+	fallback := &recordingBackend{}
+	backend := &CaddyAdmin{URL: url, Fallback: fallback}
+	services := []model.Service{{Name: "app1", IpAddress: "10.0.0.2", Port: "3000", Domain: "app1.example.com"}}
+	if err := backend.Apply(services); err != nil {
+		t.Fatal(err)
+	}
+	if len(fallback.services) != 1 {
+		t.Fatal("the services were not applied through the fallback")
+	}
+
+	// an admin API answering with an error does not fall back
+	caddy := &fakeCaddy{config: []byte(`{"apps":{"http":{"servers":{"other":{"listen":[":8080"]}}}}}`)}
+	running := httptest.NewServer(caddy)
+	defer running.Close()
+	fallback.services = nil
+	backend.URL = running.URL
+	rejected := append(services, model.Service{Name: "bad", IpAddress: "127.0.0.1", Port: "3001", Domain: "rejected.example.com"})
+	if err := backend.Apply(rejected); err == nil || fallback.services != nil {
+		t.Fatalf("expected the rejected config to fail without fallback, got %v", err)
+	}
+}
+
+func TestProxy(t *testing.T) {
+	t.Setenv("CADDY_CONF_DIR", "/etc/caddy")
+	t.Setenv("CADDY_INTERFACE_NAME", "Caddyfile")
+
+	t.Setenv("PROXY_BACKEND", "")
+	if _, ok := Proxy().(*Caddyfile); !ok {
+		t.Fatal("expected the Caddyfile by default")
+	}
+
+	t.Setenv("PROXY_BACKEND", "admin")
+	admin, ok := Proxy().(*CaddyAdmin)
+	if !ok {
+		t.Fatal("expected the Caddy admin API")
+	}
+	if fallback, ok := admin.Fallback.(*Caddyfile); !ok || fallback.Path != "/etc/caddy/Caddyfile" {
+		t.Fatalf("expected the Caddyfile as fallback, got %v", admin.Fallback)
+	}
+}
+
+func TestServiceRouteHeaders(t *testing.T) {
+	service := model.Service{
+		Name:      "app",
+		IpAddress: "10.0.0.2",
+		Port:      "3000",
+		Domain:    "app.example.com",
+		Auth:      &model.ServiceAuth{Type: "wallet"},
+		Headers: &model.ServiceHeaders{Request: map[string]string{
+			"X-Real-Ip":   "{remote_host}",
+			"X-Forwarded": "{scheme}://{host}{header.X-Path}",
+			"X-Full":      "{http.request.uri}",
+		}},
+	}
+
+	route := serviceRoute(service)
+	headers := route.Handle[len(route.Handle)-1]["headers"].(map[string]any)["request"].(map[string]any)
+	set := headers["set"].(map[string][]string)
+	for name, want := range map[string]string{
+		"X-Real-Ip":   "{http.request.remote.host}",
+		"X-Forwarded": "{http.request.scheme}://{http.request.host}{http.request.header.X-Path}",
+		"X-Full":      "{http.request.uri}",
+	} {
+		if set[name][0] != want {
+			t.Errorf("%s: expected %s, got %s", name, want, set[name][0])
+		}
+	}
+	if remove := headers["delete"].([]string); !slices.Contains(remove, "Authorization") {
+		t.Errorf("expected the token stripped before the upstream, got %v", remove)
+	}
+
+	// without header rules of its own
+	service.Headers = nil
+	route = serviceRoute(service)
+	headers = route.Handle[len(route.Handle)-1]["headers"].(map[string]any)["request"].(map[string]any)
+	if remove := headers["delete"].([]string); !slices.Contains(remove, "Authorization") {
+		t.Errorf("expected the token stripped before the upstream, got %v", remove)
+	}
+
+	caddyfile, err := template.CaddyConfigTempl(service)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(caddyfile), "header_up -Authorization") {
+		t.Errorf("expected the token stripped before the upstream in\n%s", caddyfile)
+	}
+}
@@ -0,0 +1,265 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NetSepio/nexus/model"
+	"github.com/NetSepio/nexus/util/pkg/policy"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// loadBalancingPolicies are the upstream selection policies of the Caddy reverse proxy
+var loadBalancingPolicies = map[string]bool{
+	"random":      true,
+	"round_robin": true,
+	"least_conn":  true,
+	"first":       true,
+	"ip_hash":     true,
+}
+
+// reservedNames are subdomains of the node no service may take, RESERVED_SUBDOMAINS adds more
+var reservedNames = []string{"www", "api", "admin", "app", "mail", "smtp", "ns1", "ns2", "gateway", "grpc", "node", "status", "caddy", "erebrus", "nexus"}
+
+// isDNSLabel reports whether name is a DNS label: letters, digits and inner hyphens, at most 63 chars
+func isDNSLabel(name string) bool {
+	if len(name) == 0 || len(name) > 63 || name[0] == '-' || name[len(name)-1] == '-' {
+		return false
+	}
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') && !(c >= '0' && c <= '9') && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// reservedName reports whether name is one of the reserved subdomains
+func reservedName(name string) bool {
+	reserved := reservedNames
+	if extra := os.Getenv("RESERVED_SUBDOMAINS"); extra != "" {
+		reserved = append(slices.Clone(reserved), strings.Split(extra, ",")...)
+	}
+	return slices.ContainsFunc(reserved, func(r string) bool { return strings.EqualFold(strings.TrimSpace(r), name) })
+}
+
+// validateServiceOptions returns why the options of service are invalid, empty when they are valid
+func validateServiceOptions(service model.Service) string {
+	for _, path := range service.Paths {
+		if !strings.HasPrefix(path, "/") || strings.ContainsAny(path, " \t\r\n{}") {
+			return "Paths must start with / and must not contain spaces or braces"
+		}
+	}
+
+	for _, upstream := range service.Upstreams {
+		host, port, err := net.SplitHostPort(upstream)
+		if err != nil || host == "" || !validPort(port) {
+			return "Upstreams must be host:port addresses"
+		}
+	}
+	if service.LoadBalancing != "" && !loadBalancingPolicies[service.LoadBalancing] {
+		return "LoadBalancing must be random, round_robin, least_conn, first or ip_hash"
+	}
+
+	if hc := service.HealthCheck; hc != nil {
+		if !strings.HasPrefix(hc.Path, "/") {
+			return "HealthCheck path must start with /"
+		}
+		if !validDuration(hc.Interval) || !validDuration(hc.Timeout) {
+			return "HealthCheck interval and timeout must be durations such as 30s"
+		}
+		if hc.ExpectStatus != 0 && (hc.ExpectStatus < 100 || hc.ExpectStatus > 599) {
+			return "HealthCheck expectStatus must be an HTTP status"
+		}
+	}
+
+	if headers := service.Headers; headers != nil {
+		for _, rules := range []map[string]string{headers.Request, headers.Response} {
+			for name, value := range rules {
+				if !validHeaderName(name) || strings.ContainsAny(value, "\r\n") {
+					return "Headers must be valid header names and single line values"
+				}
+			}
+		}
+	}
+
+	if auth := service.Auth; auth != nil {
+		switch auth.Type {
+		case "basic":
+			if auth.Username == "" || auth.Password == "" || strings.ContainsAny(auth.Username, " \t\r\n") {
+				return "Basic auth requires a username without spaces and a password"
+			}
+		case "wallet":
+			for _, wallet := range auth.Wallets {
+				if wallet == "" || strings.ContainsAny(wallet, " \t\r\n") {
+					return "Auth wallets must be wallet addresses"
+				}
+			}
+			// the node token of the visitor is stripped before the upstream, it must not be copied
+			if headers := service.Headers; headers != nil {
+				for name, value := range headers.Request {
+					if strings.EqualFold(name, "Authorization") || strings.Contains(strings.ToLower(value), "header.authorization}") {
+						return "Headers of wallet protected services must not send the Authorization header upstream"
+					}
+				}
+			}
+		default:
+			return "Auth type must be basic or wallet"
+		}
+	}
+
+	if tls := service.TLS; tls != nil {
+		if tls.Issuer != "" && tls.Issuer != "acme" && tls.Issuer != "internal" {
+			return "TLS issuer must be acme or internal"
+		}
+		if tls.Email != "" {
+			if _, err := mail.ParseAddress(tls.Email); err != nil || strings.ContainsAny(tls.Email, " <>") {
+				return "TLS email is invalid"
+			}
+		}
+		if tls.CA != "" {
+			if u, err := url.Parse(tls.CA); err != nil || u.Scheme != "https" || u.Host == "" {
+				return "TLS ca must be the https URL of an ACME directory"
+			}
+		}
+	}
+
+	if rl := service.RateLimit; rl != nil {
+		if rl.Requests <= 0 || rl.Window == "" || !validDuration(rl.Window) {
+			return "RateLimit requires a positive number of requests and a window such as 1m"
+		}
+	}
+
+	return ""
+}
+
+// CheckServiceTargets returns an ErrInvalidService error when a service of a non-admin proxies
+// to the node itself, such as the Caddy admin API on localhost:2019, or to link-local addresses
+func CheckServiceTargets(p policy.Principal, service model.Service) error {
+	if p.IsAdmin() {
+		return nil
+	}
+	hosts := []string{service.IpAddress}
+	for _, upstream := range service.Upstreams {
+		host, _, err := net.SplitHostPort(upstream)
+		if err != nil {
+			return fmt.Errorf("%w: Upstreams must be host:port addresses", ErrInvalidService)
+		}
+		hosts = append(hosts, host)
+	}
+
+	local := localAddresses()
+	for _, host := range hosts {
+		ips, err := resolveTarget(host)
+		if err != nil {
+			return fmt.Errorf("%w: %s does not resolve", ErrInvalidService, host)
+		}
+		for _, ip := range ips {
+			if internalAddress(ip) || slices.ContainsFunc(local, ip.Equal) {
+				return fmt.Errorf("%w: %s is an address of the node, only admins may proxy to it", ErrInvalidService, host)
+			}
+		}
+	}
+	return nil
+}
+
+// resolveTarget returns the addresses of host, an ip address or a host name
+func resolveTarget(host string) ([]net.IP, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return []net.IP{ip}, nil
+	}
+	if strings.EqualFold(host, "localhost") || strings.HasSuffix(strings.ToLower(host), ".localhost") {
+		return []net.IP{net.IPv4(127, 0, 0, 1)}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	ips := make([]net.IP, 0, len(addrs))
+	for _, addr := range addrs {
+		ips = append(ips, addr.IP)
+	}
+	return ips, nil
+}
+
+// internalAddress reports whether ip is a loopback, unspecified, link-local or multicast address
+func internalAddress(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast()
+}
+
+// localAddresses returns the addresses of the interfaces of the node
+func localAddresses() []net.IP {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil
+	}
+	ips := make([]net.IP, 0, len(addrs))
+	for _, addr := range addrs {
+		if prefix, ok := addr.(*net.IPNet); ok {
+			ips = append(ips, prefix.IP)
+		}
+	}
+	return ips
+}
+
+// hashServicePassword replaces the basic auth password of service with its bcrypt hash
+func hashServicePassword(service *model.Service) error {
+	if service.Auth == nil || service.Auth.Type != "basic" || strings.HasPrefix(service.Auth.Password, "$2") {
+		return nil
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(service.Auth.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	service.Auth.Password = string(hash)
+	return nil
+}
+
+func validPort(port string) bool {
+	p, err := strconv.Atoi(port)
+	return err == nil && p >= 1 && p <= 65535
+}
+
+// validDuration reports whether d is empty or a positive duration
+func validDuration(d string) bool {
+	if d == "" {
+		return true
+	}
+	duration, err := time.ParseDuration(d)
+	return err == nil && duration > 0
+}
+
+// validHeaderName reports whether name is an HTTP header token
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		if c > 127 || c <= ' ' || strings.ContainsRune(`"(),/:;<=>?@[\]{}`, c) {
+			return false
+		}
+	}
+	return true
+}
+
+// PublicService returns service without the hash of its basic auth password
+func PublicService(service model.Service) model.Service {
+	if service.Auth != nil {
+		auth := *service.Auth
+		auth.Password = ""
+		service.Auth = &auth
+	}
+	return service
+}
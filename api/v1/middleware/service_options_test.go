@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/NetSepio/nexus/model"
+	"github.com/NetSepio/nexus/util/pkg/policy"
+)
+
+func TestValidateServiceOptions(t *testing.T) {
+	valid := model.Service{
+		Paths:         []string{"/api", "/ws/*"},
+		Upstreams:     []string{"10.0.0.1:8080", "[::1]:8080"},
+		LoadBalancing: "round_robin",
+		HealthCheck:   &model.ServiceHealthCheck{Path: "/health", Interval: "10s", ExpectStatus: 200},
+		Headers:       &model.ServiceHeaders{Request: map[string]string{"X-Real-Ip": "{remote_host}", "X-Powered-By": ""}},
+		Auth:          &model.ServiceAuth{Type: "wallet", Wallets: []string{"0x8811Ffaa9565B5be4a030f3da4c5F1B9eC1d2177"}},
+		TLS:           &model.ServiceTLS{Email: "ops@example.com", Issuer: "acme", CA: "https://acme-staging-v02.api.letsencrypt.org/directory"},
+		RateLimit:     &model.ServiceRateLimit{Requests: 100, Window: "1m"},
+	}
+	if msg := validateServiceOptions(valid); msg != "" {
+		t.Fatalf("expected valid options, got %q", msg)
+	}
+
+	invalid := map[string]func(s *model.Service){
+		"path":      func(s *model.Service) { s.Paths = []string{"api"} },
+		"upstream":  func(s *model.Service) { s.Upstreams = []string{"10.0.0.1"} },
+		"policy":    func(s *model.Service) { s.LoadBalancing = "weighted" },
+		"interval":  func(s *model.Service) { s.HealthCheck.Interval = "often" },
+		"header":    func(s *model.Service) { s.Headers.Response = map[string]string{"X-Bad Name": "1"} },
+		"value":     func(s *model.Service) { s.Headers.Response = map[string]string{"X-Split": "a\r\nb"} },
+		"basic":     func(s *model.Service) { s.Auth = &model.ServiceAuth{Type: "basic", Username: "admin"} },
+		"auth type": func(s *model.Service) { s.Auth = &model.ServiceAuth{Type: "oauth"} },
+		"token":     func(s *model.Service) { s.Headers.Request = map[string]string{"Authorization": "Bearer static"} },
+		"token copy": func(s *model.Service) {
+			s.Headers.Request = map[string]string{"X-Token": "{http.request.header.Authorization}"}
+		},
+		"issuer": func(s *model.Service) { s.TLS.Issuer = "zerossl" },
+		"ca":     func(s *model.Service) { s.TLS.CA = "http://acme.example.com" },
+		"rate":   func(s *model.Service) { s.RateLimit.Requests = 0 },
+	}
+	for name, change := range invalid {
+		service := valid
+		hc, headers, tls, rl := *valid.HealthCheck, *valid.Headers, *valid.TLS, *valid.RateLimit
+		service.HealthCheck, service.Headers, service.TLS, service.RateLimit = &hc, &headers, &tls, &rl
+		change(&service)
+		if msg := validateServiceOptions(service); msg == "" {
+			t.Errorf("%s: expected invalid options", name)
+		}
+	}
+}
+
+func TestCheckServiceTargets(t *testing.T) {
+	admin := policy.Principal{Wallet: "0x00000000000000000000000000000000000000a1", Scopes: []string{policy.ScopeNodeAdmin}}
+	tenant := policy.Principal{Wallet: "0x00000000000000000000000000000000000000b2", Scopes: []string{policy.ScopeServicesWrite}}
+
+	for _, tc := range []struct {
+		name      string
+		ipAddress string
+		upstreams []string
+		allowed   bool
+	}{
+		{"wireguard peer", "10.0.0.2", nil, true},
+		{"public address", "203.0.113.5", []string{"203.0.113.6:443", "[2001:db8::1]:443"}, true},
+		{"loopback", "127.0.0.1", nil, false},
+		{"caddy admin upstream", "10.0.0.2", []string{"localhost:2019"}, false},
+		{"ipv6 loopback", "10.0.0.2", []string{"[::1]:2019"}, false},
+		{"unspecified", "0.0.0.0", nil, false},
+		{"metadata service", "169.254.169.254", nil, false},
+		{"ipv6 link-local", "10.0.0.2", []string{"[fe80::1]:80"}, false},
+	} {
+		service := model.Service{Name: "app", IpAddress: tc.ipAddress, Port: "80", Upstreams: tc.upstreams}
+		if err := CheckServiceTargets(tenant, service); (err == nil) != tc.allowed {
+			t.Errorf("%s: expected allowed %v for tenants, got %v", tc.name, tc.allowed, err)
+		} else if err != nil && !errors.Is(err, ErrInvalidService) {
+			t.Errorf("%s: expected an invalid service error, got %v", tc.name, err)
+		}
+		if err := CheckServiceTargets(admin, service); err != nil {
+			t.Errorf("%s: expected allowed for admins, got %v", tc.name, err)
+		}
+	}
+}
@@ -1,12 +1,15 @@
 package server
 
 import (
+	"errors"
 	"net/http"
 	"os"
 
+	"github.com/NetSepio/nexus/api/v1/middleware"
 	"github.com/NetSepio/nexus/core"
 	"github.com/NetSepio/nexus/model"
 	"github.com/NetSepio/nexus/util"
+	"github.com/NetSepio/nexus/util/pkg/policy"
 	"github.com/NetSepio/nexus/util/pkg/speedtest"
 
 	"github.com/gin-gonic/gin"
@@ -15,12 +18,16 @@ import (
 
 // ApplyRoutes applies router to gin Router
 func ApplyRoutes(r *gin.RouterGroup) {
-	g := r.Group("/server")
+	// the server is administered by admins only, the config read exposes the server key
+	g := r.Group("/server", middleware.Scope(policy.ScopeNodeAdmin))
 	{
-		g.GET("", readServer)
+		g.GET("", middleware.Require(policy.ScopeNodeAdmin), readServer)
 		g.PATCH("", updateServer)
-		g.GET("/config", configServer)
+		g.GET("/config", middleware.Require(policy.ScopeNodeAdmin), configServer)
 		g.GET("/speed", getServerSpeed)
+		g.GET("/pools", readPools)
+		g.PUT("/pools", updatePools)
+		g.POST("/rotate", rotateServerKey)
 	}
 }
 
@@ -43,7 +50,7 @@ func readServer(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, response)
 		return
 	}
-	response := core.MakeSucessResponse(200, "server details", server, nil, nil)
+	response := core.MakeSucessResponse(200, "server details", core.WithoutPrivateKeys(server), nil, nil)
 	c.JSON(http.StatusOK, response)
 }
 
@@ -67,6 +74,8 @@ func updateServer(c *gin.Context) {
 		return
 	}
 
+	data.UpdatedBy = middleware.Wallet(c)
+
 	server, err := core.UpdateServer(&data)
 	if err != nil {
 		log.WithFields(util.StandardFields).Error("failed to update server")
@@ -75,7 +84,7 @@ func updateServer(c *gin.Context) {
 		return
 	}
 
-	response := core.MakeSucessResponse(200, "server updated", server, nil, nil)
+	response := core.MakeSucessResponse(200, "server updated", core.WithoutPrivateKeys(server), nil, nil)
 
 	c.JSON(http.StatusOK, response)
 }
@@ -112,3 +121,124 @@ func getServerSpeed(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, res)
 }
+
+// swagger:route GET /server/pools Server readPools
+//
+// # Read Pools
+//
+// Retrieves the utilization and free ranges of every address pool and of the server networks.
+// responses:
+//
+//	 200: poolsSuccessResponse
+//	 400: badRequestResponse
+//		401: unauthorizedResponse
+//	 500: serverErrorResponse
+func readPools(c *gin.Context) {
+	pools, err := core.ReadPools()
+	if err != nil {
+		log.WithFields(util.StandardFields).Error("Failure in reading pools")
+		response := core.MakeErrorResponse(500, err.Error(), nil, nil, nil)
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+	c.JSON(http.StatusOK, poolsResponse("pools details", pools))
+}
+
+// Pools request body replacing the address pools
+type Pools struct {
+	Pools []*model.Pool `json:"pools"`
+}
+
+// swagger:route PUT /server/pools Server updatePools
+//
+// # Update Pools
+//
+// Replace the address pools, every pool must sit inside the server address and keep the addresses
+// of the clients drawing from it.
+// responses:
+//
+//	 200: poolsSuccessResponse
+//	 400: badRequestResponse
+//		401: unauthorizedResponse
+//	 500: serverErrorResponse
+func updatePools(c *gin.Context) {
+	var data Pools
+	if err := c.ShouldBindJSON(&data); err != nil {
+		log.WithFields(util.StandardFields).Error("failed to bind")
+		response := core.MakeErrorResponse(400, err.Error(), nil, nil, nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	pools, err := core.UpdatePools(data.Pools)
+	if err != nil {
+		log.WithFields(util.StandardFields).Error("failed to update pools")
+		// the clients left outside their pools are returned with the conflict
+		var conflict *core.PoolConflictError
+		var clients []*model.Client
+		if errors.As(err, &conflict) {
+			clients = conflict.Clients
+		}
+		response := core.MakeErrorResponse(core.ErrorStatus(err), err.Error(), nil, nil, clients)
+		c.JSON(int(response.Status), response)
+		return
+	}
+
+	c.JSON(http.StatusOK, poolsResponse("pools updated", pools))
+}
+
+func poolsResponse(message string, pools []*model.PoolUsage) gin.H {
+	return gin.H{
+		"status":  200,
+		"success": true,
+		"message": message,
+		"pools":   pools,
+	}
+}
+
+// Rotation request body for staging a new server keypair, the overlap is optional
+type Rotation struct {
+	// Overlap before the new key is used, such as 10m, defaults to KEY_ROTATION_OVERLAP
+	Overlap string `json:"overlap"`
+}
+
+// swagger:route POST /server/rotate Server rotateServerKey
+//
+// # Rotate server key
+//
+// Stage a new server keypair, announced right away and used once the overlap window elapsed.
+// responses:
+//
+//	 200: serverSuccessResponse
+//	 400: badRequestResponse
+//		401: unauthorizedResponse
+//	 500: serverErrorResponse
+func rotateServerKey(c *gin.Context) {
+	var data Rotation
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&data); err != nil {
+			log.WithFields(util.StandardFields).Error("failed to bind")
+			response := core.MakeErrorResponse(400, err.Error(), nil, nil, nil)
+			c.JSON(http.StatusBadRequest, response)
+			return
+		}
+	}
+
+	overlap, err := core.ParseOverlap(data.Overlap)
+	if err != nil {
+		response := core.MakeErrorResponse(400, err.Error(), nil, nil, nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	server, err := core.RotateServerKey(middleware.Wallet(c), overlap)
+	if err != nil {
+		log.WithFields(util.StandardFields).Error("failed to rotate server key")
+		response := core.MakeErrorResponse(500, err.Error(), nil, nil, nil)
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	response := core.MakeSucessResponse(200, "server key rotated", core.WithoutPrivateKeys(server), nil, nil)
+	c.JSON(http.StatusOK, response)
+}
@@ -29,11 +29,88 @@ type ServerUpdateReqparam struct {
 	Body Server `json:"server"`
 }
 
+// swagger:response poolsSuccessResponse
+// Response listing the address pools.
+type PoolsSuccessResponse struct {
+	// in: body
+	Body struct {
+		// example: 200
+		Status int64
+		// example: true
+		Sucess bool
+		// example: pools details
+		Message string
+		Pools   []PoolUsage `json:"pools"`
+	}
+}
+
+// swagger:parameters rotateServerKey
+type ServerRotationReqparam struct {
+	// Optional requestbody used to stage a new server keypair.
+	// in: body
+	Body struct {
+		//Time before the new key is used, defaults to KEY_ROTATION_OVERLAP
+		// example: 10m
+		Overlap string `json:"overlap"`
+	}
+}
+
+// swagger:parameters updatePools
+type PoolsUpdateReqparam struct {
+	// Requestbody  used to replace the address pools.
+	// in: body
+	Body struct {
+		Pools []Pool `json:"pools"`
+	}
+}
+
+// swagger:model
+// model for an address pool.
+type Pool struct {
+	//Unique name of the pool
+	// required: true
+	// example: tenant-a
+	Name string `json:"name"`
+	//Networks of the pool, each inside a server address
+	// required: true
+	// example: ["10.0.0.128/25","fd9f::1:0/112"]
+	Address []string `json:"address"`
+	//Addresses, networks or first-last ranges never handed out
+	// example: ["10.0.0.128-10.0.0.140"]
+	Exclude []string `json:"exclude"`
+	//Clients with one of these tags draw their addresses from the pool
+	// example: ["tenant-a"]
+	Tags []string `json:"tags"`
+}
+
+// swagger:model
+// model for the utilization of a pool network.
+type PoolUsage struct {
+	//Name of the pool, default for what is left of a server network
+	// example: tenant-a
+	Name string `json:"name"`
+	//Network of the pool
+	// example: 10.0.0.128/25
+	Address string `json:"address"`
+	//Number of addresses that can be handed out
+	// example: 128
+	Size string `json:"size"`
+	//Number of addresses in use or excluded
+	// example: 15
+	Used string `json:"used"`
+	//Number of free addresses
+	// example: 113
+	Free string `json:"free"`
+	//First free ranges
+	// example: ["10.0.0.141-10.0.0.255"]
+	FreeRanges []string `json:"freeRanges"`
+}
+
 // swagger:model
 // model for server details.
 type Server struct {
-	//Server address
-	// example: ["10.0.0.1/24"]
+	//Server address, one network per family
+	// example: ["10.0.0.1/24","fd9f::10:0:0:1/64"]
 	Address []string `json:"address"`
 	//Port the server listens
 	// example: 51280
@@ -78,6 +155,8 @@ type Server struct {
 	//Time when server is created
 	// example: 26103870
 	Updated int64 `json:"updated"`
+	//Address pools, managed through /server/pools
+	Pools []Pool `json:"pools"`
 }
 
 // swagger:model
@@ -4,25 +4,28 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"slices"
 	"strconv"
 	"time"
 
 	"github.com/NetSepio/nexus/api/v1/middleware"
 	"github.com/NetSepio/nexus/api/v1/service/util"
-	"github.com/NetSepio/nexus/core"
 	"github.com/NetSepio/nexus/model"
+	"github.com/NetSepio/nexus/util/pkg/policy"
 	"github.com/gin-gonic/gin"
 )
 
 // ApplyRoutes applies router to gin Router
 func ApplyRoutes(r *gin.RouterGroup) {
 
-	g := r.Group("/caddy")
+	g := r.Group("/caddy", middleware.Scope(policy.ScopeServicesWrite))
 	{
 		g.POST("", AddServices)
 		g.GET("", getServices)
 		g.GET(":name", getService)
+		g.PATCH(":name", updateService)
 		g.DELETE(":name", deleteService)
+		g.GET(":name/auth", serviceAuth)
 	}
 }
 
@@ -39,57 +42,127 @@ func AddServices(c *gin.Context) {
 		return
 	}
 
-	// convert port string to int
-	portInt, err := strconv.Atoi(payload.Port)
+	//create a Services struct object, owned by the wallet of the token
+	principal := middleware.Principal(c)
+	data := model.Service{
+		Name:          payload.Name,
+		Type:          os.Getenv("NODE_TYPE"),
+		Port:          payload.Port,
+		Domain:        middleware.ServiceDomain(payload.Name),
+		IpAddress:     payload.IPAddress,
+		Owner:         principal.Wallet,
+		Paths:         payload.Paths,
+		Upstreams:     payload.Upstreams,
+		LoadBalancing: payload.LoadBalancing,
+		HealthCheck:   payload.HealthCheck,
+		Headers:       payload.Headers,
+		Auth:          payload.Auth,
+		TLS:           payload.TLS,
+		RateLimit:     payload.RateLimit,
+	}
+
+	// check validity of Services name, port and options
+	value, msg, err := middleware.IsValidService(data)
 	if err != nil {
-		resp = util.Message(400, "Invalid Port")
-		c.JSON(http.StatusInternalServerError, resp)
+		status := middleware.ServiceErrorStatus(err)
+		if status == http.StatusInternalServerError {
+			msg = "Server error, Try after some time or Contact Admin..." + err.Error()
+		}
+		resp := util.Message(status, msg)
+		c.JSON(status, resp)
+		return
+	}
+	if value == -1 {
+		resp := util.Message(400, msg)
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+	if err := middleware.CheckServiceTargets(principal, data); err != nil {
+		resp := util.Message(400, err.Error())
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	//to add Services config
+	data.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	err = middleware.AddServices(data, middleware.ServiceLimit(principal))
+	if err != nil {
+		status := middleware.ServiceErrorStatus(err)
+		msg := err.Error()
+		if status == http.StatusInternalServerError {
+			msg = "Server error, Try after some time or Contact Admin..." + msg
+		}
+		resp := util.Message(status, msg)
+		c.JSON(status, resp)
+		return
+	}
+
+	resp := util.MessageService(200, middleware.PublicService(data))
+	c.JSON(http.StatusOK, resp)
+}
+
+// updateService updates the upstreams and options of a service, omitted fields keep their value
+func updateService(c *gin.Context) {
+	var payload ServiceUpdate
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	current, ok := authorizeService(c, c.Param("name"))
+	if !ok {
+		return
+	}
+	service, err := middleware.MergeService(*current, payload.changes())
+	if err != nil {
+		resp := util.Message(400, err.Error())
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+	if err := middleware.CheckServiceTargets(middleware.Principal(c), service); err != nil {
+		resp := util.Message(400, err.Error())
+		c.JSON(http.StatusBadRequest, resp)
 		return
 	}
 
-	for {
-
-		// check validity of Services name and port
-		value, msg, err := middleware.IsValidService(payload.Name, portInt, payload.IPAddress)
-
-		if err != nil {
-			resp = util.Message(500, "Server error, Try after some time or Contact Admin..."+err.Error())
-			c.JSON(http.StatusOK, resp)
-			break
-		} else if value == -1 {
-			if msg == "Port Already in use" {
-				continue
-			}
-
-			resp = util.Message(404, msg)
-			c.JSON(http.StatusBadRequest, resp)
-			break
-		} else if value == 1 {
-			//create a Services struct object
-			var data model.Service
-			data.Name = payload.Name
-			data.Type = os.Getenv("NODE_TYPE")
-			data.Port = payload.Port
-			data.Domain = os.Getenv("DOMAIN")
-			data.IpAddress = payload.IPAddress
-			data.CreatedAt = time.Now().UTC().Format(time.RFC3339)
-
-			//to add Services config
-			err := middleware.AddServices(data)
-			if err != nil {
-				resp = util.Message(500, "Server error, Try after some time or Contact Admin..."+err.Error())
-				c.JSON(http.StatusInternalServerError, resp)
-				break
-			} else {
-				resp = util.MessageService(200, data)
-				c.JSON(http.StatusOK, resp)
-				break
-			}
+	updated, err := middleware.UpdateService(current.Name, service)
+	if err != nil {
+		status := middleware.ServiceErrorStatus(err)
+		msg := err.Error()
+		if status == http.StatusInternalServerError {
+			msg = "Server error, Try after some time or Contact Admin..." + msg
 		}
+		resp := util.Message(status, msg)
+		c.JSON(status, resp)
+		return
+	}
+
+	resp := util.MessageService(200, middleware.PublicService(*updated))
+	c.JSON(http.StatusOK, resp)
+}
+
+// authorizeService reads the service called name when the token may manage it, otherwise
+// it answers the request and ok is false
+func authorizeService(c *gin.Context, name string) (*model.Service, bool) {
+	service, err := middleware.ReadService(name)
+	if err != nil {
+		resp := util.Message(500, "Server error, Try after some time or Contact Admin...")
+		c.JSON(http.StatusInternalServerError, resp)
+		return nil, false
+	}
+	if service.Name == "" {
+		resp := util.Message(404, "Service Doesn't Exists")
+		c.JSON(http.StatusNotFound, resp)
+		return nil, false
 	}
+	if !middleware.CanManageService(middleware.Principal(c), service) {
+		middleware.Forbidden(c, "service belongs to another wallet")
+		return nil, false
+	}
+	return service, true
 }
 
-// getServices gets all Services config
+// getServices gets all Services config with their health
 func getServices(c *gin.Context) {
 	services, err := middleware.ReadServices()
 	if err != nil {
@@ -97,10 +170,13 @@ func getServices(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, resp)
 		return
 	}
+	for i := range services.Services {
+		services.Services[i] = middleware.WithHealth(middleware.PublicService(services.Services[i]))
+	}
 	c.JSON(http.StatusOK, services)
 }
 
-// getServices get specific Services config
+// getServices get specific Services config with its health
 func getService(c *gin.Context) {
 	//get parameter
 	name := c.Param("name")
@@ -110,30 +186,18 @@ func getService(c *gin.Context) {
 	if err != nil {
 		resp = util.Message(500, "Server error, Try after some time or Contact Admin...")
 		c.JSON(http.StatusInternalServerError, resp)
+		return
 	}
 
 	//check if Services exists
 	if Services.Name == "" {
 		resp = util.Message(404, "Service Doesn't Exists")
 		c.JSON(http.StatusNotFound, resp)
-	} else {
-		port, err := strconv.Atoi(Services.Port)
-		if err != nil {
-			util.LogError("string conv error: ", err)
-			resp = util.Message(500, "Server error, Try after some time or Contact Admin...")
-			c.JSON(http.StatusInternalServerError, resp)
-		} else {
-			status, err := core.ScanPort(port)
-			if err != nil {
-				resp = util.Message(500, "Server error, Try after some time or Contact Admin...")
-				c.JSON(http.StatusInternalServerError, resp)
-			} else {
-				Services.Status = status
-				resp = util.MessageService(200, *Services)
-				c.JSON(http.StatusOK, resp)
-			}
-		}
+		return
 	}
+
+	resp = util.MessageService(200, middleware.WithHealth(middleware.PublicService(*Services)))
+	c.JSON(http.StatusOK, resp)
 }
 
 func deleteService(c *gin.Context) {
@@ -141,28 +205,45 @@ func deleteService(c *gin.Context) {
 	name := c.Param("name")
 
 	//read Services config
-	Services, err := middleware.ReadService(name)
+	service, ok := authorizeService(c, name)
+	if !ok {
+		return
+	}
+
+	//delete Services config
+	err := middleware.DeleteService(service.Name)
+	if err != nil {
+		resp := util.Message(500, "Server error, Try after some time or Contact Admin...")
+		c.JSON(http.StatusInternalServerError, resp)
+		return
+	}
+	resp := util.Message(200, "Deleted Services "+name)
+	c.JSON(http.StatusOK, resp)
+}
+
+// serviceAuth answers 200 when the token may reach a wallet protected service,
+// Caddy calls it through forward_auth before proxying a request
+func serviceAuth(c *gin.Context) {
+	service, err := middleware.ReadService(c.Param("name"))
 	if err != nil {
 		resp = util.Message(500, "Server error, Try after some time or Contact Admin...")
 		c.JSON(http.StatusInternalServerError, resp)
+		return
+	}
+	if service.Name == "" {
+		resp = util.Message(404, "Service Doesn't Exists")
+		c.JSON(http.StatusNotFound, resp)
+		return
 	}
 
-	//check if Services exists
-	if Services.Name == "" {
-		resp = util.Message(400, "Service Doesn't Exists")
-		c.JSON(http.StatusBadRequest, resp)
-	} else {
-		//delete Services config
-		err = middleware.DeleteService(name)
-		if err != nil {
-			resp = util.Message(500, "Server error, Try after some time or Contact Admin...")
-			c.JSON(http.StatusInternalServerError, resp)
-		} else {
-			resp = util.Message(200, "Deleted Services "+name)
-			c.JSON(http.StatusOK, resp)
+	if service.Auth != nil && service.Auth.Type == "wallet" && len(service.Auth.Wallets) > 0 {
+		wallet := middleware.Wallet(c)
+		if !slices.ContainsFunc(service.Auth.Wallets, func(w string) bool { return policy.SameWallet(w, wallet) }) {
+			middleware.Forbidden(c, "wallet may not access the service")
+			return
 		}
 	}
-
+	c.Status(http.StatusOK)
 }
 
 // func MiddlewareForCaddy(c *gin.Context) {
@@ -198,16 +279,6 @@ func MiddlewareForCaddy() gin.HandlerFunc {
 func AddServicesDirect(domain string, agentName string, port int) error {
 	ipAddress := "127.0.0.1" // Replace with actual IP logic if needed
 
-	// Validate the service
-	value, msg, err := middleware.IsValidService(agentName, port, ipAddress)
-	if err != nil {
-		return fmt.Errorf("server error: %v", err)
-	}
-
-	if value == -1 {
-		return fmt.Errorf("validation failed: %s", msg)
-	}
-
 	// Create a Services struct object
 	var data model.Service
 	data.Name = agentName
@@ -217,8 +288,18 @@ func AddServicesDirect(domain string, agentName string, port int) error {
 	data.IpAddress = ipAddress
 	data.CreatedAt = time.Now().UTC().Format(time.RFC3339)
 
+	// Validate the service
+	value, msg, err := middleware.IsValidService(data)
+	if err != nil {
+		return fmt.Errorf("server error: %v", err)
+	}
+
+	if value == -1 {
+		return fmt.Errorf("validation failed: %s", msg)
+	}
+
 	// Add the service
-	err = middleware.AddServices(data)
+	err = middleware.AddServices(data, 0)
 	if err != nil {
 		return fmt.Errorf("error adding service: %v", err)
 	}
@@ -2,20 +2,79 @@ package template
 
 import (
 	"bytes"
-	"fmt"
-	"html/template"
+	"encoding/base64"
+	"net"
 	"os"
-	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
 
-	"github.com/NetSepio/nexus/core"
 	"github.com/NetSepio/nexus/model"
 )
 
+// DefaultTLSEmail is the ACME account of the certificates of services without one
+const DefaultTLSEmail = "support@netsepio.com"
+
 var (
 	caddyTpl = `
 # {{.Name}}, {{.IpAddress}}, {{.Port}}, {{.CreatedAt}}
 {{.Domain}} {
-	reverse_proxy {{.IpAddress}}:{{.Port}}
+{{- if .Paths}}
+	@{{.Name}} path{{range .Paths}} {{pathPattern .}}{{end}}
+	route @{{.Name}} {
+{{- else}}
+	route {
+{{- end}}
+{{- with .RateLimit}}
+		rate_limit {
+			zone {{$.Name}} {
+				key {remote_host}
+				events {{.Requests}}
+				window {{.Window}}
+			}
+		}
+{{- end}}
+{{- with .Auth}}
+{{- if eq .Type "basic"}}
+		basicauth {
+			{{.Username}} {{base64 .Password}}
+		}
+{{- else}}
+		forward_auth {{authUpstream}} {
+			uri {{authURI $.Name}}
+		}
+{{- end}}
+{{- end}}
+		encode gzip zstd
+		reverse_proxy {{join (upstreams .) " "}} {
+{{- if .LoadBalancing}}
+			lb_policy {{.LoadBalancing}}
+{{- end}}
+{{- with .HealthCheck}}
+			health_uri {{.Path}}
+{{- if .Interval}}
+			health_interval {{.Interval}}
+{{- end}}
+{{- if .Timeout}}
+			health_timeout {{.Timeout}}
+{{- end}}
+{{- if .ExpectStatus}}
+			health_status {{.ExpectStatus}}
+{{- end}}
+{{- end}}
+{{- with .Headers}}
+{{- range $name, $value := .Request}}
+			{{header "header_up" $name $value}}
+{{- end}}
+{{- range $name, $value := .Response}}
+			{{header "header_down" $name $value}}
+{{- end}}
+{{- end}}
+{{- if and .Auth (eq .Auth.Type "wallet")}}
+			header_up -Authorization
+{{- end}}
+		}
+	}
 	log {
 		output file /var/log/caddy/{{.Domain}}.access.log {
 			roll_size 3MiB
@@ -24,18 +83,41 @@ var (
 		}
 		format console
 	}
-	encode gzip zstd
+{{- if and .TLS (eq .TLS.Issuer "internal")}}
 
-	tls support@netsepio.com {
+	tls internal
+{{- else}}
+
+	tls {{tlsEmail .}} {
+{{- if and .TLS .TLS.CA}}
+		ca {{.TLS.CA}}
+{{- end}}
 		protocols tls1.2 tls1.3
 	}
+{{- end}}
 }
 `
 )
 
+var funcs = template.FuncMap{
+	"join":         strings.Join,
+	"base64":       func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) },
+	"upstreams":    Upstreams,
+	"pathPattern":  PathPattern,
+	"authUpstream": AuthUpstream,
+	"authURI":      AuthURI,
+	"tlsEmail":     TLSEmail,
+	"header": func(directive string, name string, value string) string {
+		if value == "" {
+			return directive + " -" + name
+		}
+		return directive + " " + name + " " + strconv.Quote(value)
+	},
+}
+
 // Caddy configuration file template
 func CaddyConfigTempl(tunnel model.Service) ([]byte, error) {
-	t, err := template.New("config").Parse(caddyTpl)
+	t, err := template.New("config").Funcs(funcs).Parse(caddyTpl)
 	if err != nil {
 		return nil, err
 	}
@@ -46,24 +128,39 @@ func CaddyConfigTempl(tunnel model.Service) ([]byte, error) {
 		return nil, err
 	}
 
-	// Get the directory path and ensure it exists
-	configDir := os.Getenv("CADDY_CONF_DIR")
-	if configDir == "" {
-		return nil, fmt.Errorf("CADDY_CONF_DIR environment variable is not set")
-	}
+	return tplBuff.Bytes(), nil
+}
 
-	// Ensure the directory exists
-	err = os.MkdirAll(configDir, 0755) // 0755 for read/write/execute permissions
-	if err != nil {
-		return nil, fmt.Errorf("error creating directory %s: %w", configDir, err)
+// Upstreams returns the upstreams of a service, its IpAddress and Port when it declares none
+func Upstreams(service model.Service) []string {
+	if len(service.Upstreams) > 0 {
+		return service.Upstreams
 	}
+	return []string{net.JoinHostPort(service.IpAddress, service.Port)}
+}
 
-	// Write the file
-	configFilePath := filepath.Join(configDir, os.Getenv("CADDY_INTERFACE_NAME"))
-	err = core.Writefile(configFilePath, tplBuff.Bytes())
-	if err != nil {
-		return nil, fmt.Errorf("error writing file %s: %w", configFilePath, err)
+// PathPattern returns the path matcher of a path prefix
+func PathPattern(prefix string) string {
+	if strings.HasSuffix(prefix, "*") {
+		return prefix
 	}
+	return prefix + "*"
+}
 
-	return tplBuff.Bytes(), nil
+// AuthUpstream returns the address of the API checking the wallet tokens of protected services
+func AuthUpstream() string {
+	return "localhost:" + os.Getenv("HTTP_PORT")
+}
+
+// AuthURI returns the route of the API checking the wallet tokens of a service
+func AuthURI(name string) string {
+	return "/api/v1.0/caddy/" + name + "/auth"
+}
+
+// TLSEmail returns the ACME account email of a service
+func TLSEmail(service model.Service) string {
+	if service.TLS != nil && service.TLS.Email != "" {
+		return service.TLS.Email
+	}
+	return DefaultTLSEmail
 }
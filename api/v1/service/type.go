@@ -1,7 +1,53 @@
 package caddy
 
+import (
+	"github.com/NetSepio/nexus/api/v1/middleware"
+	"github.com/NetSepio/nexus/model"
+)
+
 type ServicePayload struct {
-	Name      string `json:"name" binding:"required"`
-	IPAddress string `json:"ipAddress" binding:"required"`
-	Port      string `json:"port" binding:"required"`
+	Name          string                    `json:"name" binding:"required"`
+	IPAddress     string                    `json:"ipAddress" binding:"required"`
+	Port          string                    `json:"port" binding:"required"`
+	Paths         []string                  `json:"paths"`
+	Upstreams     []string                  `json:"upstreams"`
+	LoadBalancing string                    `json:"loadBalancing"`
+	HealthCheck   *model.ServiceHealthCheck `json:"healthCheck"`
+	Headers       *model.ServiceHeaders     `json:"headers"`
+	Auth          *model.ServiceAuth        `json:"auth"`
+	TLS           *model.ServiceTLS         `json:"tls"`
+	RateLimit     *model.ServiceRateLimit   `json:"rateLimit"`
+}
+
+// ServiceUpdate is the body of PATCH /caddy/:name, omitted fields keep their value and the
+// options named in clear are removed
+type ServiceUpdate struct {
+	IPAddress     string                    `json:"ipAddress"`
+	Port          string                    `json:"port"`
+	Paths         []string                  `json:"paths"`
+	Upstreams     []string                  `json:"upstreams"`
+	LoadBalancing string                    `json:"loadBalancing"`
+	HealthCheck   *model.ServiceHealthCheck `json:"healthCheck"`
+	Headers       *model.ServiceHeaders     `json:"headers"`
+	Auth          *model.ServiceAuth        `json:"auth"`
+	TLS           *model.ServiceTLS         `json:"tls"`
+	RateLimit     *model.ServiceRateLimit   `json:"rateLimit"`
+	Clear         []string                  `json:"clear"`
+}
+
+// changes returns the update as the changes merged by middleware.MergeService
+func (u ServiceUpdate) changes() middleware.ServiceChanges {
+	return middleware.ServiceChanges{
+		IpAddress:     u.IPAddress,
+		Port:          u.Port,
+		Paths:         u.Paths,
+		Upstreams:     u.Upstreams,
+		LoadBalancing: u.LoadBalancing,
+		HealthCheck:   u.HealthCheck,
+		Headers:       u.Headers,
+		Auth:          u.Auth,
+		TLS:           u.TLS,
+		RateLimit:     u.RateLimit,
+		Clear:         u.Clear,
+	}
 }
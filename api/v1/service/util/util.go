@@ -5,6 +5,7 @@ import (
 	"regexp"
 
 	"github.com/NetSepio/nexus/model"
+	nexusutil "github.com/NetSepio/nexus/util"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -26,14 +27,9 @@ func ReadFile(path string) (bytes []byte, err error) {
 	return bytes, nil
 }
 
-// WriteFile content to file
+// WriteFile content to file atomically
 func WriteFile(path string, bytes []byte) (err error) {
-	err = os.WriteFile(path, bytes, 0644)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return nexusutil.WriteFile(path, bytes)
 }
 
 // FileExists check if file exists
@@ -0,0 +1,21 @@
+package core
+
+import (
+	"github.com/NetSepio/nexus/model"
+	"github.com/NetSepio/nexus/util/pkg/policy"
+)
+
+// OwnsClient reports whether wallet created the client or is the wallet it was issued to
+func OwnsClient(wallet string, client *model.Client) bool {
+	return policy.SameWallet(client.CreatedBy, wallet) || policy.SameWallet(client.WalletAddress, wallet)
+}
+
+// CanReadClient reports whether the principal may read the client
+func CanReadClient(p policy.Principal, client *model.Client) bool {
+	return p.ReadsAll() || OwnsClient(p.Wallet, client)
+}
+
+// CanManageClient reports whether the principal may update, delete or rotate the client
+func CanManageClient(p policy.Principal, client *model.Client) bool {
+	return p.IsAdmin() || (p.Has(policy.ScopeClientsWrite) && OwnsClient(p.Wallet, client))
+}
@@ -0,0 +1,274 @@
+package core
+
+import (
+	"errors"
+	"time"
+
+	"github.com/NetSepio/nexus/model"
+	"github.com/NetSepio/nexus/storage"
+	"github.com/NetSepio/nexus/util/pkg/stats"
+	log "github.com/sirupsen/logrus"
+)
+
+// Reasons for the accountant to disable a client
+const (
+	DisabledQuota   = "quota"
+	DisabledExpired = "expired"
+)
+
+// StartAccountant folds the peer counters into the persisted usage of every client on each
+// tick and disables the clients that reached a quota or their expiry time
+func StartAccountant(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		for now := range ticker.C {
+			if err := accountUsage(now); err != nil {
+				log.WithFields(log.Fields{
+					"err": err,
+				}).Error("failed to account client usage")
+			}
+		}
+	}()
+}
+
+// accountUsage runs a single accounting pass
+func accountUsage(now time.Time) error {
+	// expiry is still enforced when the device cannot be read
+	peers, err := ReadPeerStats()
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Debug("peer statistics unavailable, accounting without traffic")
+	}
+
+	wgMu.Lock()
+	defer wgMu.Unlock()
+
+	clients, err := readStoredClients()
+	if err != nil {
+		return err
+	}
+
+	peersChanged := false
+	updated := make([]*model.Client, 0)
+	for _, client := range clients {
+		dirty := accountClient(client, peers, now)
+
+		if enforceLimits(client, now) {
+			dirty = true
+			peersChanged = true
+		}
+
+		if dirty {
+			if err := storage.Get().WriteClient(client); err != nil {
+				return err
+			}
+			updated = append(updated, client)
+		}
+	}
+
+	if peersChanged {
+		if err := updateServerConfigWg(); err != nil {
+			return err
+		}
+	}
+	for _, client := range updated {
+		publishClient(EventClientUpdated, client)
+	}
+	return nil
+}
+
+// accountClient adds the traffic seen since the previous pass to the client usage and starts
+// a new period on day and month boundaries, it reports whether the client changed. peers are
+// the peers of the device, nil when it could not be read
+func accountClient(client *model.Client, peers map[string]*stats.PeerStats, now time.Time) bool {
+	dirty := false
+	peer := peers[client.PublicKey]
+
+	if client.UsageUpdatedAt != 0 {
+		last := time.UnixMilli(client.UsageUpdatedAt).UTC()
+		current := now.UTC()
+		if last.Year() != current.Year() || last.YearDay() != current.YearDay() {
+			client.DailyUsage = 0
+			dirty = true
+		}
+		if last.Year() != current.Year() || last.Month() != current.Month() {
+			client.MonthlyUsage = 0
+			client.TopUpBytes = 0
+			dirty = true
+		}
+	}
+
+	// the counters of a peer start from zero once it is added again
+	if peers != nil && peer == nil && resetCounters(client) {
+		dirty = true
+	}
+
+	if peer != nil {
+		// counters go back to zero when the interface or the peer is recreated
+		received := peer.ReceivedBytes - client.CounterReceiveBytes
+		if peer.ReceivedBytes < client.CounterReceiveBytes {
+			received = peer.ReceivedBytes
+		}
+		transmitted := peer.TransmittedBytes - client.CounterTransmitBytes
+		if peer.TransmittedBytes < client.CounterTransmitBytes {
+			transmitted = peer.TransmittedBytes
+		}
+
+		if delta := received + transmitted; delta > 0 {
+			client.DailyUsage += delta
+			client.MonthlyUsage += delta
+			client.TotalUsage += delta
+			dirty = true
+		}
+		if client.CounterReceiveBytes != peer.ReceivedBytes || client.CounterTransmitBytes != peer.TransmittedBytes {
+			client.CounterReceiveBytes = peer.ReceivedBytes
+			client.CounterTransmitBytes = peer.TransmittedBytes
+			dirty = true
+		}
+	}
+
+	if dirty || client.UsageUpdatedAt == 0 {
+		client.UsageUpdatedAt = now.UnixMilli()
+		dirty = true
+	}
+
+	return dirty
+}
+
+// limitReached returns why the client must be disabled, empty if it is within its limits
+func limitReached(client *model.Client, now time.Time) string {
+	if client.ExpiresAt != 0 && now.UnixMilli() >= client.ExpiresAt {
+		return DisabledExpired
+	}
+	if client.DailyQuota != 0 && client.DailyUsage >= client.DailyQuota {
+		return DisabledQuota
+	}
+	if client.MonthlyQuota != 0 && client.MonthlyUsage >= client.MonthlyQuota+client.TopUpBytes {
+		return DisabledQuota
+	}
+	return ""
+}
+
+// enforceLimits disables a client over its limits and re-enables one the accountant disabled
+// once it is back within them, it reports whether the client enable state changed
+func enforceLimits(client *model.Client, now time.Time) bool {
+	reason := limitReached(client, now)
+
+	if client.Enable && reason != "" {
+		client.Enable = false
+		client.DisabledReason = reason
+		log.WithFields(log.Fields{
+			"uuid":   client.UUID,
+			"reason": reason,
+		}).Info("client disabled")
+		return true
+	}
+
+	if !client.Enable && client.DisabledReason != "" && reason == "" {
+		client.Enable = true
+		client.DisabledReason = ""
+		// the peer was removed while disabled
+		resetCounters(client)
+		log.WithFields(log.Fields{
+			"uuid": client.UUID,
+		}).Info("client enabled again")
+		return true
+	}
+
+	return false
+}
+
+// keepUsage copies the accounting state maintained by the node from current to client
+func keepUsage(client *model.Client, current *model.Client) {
+	client.DailyUsage = current.DailyUsage
+	client.MonthlyUsage = current.MonthlyUsage
+	client.TotalUsage = current.TotalUsage
+	client.TopUpBytes = current.TopUpBytes
+	client.UsageUpdatedAt = current.UsageUpdatedAt
+	client.CounterReceiveBytes = current.CounterReceiveBytes
+	client.CounterTransmitBytes = current.CounterTransmitBytes
+	if client.Enable && !current.Enable {
+		// the peer of a disabled client is created again
+		resetCounters(client)
+	}
+	if client.Enable {
+		client.DisabledReason = ""
+	} else {
+		client.DisabledReason = current.DisabledReason
+	}
+}
+
+// resetCounters forgets the peer counters of a client whose peer is gone, it reports whether
+// they were set
+func resetCounters(client *model.Client) bool {
+	if client.CounterReceiveBytes == 0 && client.CounterTransmitBytes == 0 {
+		return false
+	}
+	client.CounterReceiveBytes = 0
+	client.CounterTransmitBytes = 0
+	return true
+}
+
+// KeepClientLimits copies the fields only admins may change from current to client: the quotas,
+// rates and expiry time, the address pool and tags, and the enable state of a client the node
+// disabled for its limits
+func KeepClientLimits(client *model.Client, current *model.Client) {
+	client.Pool = current.Pool
+	client.Tags = current.Tags
+	client.DailyQuota = current.DailyQuota
+	client.MonthlyQuota = current.MonthlyQuota
+	client.ExpiresAt = current.ExpiresAt
+	client.UploadRate = current.UploadRate
+	client.DownloadRate = current.DownloadRate
+	client.DisabledReason = current.DisabledReason
+	if current.DisabledReason != "" {
+		client.Enable = current.Enable
+	}
+}
+
+// ResetClientUsage zeroes the daily and monthly usage of a client, re-enabling it when it was
+// disabled for its quota
+func ResetClientUsage(id string) (*model.Client, error) {
+	return updateUsage(id, func(client *model.Client) {
+		client.DailyUsage = 0
+		client.MonthlyUsage = 0
+	})
+}
+
+// TopUpClient adds bytes to the client allowance for the current month
+func TopUpClient(id string, bytes int64) (*model.Client, error) {
+	if bytes <= 0 {
+		return nil, errors.New("top-up must be a positive number of bytes")
+	}
+	return updateUsage(id, func(client *model.Client) {
+		client.TopUpBytes += bytes
+	})
+}
+
+func updateUsage(id string, fn func(client *model.Client)) (*model.Client, error) {
+	wgMu.Lock()
+	defer wgMu.Unlock()
+
+	client, err := storage.Get().ReadClient(id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	accountClient(client, nil, now)
+	fn(client)
+	changed := enforceLimits(client, now)
+
+	if err := storage.Get().WriteClient(client); err != nil {
+		return nil, err
+	}
+
+	if changed {
+		if err := updateServerConfigWg(); err != nil {
+			return client, err
+		}
+	}
+	publishClient(EventClientUpdated, client)
+	return client, nil
+}
@@ -0,0 +1,121 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NetSepio/nexus/model"
+	"github.com/NetSepio/nexus/util/pkg/stats"
+)
+
+func TestAccountClient(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	earlier := now.Add(-time.Minute).UnixMilli()
+
+	for _, tc := range []struct {
+		name     string
+		client   *model.Client
+		peers    map[string]*stats.PeerStats
+		usage    int64
+		counters [2]int64
+	}{
+		{
+			name:     "traffic since the previous pass",
+			client:   &model.Client{PublicKey: "k", UsageUpdatedAt: earlier, DailyUsage: 100, CounterReceiveBytes: 1000, CounterTransmitBytes: 500},
+			peers:    map[string]*stats.PeerStats{"k": {ReceivedBytes: 1300, TransmittedBytes: 600}},
+			usage:    500,
+			counters: [2]int64{1300, 600},
+		},
+		{
+			name:     "counters reset below the stored ones",
+			client:   &model.Client{PublicKey: "k", UsageUpdatedAt: earlier, DailyUsage: 100, CounterReceiveBytes: 1000, CounterTransmitBytes: 500},
+			peers:    map[string]*stats.PeerStats{"k": {ReceivedBytes: 200, TransmittedBytes: 100}},
+			usage:    400,
+			counters: [2]int64{200, 100},
+		},
+		{
+			name:     "peer gone forgets the counters",
+			client:   &model.Client{PublicKey: "k", UsageUpdatedAt: earlier, DailyUsage: 100, CounterReceiveBytes: 1000, CounterTransmitBytes: 500},
+			peers:    map[string]*stats.PeerStats{},
+			usage:    100,
+			counters: [2]int64{0, 0},
+		},
+		{
+			name:     "unreadable device keeps the counters",
+			client:   &model.Client{PublicKey: "k", UsageUpdatedAt: earlier, DailyUsage: 100, CounterReceiveBytes: 1000, CounterTransmitBytes: 500},
+			peers:    nil,
+			usage:    100,
+			counters: [2]int64{1000, 500},
+		},
+		{
+			name:     "new day starts from zero",
+			client:   &model.Client{PublicKey: "k", UsageUpdatedAt: now.Add(-24 * time.Hour).UnixMilli(), DailyUsage: 100, CounterReceiveBytes: 1000},
+			peers:    map[string]*stats.PeerStats{"k": {ReceivedBytes: 1050}},
+			usage:    50,
+			counters: [2]int64{1050, 0},
+		},
+	} {
+		client := tc.client
+		accountClient(client, tc.peers, now)
+		if client.DailyUsage != tc.usage || client.CounterReceiveBytes != tc.counters[0] || client.CounterTransmitBytes != tc.counters[1] {
+			t.Errorf("%s: expected usage %d and counters %v, got %d and [%d %d]", tc.name, tc.usage, tc.counters,
+				client.DailyUsage, client.CounterReceiveBytes, client.CounterTransmitBytes)
+		}
+	}
+
+	// a peer removed and added again within one interval is billed for everything it counted
+	client := model.Client{PublicKey: "k", UsageUpdatedAt: earlier, CounterReceiveBytes: 1000, Enable: true}
+	accountClient(&client, map[string]*stats.PeerStats{}, now)
+	accountClient(&client, map[string]*stats.PeerStats{"k": {ReceivedBytes: 1500}}, now.Add(time.Minute))
+	if client.DailyUsage != 1500 {
+		t.Errorf("expected the recreated peer billed 1500 bytes, got %d", client.DailyUsage)
+	}
+}
+
+func TestEnforceLimits(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name    string
+		client  *model.Client
+		changed bool
+		enable  bool
+		reason  string
+	}{
+		{"within limits", &model.Client{Enable: true, DailyQuota: 100, DailyUsage: 99}, false, true, ""},
+		{"daily quota", &model.Client{Enable: true, DailyQuota: 100, DailyUsage: 100}, true, false, DisabledQuota},
+		{"monthly quota", &model.Client{Enable: true, MonthlyQuota: 100, MonthlyUsage: 150}, true, false, DisabledQuota},
+		{"monthly top-up", &model.Client{Enable: true, MonthlyQuota: 100, TopUpBytes: 100, MonthlyUsage: 150}, false, true, ""},
+		{"expired", &model.Client{Enable: true, ExpiresAt: now.UnixMilli()}, true, false, DisabledExpired},
+		{"not yet expired", &model.Client{Enable: true, ExpiresAt: now.Add(time.Second).UnixMilli()}, false, true, ""},
+		{"back within limits", &model.Client{DisabledReason: DisabledQuota, DailyQuota: 100, DailyUsage: 10, CounterReceiveBytes: 5}, true, true, ""},
+		{"disabled by its owner", &model.Client{DailyQuota: 100, DailyUsage: 10}, false, false, ""},
+	} {
+		client := tc.client
+		changed := enforceLimits(client, now)
+		if changed != tc.changed || client.Enable != tc.enable || client.DisabledReason != tc.reason {
+			t.Errorf("%s: expected changed %v, enable %v and reason %q, got %v, %v and %q", tc.name, tc.changed, tc.enable, tc.reason,
+				changed, client.Enable, client.DisabledReason)
+		}
+		if tc.changed && tc.enable && client.CounterReceiveBytes != 0 {
+			t.Errorf("%s: expected the counters of the re-enabled client reset", tc.name)
+		}
+	}
+}
+
+func TestKeepClientLimits(t *testing.T) {
+	current := &model.Client{DailyQuota: 100, UploadRate: 10, ExpiresAt: 1, Pool: "vip", Tags: []string{"gold"}, Enable: false, DisabledReason: DisabledQuota}
+	client := &model.Client{DailyQuota: 0, UploadRate: 0, Pool: "other", Enable: true}
+	KeepClientLimits(client, current)
+	if client.DailyQuota != 100 || client.UploadRate != 10 || client.ExpiresAt != 1 || client.Pool != "vip" || len(client.Tags) != 1 ||
+		client.Enable || client.DisabledReason != DisabledQuota {
+		t.Errorf("expected the limits and the disabled state kept, got %+v", client)
+	}
+
+	// owners still switch their own clients on and off
+	client = &model.Client{Enable: false}
+	KeepClientLimits(client, &model.Client{Enable: true})
+	if client.Enable || client.DisabledReason != "" {
+		t.Errorf("expected the client disabled by its owner, got %+v", client)
+	}
+}
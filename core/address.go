@@ -0,0 +1,434 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/NetSepio/nexus/model"
+	"github.com/NetSepio/nexus/storage"
+	"github.com/NetSepio/nexus/util/pkg/ipam"
+	log "github.com/sirupsen/logrus"
+)
+
+// maxFreeRanges caps the free ranges reported per pool, a fragmented /64 has no useful bound
+const maxFreeRanges = 32
+
+// addressSpace holds one allocator per server network and the allocators of the pools carved
+// out of them, pool addresses are only handed out to the clients of the pool
+type addressSpace struct {
+	networks []*ipam.Allocator
+	pools    map[string][]*ipam.Allocator
+	// carved counts the addresses of each network handed over to pools
+	carved map[*ipam.Allocator]*big.Int
+}
+
+// newAddressSpace returns the address space of the server with the server addresses, the pool
+// exclusions and the addresses of every client except skip already reserved
+func newAddressSpace(server *model.Server, clients []*model.Client, skip string) (*addressSpace, error) {
+	space := &addressSpace{
+		networks: make([]*ipam.Allocator, 0, len(server.Address)),
+		pools:    make(map[string][]*ipam.Allocator, len(server.Pools)),
+		carved:   make(map[*ipam.Allocator]*big.Int),
+	}
+	for _, cidr := range server.Address {
+		allocator, err := ipam.New(cidr)
+		if err != nil {
+			return nil, err
+		}
+		space.networks = append(space.networks, allocator)
+	}
+
+	reserve := func(cidr string) {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"err":  err,
+				"cidr": cidr,
+			}).Error("failed to ip from cidr")
+			return
+		}
+		// addresses outside of the server networks cannot collide with new ones
+		if allocator := allocatorFor(space.networks, prefix.Addr()); allocator != nil {
+			allocator.Reserve(prefix.Addr())
+		}
+	}
+
+	for _, cidr := range server.Address {
+		reserve(cidr)
+	}
+	for _, client := range clients {
+		if client.UUID == skip {
+			continue
+		}
+		for _, cidr := range client.Address {
+			reserve(cidr)
+		}
+	}
+
+	// pools inherit the reservations of their network before being removed from it
+	carved := make(map[*ipam.Allocator][]netip.Prefix)
+	for _, pool := range server.Pools {
+		for _, cidr := range pool.Address {
+			prefix, err := netip.ParsePrefix(cidr)
+			if err != nil {
+				return nil, err
+			}
+			network := allocatorFor(space.networks, prefix.Addr())
+			if network == nil {
+				return nil, fmt.Errorf("%w: pool %s address %s is not inside the server address", ErrInvalid, pool.Name, cidr)
+			}
+			allocator, err := network.Sub(prefix)
+			if err != nil {
+				return nil, err
+			}
+			for _, exclude := range pool.Exclude {
+				r, err := ipam.ParseRange(exclude)
+				if err != nil {
+					return nil, err
+				}
+				allocator.ReserveRange(r)
+			}
+			space.pools[pool.Name] = append(space.pools[pool.Name], allocator)
+			carved[network] = append(carved[network], prefix)
+			if space.carved[network] == nil {
+				space.carved[network] = new(big.Int)
+			}
+			space.carved[network].Add(space.carved[network], allocator.Size())
+		}
+	}
+	for network, prefixes := range carved {
+		for _, prefix := range prefixes {
+			network.ReserveRange(ipam.PrefixRange(prefix))
+		}
+	}
+
+	return space, nil
+}
+
+// allocatorsFor returns the allocators a client draws its addresses from. The pool is the one
+// named by the client or else the first one sharing a tag with it, its networks replace the
+// server networks they are carved from.
+func (s *addressSpace) allocatorsFor(client *model.Client, pools []*model.Pool) ([]*ipam.Allocator, error) {
+	name := client.Pool
+	if name == "" {
+		for _, pool := range pools {
+			if slices.ContainsFunc(pool.Tags, func(tag string) bool { return slices.Contains(client.Tags, tag) }) {
+				name = pool.Name
+				break
+			}
+		}
+	}
+	if name == "" {
+		return s.networks, nil
+	}
+
+	allocators, ok := s.pools[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: pool %s does not exist", ErrInvalid, name)
+	}
+	allocators = slices.Clone(allocators)
+	for _, network := range s.networks {
+		covered := slices.ContainsFunc(allocators, func(pool *ipam.Allocator) bool {
+			return network.Prefix().Contains(pool.Prefix().Addr())
+		})
+		if !covered {
+			allocators = append(allocators, network)
+		}
+	}
+
+	return allocators, nil
+}
+
+// allocatorFor returns the allocator whose prefix contains addr, nil if none
+func allocatorFor(allocators []*ipam.Allocator, addr netip.Addr) *ipam.Allocator {
+	for _, allocator := range allocators {
+		if allocator.Prefix().Contains(addr.Unmap()) {
+			return allocator
+		}
+	}
+	return nil
+}
+
+// allocateAddresses returns one host address per allocator. Each requested entry is either a
+// network to allocate from or a /32 or /128 host address to reserve as is, allocators not
+// requested hand out their lowest free address.
+func allocateAddresses(requested []string, allocators []*ipam.Allocator) ([]string, error) {
+	ips := make([]string, 0, len(allocators))
+	used := make(map[*ipam.Allocator]bool, len(allocators))
+
+	for _, cidr := range requested {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, err
+		}
+
+		var allocator *ipam.Allocator
+		if prefix.IsSingleIP() {
+			allocator = allocatorFor(allocators, prefix.Addr())
+		} else {
+			for _, a := range allocators {
+				if a.Prefix().Overlaps(prefix) {
+					allocator = a
+					break
+				}
+			}
+		}
+		if allocator == nil {
+			return nil, fmt.Errorf("%w: address %s is not inside the networks available to the client", ErrInvalid, cidr)
+		}
+		if used[allocator] {
+			return nil, fmt.Errorf("%w: only one address per server network can be assigned, %s is a duplicate", ErrInvalid, cidr)
+		}
+		used[allocator] = true
+
+		if prefix.IsSingleIP() {
+			if err := allocator.Reserve(prefix.Addr()); err != nil {
+				if errors.Is(err, ipam.ErrOutOfRange) {
+					return nil, fmt.Errorf("%w: address %s cannot be assigned to a client", ErrInvalid, cidr)
+				}
+				if errors.Is(err, ipam.ErrInUse) {
+					return nil, fmt.Errorf("%w: address %s is already assigned", ErrConflict, cidr)
+				}
+				return nil, err
+			}
+			ips = append(ips, hostPrefix(prefix.Addr()))
+			continue
+		}
+
+		addr, err := allocator.Allocate()
+		if err != nil {
+			return nil, allocateError(allocator, err)
+		}
+		ips = append(ips, hostPrefix(addr))
+	}
+
+	for _, allocator := range allocators {
+		if used[allocator] {
+			continue
+		}
+		addr, err := allocator.Allocate()
+		if err != nil {
+			return nil, allocateError(allocator, err)
+		}
+		ips = append(ips, hostPrefix(addr))
+	}
+
+	return ips, nil
+}
+
+// allocateError reports an allocator without free address as ErrPoolExhausted
+func allocateError(allocator *ipam.Allocator, err error) error {
+	if errors.Is(err, ipam.ErrExhausted) {
+		return fmt.Errorf("%w: no free address left in %s", ErrPoolExhausted, allocator.Prefix())
+	}
+	return err
+}
+
+// assignAddresses replaces the requested addresses of client with the assigned ones, the previous
+// addresses outside the pool the client now draws from are reassigned. Callers must hold wgMu.
+func assignAddresses(client *model.Client, skip string, previous []string) error {
+	clients, err := readStoredClients()
+	if err != nil {
+		return err
+	}
+	server, err := readServer()
+	if err != nil {
+		return err
+	}
+
+	space, err := newAddressSpace(server, clients, skip)
+	if err != nil {
+		return err
+	}
+	allocators, err := space.allocatorsFor(client, server.Pools)
+	if err != nil {
+		return err
+	}
+	client.Address = slices.DeleteFunc(client.Address, func(cidr string) bool {
+		return slices.Contains(previous, cidr) && !space.holds(allocators, cidr)
+	})
+
+	client.Address, err = allocateAddresses(client.Address, allocators)
+	return err
+}
+
+// hostPrefix returns addr as a /32 or /128 cidr
+func hostPrefix(addr netip.Addr) string {
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()).String()
+}
+
+// ReadPools returns the utilization of every pool followed by what is left of the server
+// networks once the pools are carved out, named "default"
+func ReadPools() ([]*model.PoolUsage, error) {
+	clients, err := readStoredClients()
+	if err != nil {
+		return nil, err
+	}
+	server, err := ReadServer()
+	if err != nil {
+		return nil, err
+	}
+
+	space, err := newAddressSpace(server, clients, "")
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make([]*model.PoolUsage, 0)
+	for _, pool := range server.Pools {
+		for _, allocator := range space.pools[pool.Name] {
+			usage = append(usage, poolUsage(pool.Name, allocator, nil))
+		}
+	}
+	for _, allocator := range space.networks {
+		usage = append(usage, poolUsage("default", allocator, space.carved[allocator]))
+	}
+
+	return usage, nil
+}
+
+// poolUsage reports the utilization of allocator, the carved addresses are reserved in the
+// allocator but belong to the pools and count neither in its size nor as used
+func poolUsage(name string, allocator *ipam.Allocator, carved *big.Int) *model.PoolUsage {
+	size, used := allocator.Size(), allocator.Used()
+	if carved != nil {
+		size.Sub(size, carved)
+		used.Sub(used, carved)
+	}
+	remaining := new(big.Int).Sub(size, used)
+
+	free := make([]string, 0)
+	for _, r := range allocator.FreeRanges(maxFreeRanges) {
+		free = append(free, r.String())
+	}
+
+	return &model.PoolUsage{
+		Name:       name,
+		Address:    allocator.Prefix().String(),
+		Size:       size.String(),
+		Used:       used.String(),
+		Free:       remaining.String(),
+		FreeRanges: free,
+	}
+}
+
+// PoolConflictError is returned when new pools would leave clients with addresses outside the
+// networks they draw from
+type PoolConflictError struct {
+	Clients []*model.Client
+}
+
+func (e *PoolConflictError) Error() string {
+	ids := make([]string, len(e.Clients))
+	for i, client := range e.Clients {
+		ids[i] = client.UUID
+	}
+	return fmt.Sprintf("%s: the pools leave the addresses of clients %s outside their pools", ErrConflict, strings.Join(ids, ", "))
+}
+
+func (e *PoolConflictError) Unwrap() error {
+	return ErrConflict
+}
+
+// orphanedClients returns the clients whose addresses fit the current pools of server but not
+// its new ones, clients that did not fit before are left alone
+func orphanedClients(server *model.Server, current []*model.Pool, clients []*model.Client) ([]*model.Client, error) {
+	before, err := newAddressSpace(&model.Server{Address: server.Address, Pools: current}, clients, "")
+	if err != nil {
+		return nil, err
+	}
+	after, err := newAddressSpace(server, clients, "")
+	if err != nil {
+		return nil, err
+	}
+
+	orphaned := make([]*model.Client, 0)
+	for _, client := range clients {
+		if before.fits(client, current) && !after.fits(client, server.Pools) {
+			orphaned = append(orphaned, client)
+		}
+	}
+	return orphaned, nil
+}
+
+// fits reports whether every address of client lies in the networks it draws from and outside
+// the pools of other clients
+func (s *addressSpace) fits(client *model.Client, pools []*model.Pool) bool {
+	allocators, err := s.allocatorsFor(client, pools)
+	if err != nil {
+		return false
+	}
+	return !slices.ContainsFunc(client.Address, func(cidr string) bool { return !s.holds(allocators, cidr) })
+}
+
+// holds reports whether the address of cidr belongs to allocators and not to a pool carved out
+// of them
+func (s *addressSpace) holds(allocators []*ipam.Allocator, cidr string) bool {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return false
+	}
+	allocator := allocatorFor(allocators, prefix.Addr())
+	return allocator != nil && !(slices.Contains(s.networks, allocator) && s.pooled(prefix.Addr()))
+}
+
+// pooled reports whether addr belongs to one of the pools
+func (s *addressSpace) pooled(addr netip.Addr) bool {
+	for _, allocators := range s.pools {
+		if allocatorFor(allocators, addr) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdatePools replaces the address pools of the server, it refuses pools leaving clients with
+// addresses outside their pools
+func UpdatePools(pools []*model.Pool) ([]*model.PoolUsage, error) {
+	wgMu.Lock()
+	defer wgMu.Unlock()
+
+	server, err := storage.Get().ReadServer()
+	if err != nil {
+		return nil, err
+	}
+	current := server.Pools
+	server.Pools = pools
+
+	// check if server is valid
+	errs := server.IsValid()
+	if len(errs) != 0 {
+		for _, err := range errs {
+			log.WithFields(log.Fields{
+				"err": err,
+			}).Error("pools validation error")
+		}
+		return nil, fmt.Errorf("%w: failed to validate pools", ErrInvalid)
+	}
+
+	clients, err := readStoredClients()
+	if err != nil {
+		return nil, err
+	}
+	orphaned, err := orphanedClients(server, current, clients)
+	if err != nil {
+		return nil, err
+	}
+	if len(orphaned) != 0 {
+		return nil, &PoolConflictError{Clients: orphaned}
+	}
+
+	server.UpdatedAt = int64(time.Now().Nanosecond())
+	err = storage.Get().WriteServer(server)
+	if err != nil {
+		return nil, err
+	}
+
+	return ReadPools()
+}
@@ -0,0 +1,145 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/NetSepio/nexus/model"
+)
+
+func TestAddressSpacePools(t *testing.T) {
+	server := &model.Server{
+		Address: []string{"10.0.0.1/24"},
+		Pools:   []*model.Pool{{Name: "vip", Address: []string{"10.0.0.128/25"}, Exclude: []string{"10.0.0.128"}, Tags: []string{"gold"}}},
+	}
+	clients := []*model.Client{
+		{UUID: "a", Address: []string{"10.0.0.2/32"}},
+		{UUID: "b", Address: []string{"10.0.0.130/32"}},
+	}
+
+	space, err := newAddressSpace(server, clients, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		client  *model.Client
+		address string
+	}{
+		{"default network", &model.Client{}, "10.0.0.3/32"},
+		{"pool by name", &model.Client{Pool: "vip"}, "10.0.0.129/32"},
+		{"pool by tag", &model.Client{Tags: []string{"gold"}}, "10.0.0.131/32"},
+	} {
+		allocators, err := space.allocatorsFor(tc.client, server.Pools)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ips, err := allocateAddresses(nil, allocators)
+		if err != nil || len(ips) != 1 || ips[0] != tc.address {
+			t.Errorf("%s: expected %s, got %v, %v", tc.name, tc.address, ips, err)
+		}
+	}
+	if _, err := space.allocatorsFor(&model.Client{Pool: "missing"}, server.Pools); !errors.Is(err, ErrInvalid) {
+		t.Errorf("expected an unknown pool to be invalid, got %v", err)
+	}
+	if _, err := allocateAddresses([]string{"10.0.0.2/32"}, space.networks); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected an address in use to conflict, got %v", err)
+	}
+	full, err := newAddressSpace(&model.Server{Address: []string{"10.0.1.1/30"}}, []*model.Client{{UUID: "c", Address: []string{"10.0.1.2/32"}}}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := allocateAddresses(nil, full.networks); !errors.Is(err, ErrPoolExhausted) {
+		t.Errorf("expected a full network to be exhausted, got %v", err)
+	}
+
+	// the default network no longer counts the pool among its addresses
+	network := space.networks[0]
+	usage := poolUsage("default", network, space.carved[network])
+	if usage.Size != "127" || usage.Used != "3" || usage.Free != "124" {
+		t.Errorf("expected default size 127, used 3 and free 124, got %s, %s and %s", usage.Size, usage.Used, usage.Free)
+	}
+	pool := poolUsage("vip", space.pools["vip"][0], nil)
+	if pool.Size != "127" || pool.Used != "4" || pool.Free != "123" {
+		t.Errorf("expected vip size 127, used 4 and free 123, got %s, %s and %s", pool.Size, pool.Used, pool.Free)
+	}
+}
+
+func TestOrphanedClients(t *testing.T) {
+	vip := &model.Pool{Name: "vip", Address: []string{"10.0.0.128/25"}, Tags: []string{"gold"}}
+	server := &model.Server{Address: []string{"10.0.0.1/24"}}
+	clients := []*model.Client{
+		{UUID: "default", Address: []string{"10.0.0.2/32"}},
+		{UUID: "named", Pool: "vip", Address: []string{"10.0.0.130/32"}},
+		{UUID: "tagged", Tags: []string{"gold"}, Address: []string{"10.0.0.131/32"}},
+		// already outside every network, not made worse by the new pools
+		{UUID: "stray", Address: []string{"192.168.1.2/32"}},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		pools    []*model.Pool
+		orphaned []string
+	}{
+		{"unchanged", []*model.Pool{vip}, nil},
+		// tagged clients fall back to the server network that still holds their address
+		{"removed", nil, []string{"named"}},
+		{"shrunk", []*model.Pool{{Name: "vip", Address: []string{"10.0.0.192/26"}, Tags: []string{"gold"}}}, []string{"named", "tagged"}},
+		{"carved over clients", []*model.Pool{vip, {Name: "lab", Address: []string{"10.0.0.0/28"}}}, []string{"default"}},
+	} {
+		server.Pools = tc.pools
+		orphaned, err := orphanedClients(server, []*model.Pool{vip}, clients)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := make([]string, 0)
+		for _, client := range orphaned {
+			ids = append(ids, client.UUID)
+		}
+		if strings.Join(ids, ",") != strings.Join(tc.orphaned, ",") {
+			t.Errorf("%s: expected %v orphaned, got %v", tc.name, tc.orphaned, ids)
+		}
+	}
+}
+
+func TestUpdateClientTags(t *testing.T) {
+	store := useTestStore(t)
+	key := "yAnz5TF+lXXJte14tji3zlMNq+hd2rYUIgJBgB3fBmk="
+	server := &model.Server{
+		Address:    []string{"10.0.0.1/24"},
+		ListenPort: 51820,
+		PrivateKey: key,
+		PublicKey:  key,
+		Pools:      []*model.Pool{{Name: "vip", Address: []string{"10.0.0.128/25"}, Tags: []string{"gold"}}},
+	}
+	if err := store.WriteServer(server); err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := RegisterClient(&model.Client{Name: "laptop", Enable: true, AllowedIPs: []string{"0.0.0.0/0"}, Address: []string{}})
+	if err != nil || client.Address[0] != "10.0.0.2/32" {
+		t.Fatalf("expected an address of the server network, got %v, %v", client, err)
+	}
+
+	// the tags select the vip pool, the address follows
+	client.Tags = []string{"gold"}
+	client, err = UpdateClient(client.UUID, client)
+	if err != nil || client.Address[0] != "10.0.0.128/32" {
+		t.Fatalf("expected an address of the vip pool, got %v, %v", client, err)
+	}
+
+	// tags that keep the pool keep the address
+	client.Tags = []string{"gold", "laptop"}
+	client, err = UpdateClient(client.UUID, client)
+	if err != nil || client.Address[0] != "10.0.0.128/32" {
+		t.Fatalf("expected the vip address kept, got %v, %v", client, err)
+	}
+
+	client.Tags = nil
+	client, err = UpdateClient(client.UUID, client)
+	if err != nil || client.Address[0] != "10.0.0.2/32" {
+		t.Fatalf("expected an address of the server network again, got %v, %v", client, err)
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"net"
 	"net/http"
 	"os"
-	"os/exec"
 	"runtime"
 	"strings"
 	"bytes"
@@ -931,82 +930,28 @@ func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("up %s", strings.Join(parts, ", "))
 }
 
-// getBandwidthStats fetches the bandwidth stats of WireGuard clients
+// getBandwidthStats fetches the bandwidth stats of the online WireGuard clients
 func getBandwidthStats() ([]ClientStats, error) {
 	var clients []ClientStats
 
-	// Get the latest handshakes
-	cmd := exec.Command("bash", "-c", "wg show wg0 latest-handshakes")
-	var out bytes.Buffer
-	cmd.Stdout = &out
-	if err := cmd.Run(); err != nil {
-		return nil, err
-	}
-
-	nowCmd := exec.Command("date", "+%s")
-	nowOut, err := nowCmd.Output()
-	if err != nil {
-		return nil, err
-	}
-
-	now, err := strconv.Atoi(strings.TrimSpace(string(nowOut)))
+	peers, err := ReadPeerStats()
 	if err != nil {
 		return nil, err
 	}
 
-	var activeClients []string
-	for _, line := range strings.Split(out.String(), "\n") {
-		fields := strings.Fields(line)
-		if len(fields) < 2 {
+	for _, peer := range peers {
+		if !peer.Online {
 			continue
 		}
 
-		handshakeTime, err := strconv.Atoi(fields[1])
-		if err != nil {
-			continue
-		}
-
-		if handshakeTime > 0 && (now-handshakeTime) < 120 {
-			activeClients = append(activeClients, fields[0])
-		}
-	}
-
-	// Get the transfer stats
-	cmd = exec.Command("wg", "show", "wg0", "transfer")
-	out.Reset()
-	cmd.Stdout = &out
-	if err := cmd.Run(); err != nil {
-		return nil, err
-	}
+		rxMB := float64(peer.ReceivedBytes) / 1024 / 1024
+		txMB := float64(peer.TransmittedBytes) / 1024 / 1024
 
-	transferStats := out.String()
-	for _, client := range activeClients {
-		for _, line := range strings.Split(transferStats, "\n") {
-			if strings.Contains(line, client) {
-				fields := strings.Fields(line)
-				if len(fields) < 3 {
-					continue
-				}
-
-				rxBytes, err := strconv.ParseFloat(fields[1], 64)
-				if err != nil {
-					continue
-				}
-				txBytes, err := strconv.ParseFloat(fields[2], 64)
-				if err != nil {
-					continue
-				}
-
-				rxMB := rxBytes / 1024 / 1024
-				txMB := txBytes / 1024 / 1024
-
-				clients = append(clients, ClientStats{
-					Client: client,
-					RX:     strconv.FormatFloat(rxMB, 'f', 4, 64) + " MB",
-					TX:     strconv.FormatFloat(txMB, 'f', 4, 64) + " MB",
-				})
-			}
-		}
+		clients = append(clients, ClientStats{
+			Client: peer.PublicKey,
+			RX:     strconv.FormatFloat(rxMB, 'f', 4, 64) + " MB",
+			TX:     strconv.FormatFloat(txMB, 'f', 4, 64) + " MB",
+		})
 	}
 
 	return clients, nil
@@ -1112,6 +1057,10 @@ func createCheckpoint(nodeID string, instance *contract.Contract, auth *bind.Tra
 	}
 
 	duration := time.Since(startTime)
+	Publish(Event{Type: EventCheckpointSubmitted, Data: map[string]string{
+		"nodeId":      nodeID,
+		"transaction": tx.Hash().Hex(),
+	}})
 
 	fmt.Printf("\n%s%s%s\n", colorYellow, "═══════════ Checkpoint Created ═══════════", colorReset)
 	fmt.Printf("%s• Node ID:%s %s\n", colorCyan, colorReset, nodeID)
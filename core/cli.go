@@ -1,10 +1,21 @@
 package core
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/NetSepio/nexus/model"
 	"github.com/NetSepio/nexus/util"
+	"github.com/NetSepio/nexus/util/pkg/auth"
+	"github.com/NetSepio/nexus/util/pkg/claims"
+	"github.com/NetSepio/nexus/util/pkg/policy"
 	"github.com/spf13/cobra"
 )
 
@@ -84,6 +95,107 @@ var activateCmd = &cobra.Command{
 	},
 }
 
+var keysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Manage the WireGuard keys of the Erebrus node",
+}
+
+var rotateServerCmd = &cobra.Command{
+	Use:   "rotate-server",
+	Short: "Rotate the WireGuard keypair of the server",
+	Long: `Ask the running node to stage a new WireGuard keypair for the server. The node announces the new
+public key right away and switches to it once the overlap window elapsed, clients must fetch their new
+config in the meantime.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		overlap := ""
+		if cmd.Flags().Changed("overlap") {
+			value, _ := cmd.Flags().GetDuration("overlap")
+			overlap = value.String()
+		}
+		api, _ := cmd.Flags().GetString("api")
+		if api == "" {
+			api = nodeAPI()
+		}
+
+		server, err := requestServerRotation(api, overlap)
+		if err != nil {
+			fmt.Printf("\n%s❌ Error: %s%s\n", colorRed, err.Error(), colorReset)
+			os.Exit(1)
+		}
+
+		fmt.Printf("\n%s%s%s\n", colorYellow, "====================================", colorReset)
+		fmt.Printf("%s🔑 Server Key Rotation%s\n", colorGreen, colorReset)
+		fmt.Printf("%s%s%s\n", colorYellow, "====================================", colorReset)
+		if server.NextPublicKey != "" {
+			fmt.Printf("%s🔖 Current Key:%s %s\n", colorCyan, colorReset, server.PublicKey)
+			fmt.Printf("%s🆕 Next Key:%s %s\n", colorCyan, colorReset, server.NextPublicKey)
+			fmt.Printf("%s⏱  Switches At:%s %s\n", colorCyan, colorReset, time.UnixMilli(server.RotateAt).Format(time.RFC1123))
+		} else {
+			fmt.Printf("%s🆕 Active Key:%s %s\n", colorCyan, colorReset, server.PublicKey)
+		}
+		fmt.Printf("%s%s%s\n\n", colorYellow, "====================================", colorReset)
+	},
+}
+
+// nodeAPI returns the base url of the REST api of the node running on this host
+func nodeAPI() string {
+	host := os.Getenv("SERVER")
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "127.0.0.1"
+	}
+	return "http://" + net.JoinHostPort(host, os.Getenv("HTTP_PORT")) + "/api/v1.0"
+}
+
+// requestServerRotation asks the running node to stage a new server keypair, the node owns the
+// storage and the wireguard lock so the cli never writes them itself. The request carries a token
+// signed with the node key on behalf of an operator wallet, an empty overlap lets the node use
+// KEY_ROTATION_OVERLAP.
+func requestServerRotation(api string, overlap string) (*model.Server, error) {
+	wallet := policy.OperatorWallet()
+	if wallet == "" {
+		return nil, errors.New("GATEWAY_WALLET or OPERATOR_WALLETS must name an operator wallet")
+	}
+	roles := []string{policy.RoleOperator}
+	token, err := auth.GenerateTokenPaseto(claims.CustomClaims{
+		WalletAddress: wallet,
+		SignedBy:      "cli",
+		Roles:         roles,
+		Scopes:        policy.ScopesFor(roles),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(map[string]string{"overlap": overlap})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(api, "/")+"/server/rotate", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := (&http.Client{Timeout: 30 * time.Second}).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("node api unreachable, is the node running? %w", err)
+	}
+	defer resp.Body.Close()
+
+	var response model.Response
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("unexpected response from the node: %s", resp.Status)
+	}
+	if !response.Success || response.Server == nil {
+		if response.Error != "" {
+			return nil, errors.New(response.Error)
+		}
+		return nil, fmt.Errorf("unexpected response from the node: %s", resp.Status)
+	}
+	return response.Server, nil
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
@@ -97,5 +209,10 @@ func init() {
 	rootCmd.AddCommand(statusCmd)
 	rootCmd.AddCommand(deactivateCmd)
 	rootCmd.AddCommand(activateCmd)
+
+	rotateServerCmd.Flags().Duration("overlap", defaultRotationOverlap, "time left to clients before the new key is used, 0 switches right away, defaults to KEY_ROTATION_OVERLAP of the node")
+	rotateServerCmd.Flags().String("api", "", "REST api of the running node, defaults to http://SERVER:HTTP_PORT/api/v1.0")
+	keysCmd.AddCommand(rotateServerCmd)
+	rootCmd.AddCommand(keysCmd)
 }
 
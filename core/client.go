@@ -2,20 +2,16 @@ package core
 
 import (
 	// "crypto/rand"
-	"errors"
-	// "fmt"
+	"fmt"
 	// "math/big"
-	"os"
-	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 
 	"github.com/NetSepio/nexus/model"
 	"github.com/NetSepio/nexus/storage"
 	"github.com/NetSepio/nexus/template"
-	"github.com/NetSepio/nexus/util"
-	"github.com/NetSepio/nexus/util/pkg/stats"
 	uuid "github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
@@ -24,6 +20,9 @@ import (
 
 // RegisterClient client with all necessary data
 func RegisterClient(client *model.Client) (*model.Client, error) {
+	wgMu.Lock()
+	defer wgMu.Unlock()
+
 	// check if client is valid
 	errs := client.IsValid()
 	if len(errs) != 0 {
@@ -32,68 +31,99 @@ func RegisterClient(client *model.Client) (*model.Client, error) {
 				"err": err,
 			}).Error("client validation error")
 		}
-		return nil, errors.New("failed to validate client")
+		return nil, fmt.Errorf("%w: failed to validate client", ErrInvalid)
 	}
 
 	u, err := uuid.NewRandom()
 	client.UUID = u.String()
 
-	presharedKey, err := wgtypes.GenerateKey()
+	// the node only ever stores the public key, a generated private key is returned once
+	if client.PrivateKey != "" {
+		return nil, fmt.Errorf("%w: privateKey must not be submitted, send only the publicKey", ErrInvalid)
+	}
+	privateKey := ""
+	if client.PublicKey == "" {
+		key, err := wgtypes.GeneratePrivateKey()
+		if err != nil {
+			return nil, err
+		}
+		privateKey = key.String()
+		client.PublicKey = key.PublicKey().String()
+	}
+	err = checkPublicKey(client.PublicKey, client.UUID)
 	if err != nil {
 		return nil, err
 	}
-	client.PresharedKey = presharedKey.String()
 
-	reserverIps, err := GetAllReservedIps()
+	presharedKey, err := wgtypes.GenerateKey()
 	if err != nil {
 		return nil, err
 	}
+	client.PresharedKey = presharedKey.String()
 
-	ips := make([]string, 0)
-	for _, network := range client.Address {
-		ip, err := util.GetAvailableIP(network, reserverIps)
-		if err != nil {
-			return nil, err
-		}
-		if util.IsIPv6(ip) {
-			ip = ip + "/128"
-		} else {
-			ip = ip + "/32"
-		}
-		ips = append(ips, ip)
+	// one address per server network or pool, requested host addresses are kept as is
+	err = assignAddresses(client, "", nil)
+	if err != nil {
+		return nil, err
 	}
-	client.Address = ips
 	client.CreatedAt = timestamppb.Now().AsTime().UnixMilli()
 
+	// usage is maintained by the accountant only
+	keepUsage(client, &model.Client{})
+
 	client.UpdatedAt = client.CreatedAt
 
-	err = storage.Serialize(client.UUID, client)
+	err = storage.Get().WriteClient(client)
 	if err != nil {
 		return nil, err
 	}
 
-	v, err := storage.Deserialize(client.UUID)
+	client, err = storage.Get().ReadClient(client.UUID)
 	if err != nil {
 		return nil, err
 	}
-	client = v.(*model.Client)
+	client.PrivateKey = privateKey
 
-	// data modified, dump new config
-	return client, UpdateServerConfigWg()
+	// data modified, dump new config, watchers only see the change once it is applied
+	err = updateServerConfigWg()
+	if err == nil {
+		publishClient(EventClientCreated, client)
+	}
+	return client, err
+}
+
+// checkPublicKey rejects a public key already used by the server or another client, callers
+// must hold wgMu
+func checkPublicKey(publicKey string, skip string) error {
+	server, err := readServer()
+	if err != nil {
+		return err
+	}
+	if publicKey == server.PublicKey || publicKey == server.NextPublicKey {
+		return fmt.Errorf("%w: publicKey is already in use", ErrConflict)
+	}
+
+	clients, err := readStoredClients()
+	if err != nil {
+		return err
+	}
+	for _, c := range clients {
+		if c.UUID != skip && (c.PublicKey == publicKey || c.NextPublicKey == publicKey) {
+			return fmt.Errorf("%w: publicKey is already in use", ErrConflict)
+		}
+	}
+	return nil
 }
 
 // ReadClient client by id
 func ReadClient(id string) (*model.Client, error) {
-	v, err := storage.Deserialize(id)
+	client, err := storage.Get().ReadClient(id)
 	if err != nil {
 		return nil, err
 	}
-	client := v.(*model.Client)
-	pkey := client.PublicKey
-	clientStats, err := stats.GetWireGuardStatsForPeer(pkey)
+	peers, err := ReadPeerStats()
 	if err == nil {
-		client.ReceiveBytes = clientStats.ReceivedBytes
-		client.TransmitBytes = clientStats.TransmittedBytes
+		applyPeerStats(client, peers)
 	}
 
 	return client, nil
@@ -101,14 +131,16 @@ func ReadClient(id string) (*model.Client, error) {
 
 // UpdateClient preserve keys
 func UpdateClient(UUID string, client *model.Client) (*model.Client, error) {
-	v, err := storage.Deserialize(UUID)
+	wgMu.Lock()
+	defer wgMu.Unlock()
+
+	current, err := storage.Get().ReadClient(UUID)
 	if err != nil {
 		return nil, err
 	}
-	current := v.(*model.Client)
 
 	if current.UUID != client.UUID {
-		return nil, errors.New("records UUID mismatch")
+		return nil, fmt.Errorf("%w: records UUID mismatch", ErrInvalid)
 	}
 
 	// check if client is valid
@@ -119,71 +151,82 @@ func UpdateClient(UUID string, client *model.Client) (*model.Client, error) {
 				"err": err,
 			}).Error("client validation error")
 		}
-		return nil, errors.New("failed to validate client")
+		return nil, fmt.Errorf("%w: failed to validate client", ErrInvalid)
+	}
+
+	// addresses only go through the allocator when changed or when the pool, named or selected
+	// by the tags, may have changed
+	if !slices.Equal(client.Address, current.Address) || client.Pool != current.Pool || !slices.Equal(client.Tags, current.Tags) {
+		err = assignAddresses(client, client.UUID, current.Address)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// Keep Keys
+	// the owner and creation time are set once at registration
+	client.CreatedBy = current.CreatedBy
+	client.CreatedAt = current.CreatedAt
+
+	// Keep Keys, private keys are never stored
 	client.PublicKey = current.PublicKey
 	client.PresharedKey = current.PresharedKey
+	client.PrivateKey = ""
+	keepRotation(client, current)
+	keepUsage(client, current)
 	client.UpdatedAt = timestamppb.Now().AsTime().UnixMilli()
 
-	err = storage.Serialize(client.UUID, client)
+	err = storage.Get().WriteClient(client)
 	if err != nil {
 		return nil, err
 	}
 
-	v, err = storage.Deserialize(UUID)
+	client, err = storage.Get().ReadClient(UUID)
 	if err != nil {
 		return nil, err
 	}
-	client = v.(*model.Client)
 
-	// data modified, dump new config
-	return client, UpdateServerConfigWg()
+	// data modified, dump new config, watchers only see the change once it is applied
+	err = updateServerConfigWg()
+	if err == nil {
+		publishClient(EventClientUpdated, client)
+	}
+	return client, err
 }
 
-// DeleteClient from disk
+// DeleteClient from store
 func DeleteClient(id string) error {
-	path := filepath.Join(os.Getenv("WG_CLIENTS_DIR"), id)
-	err := os.Remove(path)
+	wgMu.Lock()
+	defer wgMu.Unlock()
+
+	client, err := storage.Get().ReadClient(id)
+	if err != nil {
+		return err
+	}
+	err = storage.Get().DeleteClient(id)
 	if err != nil {
 		return err
 	}
 
-	// data modified, dump new config
-	return UpdateServerConfigWg()
+	// data modified, dump new config, watchers only see the change once it is applied
+	err = updateServerConfigWg()
+	if err == nil {
+		publishClient(EventClientDeleted, client)
+	}
+	return err
 }
 
 // ReadClients all clients
 func ReadClients() ([]*model.Client, error) {
-	clients := make([]*model.Client, 0)
-
-	files, err := os.ReadDir(filepath.Join(os.Getenv("WG_CLIENTS_DIR")))
+	clients, err := storage.Get().ReadClients()
 	if err != nil {
 		return nil, err
 	}
 
-	for _, f := range files {
-		// clients file name is an uuid
-		_, err := uuid.Parse(f.Name())
-		if err == nil {
-			c, err := storage.Deserialize(f.Name())
-			if err != nil {
-				log.WithFields(log.Fields{
-					"err":  err,
-					"path": f.Name(),
-				}).Error("failed to deserialize client")
-			} else {
-				cl := c.(*model.Client)
-				pkey := cl.PublicKey
-				clientStats, err := stats.GetWireGuardStatsForPeer(pkey)
-				if err == nil {
-					cl.ReceiveBytes = clientStats.ReceivedBytes
-					cl.TransmitBytes = clientStats.TransmittedBytes
-				}
-
-				clients = append(clients, cl)
-			}
+	// one device read for every client
+	peers, err := ReadPeerStats()
+	if err == nil {
+		for _, cl := range clients {
+			applyPeerStats(cl, peers)
 		}
 	}
 
@@ -194,6 +237,20 @@ func ReadClients() ([]*model.Client, error) {
 	return clients, nil
 }
 
+// readStoredClients all clients as persisted, without live peer statistics
+func readStoredClients() ([]*model.Client, error) {
+	clients, err := storage.Get().ReadClients()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(clients, func(i, j int) bool {
+		return clients[i].CreatedAt < (clients[j].CreatedAt)
+	})
+
+	return clients, nil
+}
+
 func ReadClientConfig(id string) ([]byte, error) {
 	client, err := ReadClient(id)
 	if err != nil {
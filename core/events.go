@@ -0,0 +1,102 @@
+package core
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NetSepio/nexus/model"
+	"google.golang.org/protobuf/proto"
+)
+
+// Types of the events published on the node event bus
+const (
+	EventClientCreated = "client.created"
+	EventClientUpdated = "client.updated"
+	EventClientDeleted = "client.deleted"
+
+	EventCheckpointSubmitted = "node.checkpoint.submitted"
+	EventPeerJoined          = "node.peer.joined"
+	EventAgentState          = "node.agent.state"
+)
+
+// eventBuffer is how many events a subscriber may fall behind by before events are dropped for it
+const eventBuffer = 64
+
+// Event is a change published on the node event bus
+type Event struct {
+	Type string
+	Time time.Time
+	// Client is set for the client events, without its private key
+	Client *model.Client
+	// Data holds the details of the node events
+	Data map[string]string
+}
+
+type subscription struct {
+	prefixes []string
+	events   chan Event
+}
+
+func (s *subscription) wants(eventType string) bool {
+	if len(s.prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range s.prefixes {
+		if strings.HasPrefix(eventType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	busMu         sync.RWMutex
+	subscriptions = make(map[*subscription]struct{})
+)
+
+// Subscribe returns the events whose type starts with one of prefixes, every event when none is
+// given. cancel ends the subscription and closes the channel.
+func Subscribe(prefixes ...string) (events <-chan Event, cancel func()) {
+	s := &subscription{prefixes: prefixes, events: make(chan Event, eventBuffer)}
+
+	busMu.Lock()
+	subscriptions[s] = struct{}{}
+	busMu.Unlock()
+
+	var once sync.Once
+	return s.events, func() {
+		once.Do(func() {
+			busMu.Lock()
+			delete(subscriptions, s)
+			busMu.Unlock()
+			close(s.events)
+		})
+	}
+}
+
+// Publish sends e to the subscribers without blocking, subscribers that fell behind miss it
+func Publish(e Event) {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	busMu.RLock()
+	defer busMu.RUnlock()
+	for s := range subscriptions {
+		if !s.wants(e.Type) {
+			continue
+		}
+		select {
+		case s.events <- e:
+		default:
+		}
+	}
+}
+
+// publishClient publishes a client event with a copy of client
+func publishClient(eventType string, client *model.Client) {
+	c := proto.Clone(client).(*model.Client)
+	c.PrivateKey = ""
+	Publish(Event{Type: eventType, Client: c})
+}
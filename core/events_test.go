@@ -0,0 +1,156 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NetSepio/nexus/model"
+)
+
+// nextEvent returns the next event of events, or fails when none arrives
+func nextEvent(t *testing.T, events <-chan Event) Event {
+	t.Helper()
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+		return Event{}
+	}
+}
+
+// noEvent fails when events holds an event
+func noEvent(t *testing.T, events <-chan Event) {
+	t.Helper()
+	select {
+	case e := <-events:
+		t.Fatalf("unexpected event %s", e.Type)
+	default:
+	}
+}
+
+func TestSubscribePrefixes(t *testing.T) {
+	all, cancelAll := Subscribe()
+	defer cancelAll()
+	clients, cancelClients := Subscribe("client.")
+	defer cancelClients()
+	node, cancelNode := Subscribe("node.peer.", "node.agent.")
+	defer cancelNode()
+
+	Publish(Event{Type: EventClientCreated})
+	Publish(Event{Type: EventCheckpointSubmitted})
+	Publish(Event{Type: EventAgentState, Data: map[string]string{"state": "running"}})
+
+	for _, want := range []string{EventClientCreated, EventCheckpointSubmitted, EventAgentState} {
+		if e := nextEvent(t, all); e.Type != want {
+			t.Fatalf("got %s, want %s", e.Type, want)
+		}
+	}
+	noEvent(t, all)
+
+	e := nextEvent(t, clients)
+	if e.Type != EventClientCreated || e.Time.IsZero() {
+		t.Fatalf("got %s at %v, want %s with its time set", e.Type, e.Time, EventClientCreated)
+	}
+	noEvent(t, clients)
+
+	if e := nextEvent(t, node); e.Type != EventAgentState || e.Data["state"] != "running" {
+		t.Fatalf("got %s %v, want %s", e.Type, e.Data, EventAgentState)
+	}
+	noEvent(t, node)
+}
+
+func TestPublishSlowSubscriber(t *testing.T) {
+	slow, cancelSlow := Subscribe()
+	defer cancelSlow()
+
+	// a subscriber that fell behind misses events instead of blocking the publisher
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < eventBuffer+10; i++ {
+			Publish(Event{Type: EventClientUpdated})
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("publish blocked on a slow subscriber")
+	}
+	if len(slow) != eventBuffer {
+		t.Fatalf("got %d buffered events, want %d", len(slow), eventBuffer)
+	}
+
+	// a subscriber that caught up receives new events again
+	for len(slow) > 0 {
+		<-slow
+	}
+	Publish(Event{Type: EventClientDeleted})
+	if e := nextEvent(t, slow); e.Type != EventClientDeleted {
+		t.Fatalf("got %s, want %s", e.Type, EventClientDeleted)
+	}
+}
+
+func TestSubscribeCancel(t *testing.T) {
+	events, cancel := Subscribe()
+	cancel()
+	// cancel may be called more than once
+	cancel()
+
+	Publish(Event{Type: EventClientCreated})
+	if _, ok := <-events; ok {
+		t.Fatal("channel still open after cancel")
+	}
+}
+
+func TestPublishClient(t *testing.T) {
+	events, cancel := Subscribe("client.")
+	defer cancel()
+
+	client := &model.Client{UUID: "a", Name: "laptop", PrivateKey: "secret"}
+	publishClient(EventClientUpdated, client)
+
+	e := nextEvent(t, events)
+	if e.Client == nil || e.Client.UUID != "a" || e.Client.PrivateKey != "" {
+		t.Fatalf("got client %v, want a copy without private key", e.Client)
+	}
+	if client.PrivateKey != "secret" {
+		t.Fatal("private key cleared on the published client")
+	}
+
+	// later changes of the client do not reach the subscribers
+	client.Name = "phone"
+	if e.Client.Name != "laptop" {
+		t.Fatal("published client shares its state with the caller")
+	}
+}
+
+func TestClientEventsAfterConfigWrite(t *testing.T) {
+	store := useTestStore(t)
+	key := "yAnz5TF+lXXJte14tji3zlMNq+hd2rYUIgJBgB3fBmk="
+	if err := store.WriteServer(&model.Server{Address: []string{"10.0.0.1/24"}, ListenPort: 51820, PrivateKey: key, PublicKey: key}); err != nil {
+		t.Fatal(err)
+	}
+
+	events, cancel := Subscribe("client.")
+	defer cancel()
+
+	client, err := RegisterClient(&model.Client{Name: "laptop", Enable: true, AllowedIPs: []string{"0.0.0.0/0"}, Address: []string{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := nextEvent(t, events); e.Type != EventClientCreated || e.Client.UUID != client.UUID {
+		t.Fatalf("got %s, want %s of %s", e.Type, EventClientCreated, client.UUID)
+	}
+
+	// the wireguard config cannot be written, watchers must not see the change
+	t.Setenv("WG_INTERFACE_NAME", "missing/nexustest0.conf")
+	client.Name = "phone"
+	if _, err := UpdateClient(client.UUID, client); err == nil {
+		t.Fatal("update succeeded without wireguard config")
+	}
+	if err := DeleteClient(client.UUID); err == nil {
+		t.Fatal("delete succeeded without wireguard config")
+	}
+	noEvent(t, events)
+}
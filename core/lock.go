@@ -0,0 +1,7 @@
+package core
+
+import "sync"
+
+// wgMu serializes every mutation of the wireguard state, server, clients, ip allocation and
+// the rendered interface config, across concurrent REST and gRPC callers
+var wgMu sync.Mutex
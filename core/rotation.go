@@ -0,0 +1,372 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NetSepio/nexus/model"
+	"github.com/NetSepio/nexus/storage"
+	log "github.com/sirupsen/logrus"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+// Kinds of key rotation recorded in the key history
+const (
+	RotationServer       = "server"
+	RotationPresharedKey = "presharedKey"
+	RotationKeypair      = "keypair"
+)
+
+// maxKeyHistory is how many rotations are kept on the server and on each client
+const maxKeyHistory = 20
+
+// defaultRotationOverlap leaves clients time to fetch their new config before the switch
+const defaultRotationOverlap = 10 * time.Minute
+
+// ServerKeyAnnouncement is published on the p2p status topic when the server key is staged or replaced
+type ServerKeyAnnouncement struct {
+	Type          string `json:"type"`
+	PublicKey     string `json:"publicKey"`
+	NextPublicKey string `json:"nextPublicKey,omitempty"`
+	RotateAt      int64  `json:"rotateAt,omitempty"`
+}
+
+var (
+	announceMu sync.Mutex
+	announcer  func(ServerKeyAnnouncement)
+	announced  ServerKeyAnnouncement
+)
+
+// OnServerKeyChange registers fn to publish the server keys, it is called with the current keys
+// on the next rotation pass and again every time they change
+func OnServerKeyChange(fn func(ServerKeyAnnouncement)) {
+	announceMu.Lock()
+	defer announceMu.Unlock()
+
+	announcer = fn
+	announced = ServerKeyAnnouncement{}
+}
+
+// announceServerKey publishes the server keys when they differ from the last announcement
+func announceServerKey(server *model.Server) {
+	a := ServerKeyAnnouncement{
+		Type:          "serverKey",
+		PublicKey:     server.PublicKey,
+		NextPublicKey: server.NextPublicKey,
+		RotateAt:      server.RotateAt,
+	}
+
+	announceMu.Lock()
+	defer announceMu.Unlock()
+	if announcer == nil || a == announced {
+		return
+	}
+	announced = a
+	go announcer(a)
+}
+
+// RotationOverlap returns KEY_ROTATION_OVERLAP, how long staged keys wait before replacing the
+// active ones
+func RotationOverlap() time.Duration {
+	value := os.Getenv("KEY_ROTATION_OVERLAP")
+	if value == "" {
+		return defaultRotationOverlap
+	}
+	overlap, err := time.ParseDuration(value)
+	if err != nil || overlap < 0 {
+		log.WithFields(log.Fields{
+			"err":   err,
+			"value": value,
+		}).Warn("invalid KEY_ROTATION_OVERLAP, using the default")
+		return defaultRotationOverlap
+	}
+	return overlap
+}
+
+// ParseOverlap parses an overlap window such as 10m, empty means RotationOverlap
+func ParseOverlap(value string) (time.Duration, error) {
+	if value == "" {
+		return RotationOverlap(), nil
+	}
+	overlap, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if overlap < 0 {
+		return 0, errors.New("overlap must not be negative")
+	}
+	return overlap, nil
+}
+
+// StartKeyRotation replaces the active keys with the staged ones once their overlap window
+// elapsed and announces the server keys
+func StartKeyRotation(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		for now := range ticker.C {
+			if err := applyDueRotations(now); err != nil {
+				log.WithFields(log.Fields{
+					"err": err,
+				}).Error("failed to apply key rotations")
+			}
+		}
+	}()
+}
+
+// applyDueRotations runs a single rotation pass
+func applyDueRotations(now time.Time) error {
+	wgMu.Lock()
+	defer wgMu.Unlock()
+
+	server, err := storage.Get().ReadServer()
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	if applyServerRotation(server, now) {
+		if err := storage.Get().WriteServer(server); err != nil {
+			return err
+		}
+		changed = true
+	}
+
+	clients, err := readStoredClients()
+	if err != nil {
+		return err
+	}
+	rotated := make([]*model.Client, 0)
+	for _, client := range clients {
+		if applyClientRotation(client, now) {
+			if err := storage.Get().WriteClient(client); err != nil {
+				return err
+			}
+			rotated = append(rotated, client)
+			changed = true
+		}
+	}
+
+	if changed {
+		if err := updateServerConfigWg(); err != nil {
+			return err
+		}
+	}
+	for _, client := range rotated {
+		publishClient(EventClientUpdated, client)
+	}
+	announceServerKey(server)
+
+	return nil
+}
+
+// RotateServerKey stages a new server keypair that replaces the active one once overlap
+// elapsed, a zero overlap switches right away
+func RotateServerKey(by string, overlap time.Duration) (*model.Server, error) {
+	wgMu.Lock()
+	defer wgMu.Unlock()
+
+	server, err := storage.Get().ReadServer()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := wgtypes.GeneratePrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	server.NextPrivateKey = key.String()
+	server.NextPublicKey = key.PublicKey().String()
+	server.RotateAt = now.Add(overlap).UnixMilli()
+	server.KeyHistory = stageRotation(server.KeyHistory, &model.KeyRotation{
+		Kind:              RotationServer,
+		PublicKey:         server.NextPublicKey,
+		PreviousPublicKey: server.PublicKey,
+		RequestedAt:       now.UnixMilli(),
+		RotatedBy:         by,
+	})
+
+	rotated := applyServerRotation(server, now)
+	if err := storage.Get().WriteServer(server); err != nil {
+		return nil, err
+	}
+	if rotated {
+		if err := updateServerConfigWg(); err != nil {
+			return nil, err
+		}
+	}
+	announceServerKey(server)
+
+	return server, nil
+}
+
+// RotateClientPresharedKey stages a new preshared key for the client
+func RotateClientPresharedKey(id string, by string, overlap time.Duration) (*model.Client, error) {
+	return rotateClient(id, overlap, func(client *model.Client, now time.Time) (string, error) {
+		key, err := wgtypes.GenerateKey()
+		if err != nil {
+			return "", err
+		}
+		client.NextPresharedKey = key.String()
+		client.KeyHistory = stageRotation(client.KeyHistory, &model.KeyRotation{
+			Kind:        RotationPresharedKey,
+			RequestedAt: now.UnixMilli(),
+			RotatedBy:   by,
+		})
+		return "", nil
+	})
+}
+
+// RotateClientKeypair stages a new public key for the client, when publicKey is empty a keypair
+// is generated and its private key returned once in the client
+func RotateClientKeypair(id string, publicKey string, by string, overlap time.Duration) (*model.Client, error) {
+	return rotateClient(id, overlap, func(client *model.Client, now time.Time) (string, error) {
+		privateKey := ""
+		if publicKey == "" {
+			key, err := wgtypes.GeneratePrivateKey()
+			if err != nil {
+				return "", err
+			}
+			privateKey = key.String()
+			publicKey = key.PublicKey().String()
+		} else if _, err := wgtypes.ParseKey(publicKey); err != nil {
+			return "", fmt.Errorf("%w: publicKey is invalid", ErrInvalid)
+		}
+
+		if publicKey == client.PublicKey {
+			return "", fmt.Errorf("%w: publicKey is already the active key of the client", ErrInvalid)
+		}
+		if err := checkPublicKey(publicKey, client.UUID); err != nil {
+			return "", err
+		}
+
+		client.NextPublicKey = publicKey
+		client.KeyHistory = stageRotation(client.KeyHistory, &model.KeyRotation{
+			Kind:              RotationKeypair,
+			PublicKey:         publicKey,
+			PreviousPublicKey: client.PublicKey,
+			RequestedAt:       now.UnixMilli(),
+			RotatedBy:         by,
+		})
+		return privateKey, nil
+	})
+}
+
+// rotateClient stages the keys set by fn, every staged key of the client switches together once
+// overlap elapsed, right away when it is zero
+func rotateClient(id string, overlap time.Duration, fn func(client *model.Client, now time.Time) (string, error)) (*model.Client, error) {
+	wgMu.Lock()
+	defer wgMu.Unlock()
+
+	client, err := storage.Get().ReadClient(id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	privateKey, err := fn(client, now)
+	if err != nil {
+		return nil, err
+	}
+	client.RotateAt = now.Add(overlap).UnixMilli()
+
+	rotated := applyClientRotation(client, now)
+	if err := storage.Get().WriteClient(client); err != nil {
+		return nil, err
+	}
+	if rotated {
+		if err := updateServerConfigWg(); err != nil {
+			return nil, err
+		}
+	}
+	publishClient(EventClientUpdated, client)
+
+	client.PrivateKey = privateKey
+	return client, nil
+}
+
+// stageRotation appends entry to history, dropping a pending rotation of the same kind it
+// supersedes and the oldest entries past maxKeyHistory
+func stageRotation(history []*model.KeyRotation, entry *model.KeyRotation) []*model.KeyRotation {
+	kept := make([]*model.KeyRotation, 0, len(history)+1)
+	for _, h := range history {
+		if h.RotatedAt == 0 && h.Kind == entry.Kind {
+			continue
+		}
+		kept = append(kept, h)
+	}
+	kept = append(kept, entry)
+
+	if len(kept) > maxKeyHistory {
+		kept = kept[len(kept)-maxKeyHistory:]
+	}
+	return kept
+}
+
+// completeRotations marks every pending rotation of history as done
+func completeRotations(history []*model.KeyRotation, now time.Time) {
+	for _, h := range history {
+		if h.RotatedAt == 0 {
+			h.RotatedAt = now.UnixMilli()
+		}
+	}
+}
+
+// applyServerRotation switches to the staged server keypair when due, it reports whether the
+// server changed
+func applyServerRotation(server *model.Server, now time.Time) bool {
+	if server.NextPrivateKey == "" || now.UnixMilli() < server.RotateAt {
+		return false
+	}
+
+	server.PrivateKey = server.NextPrivateKey
+	server.PublicKey = server.NextPublicKey
+	server.NextPrivateKey = ""
+	server.NextPublicKey = ""
+	server.RotateAt = 0
+	completeRotations(server.KeyHistory, now)
+
+	log.WithFields(log.Fields{
+		"publicKey": server.PublicKey,
+	}).Info("server key rotated")
+	return true
+}
+
+// applyClientRotation switches to the staged client keys when due, it reports whether the
+// client changed
+func applyClientRotation(client *model.Client, now time.Time) bool {
+	if (client.NextPublicKey == "" && client.NextPresharedKey == "") || now.UnixMilli() < client.RotateAt {
+		return false
+	}
+
+	if client.NextPublicKey != "" && client.NextPublicKey != client.PublicKey {
+		client.PublicKey = client.NextPublicKey
+		// usage is counted on the peer of the new key from now on
+		resetCounters(client)
+	}
+	if client.NextPresharedKey != "" {
+		client.PresharedKey = client.NextPresharedKey
+	}
+	client.NextPublicKey = ""
+	client.NextPresharedKey = ""
+	client.RotateAt = 0
+	client.UpdatedAt = now.UnixMilli()
+	completeRotations(client.KeyHistory, now)
+
+	log.WithFields(log.Fields{
+		"uuid": client.UUID,
+	}).Info("client keys rotated")
+	return true
+}
+
+// keepRotation copies the key rotation state maintained by the node from current to client
+func keepRotation(client *model.Client, current *model.Client) {
+	client.NextPublicKey = current.NextPublicKey
+	client.NextPresharedKey = current.NextPresharedKey
+	client.RotateAt = current.RotateAt
+	client.KeyHistory = current.KeyHistory
+}
@@ -0,0 +1,95 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NetSepio/nexus/model"
+	"github.com/NetSepio/nexus/storage"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+// useTestStore points the node at a file store in a temporary directory and an interface that
+// does not exist
+func useTestStore(t *testing.T) storage.Store {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("WG_CONF_DIR", dir)
+	t.Setenv("WG_CLIENTS_DIR", filepath.Join(dir, "clients"))
+	t.Setenv("WG_INTERFACE_NAME", "nexustest0.conf")
+	if err := os.MkdirAll(filepath.Join(dir, "clients"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	previous := storage.Get()
+	store := storage.NewFileStore()
+	storage.Set(store)
+	t.Cleanup(func() { storage.Set(previous) })
+	return store
+}
+
+func TestApplyDueRotations(t *testing.T) {
+	store := useTestStore(t)
+
+	current, _ := wgtypes.GeneratePrivateKey()
+	next, _ := wgtypes.GeneratePrivateKey()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	rotateAt := now.Add(10 * time.Minute)
+	server := &model.Server{
+		Address:        []string{"10.0.0.1/24"},
+		ListenPort:     51820,
+		PrivateKey:     current.String(),
+		PublicKey:      current.PublicKey().String(),
+		NextPrivateKey: next.String(),
+		NextPublicKey:  next.PublicKey().String(),
+		RotateAt:       rotateAt.UnixMilli(),
+		KeyHistory:     []*model.KeyRotation{{Kind: RotationServer, PublicKey: next.PublicKey().String()}},
+	}
+	if err := store.WriteServer(server); err != nil {
+		t.Fatal(err)
+	}
+
+	announcements := make(chan ServerKeyAnnouncement, 4)
+	OnServerKeyChange(func(a ServerKeyAnnouncement) { announcements <- a })
+	t.Cleanup(func() { OnServerKeyChange(nil) })
+
+	// before the switch the staged key is announced next to the active one
+	if err := applyDueRotations(rotateAt.Add(-time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+	if stored, _ := store.ReadServer(); stored.PublicKey != current.PublicKey().String() {
+		t.Fatalf("expected the active key kept before the switch, got %s", stored.PublicKey)
+	}
+	if a := <-announcements; a.PublicKey != current.PublicKey().String() || a.NextPublicKey != next.PublicKey().String() {
+		t.Errorf("expected the staged key announced, got %+v", a)
+	}
+
+	if err := applyDueRotations(rotateAt); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := store.ReadServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.PrivateKey != next.String() || stored.PublicKey != next.PublicKey().String() || stored.NextPrivateKey != "" || stored.RotateAt != 0 {
+		t.Errorf("expected the staged key active at RotateAt, got %+v", stored)
+	}
+	if stored.KeyHistory[0].RotatedAt != rotateAt.UnixMilli() {
+		t.Errorf("expected the rotation completed at %d, got %d", rotateAt.UnixMilli(), stored.KeyHistory[0].RotatedAt)
+	}
+	if a := <-announcements; a.PublicKey != next.PublicKey().String() || a.NextPublicKey != "" {
+		t.Errorf("expected the new key announced, got %+v", a)
+	}
+
+	// nothing left to switch or announce
+	if err := applyDueRotations(rotateAt.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case a := <-announcements:
+		t.Errorf("expected no announcement, got %+v", a)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
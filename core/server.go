@@ -1,7 +1,6 @@
 package core
 
 import (
-	"encoding/json"
 	"errors"
 	"io"
 	"net"
@@ -21,65 +20,108 @@ import (
 
 // ReadServer object, create default one
 func ReadServer() (*model.Server, error) {
-	if !util.FileExists(filepath.Join(os.Getenv("WG_CONF_DIR"), "server.json")) {
-		server := &model.Server{}
+	current, err := storage.Get().ReadServer()
+	if err != storage.ErrNotFound {
+		return current, err
+	}
 
-		key, err := wgtypes.GeneratePrivateKey()
-		if err != nil {
-			return nil, err
-		}
-		server.PrivateKey = key.String()
-		server.PublicKey = key.PublicKey().String()
-		server.Endpoint = os.Getenv("WG_ENDPOINT_HOST")
-		listenPort, _ := strconv.ParseInt(os.Getenv("WG_ENDPOINT_PORT"), 10, 32)
-
-		util.CheckError("Error while reading listen port:", err)
-		server.ListenPort = listenPort
-
-		server.Address = make([]string, 0)
-		// server.Address = append(server.Address, os.Getenv("WG_IPv6_SUBNET")) //	"fd9f:6666::10:0:0:1/64"
-		server.Address = append(server.Address, os.Getenv("WG_IPv4_SUBNET")) //	"10.0.0.1/24"
-
-		server.DNS = make([]string, 0)
-		// server.DNS = append(server.DNS, "fd9f::10:0:0:2")
-		server.DNS = append(server.DNS, os.Getenv("WG_DNS")) //	"1.1.1.1"
-
-		server.AllowedIPs = make([]string, 0)
-		server.AllowedIPs = append(server.AllowedIPs, os.Getenv("WG_ALLOWED_IP_1")) //	"0.0.0.0/0"
-		server.AllowedIPs = append(server.AllowedIPs, os.Getenv("WG_ALLOWED_IP_2")) //	"::/0"
-
-		server.PersistentKeepalive = 16
-		server.Mtu = 0
-		server.PreUp = os.Getenv("WG_PRE_UP")       //	"echo WireGuard PreUp"
-		server.PostUp = os.Getenv("WG_POST_UP")     //	"echo WireGuard PostUp"
-		server.PreDown = os.Getenv("WG_PRE_DOWN")   //	"echo WireGuard PreDown"
-		server.PostDown = os.Getenv("WG_POST_DOWN") //	"echo WireGuard PostDown"
-		server.CreatedAt = int64(time.Now().Nanosecond())
-		server.UpdatedAt = server.CreatedAt
-
-		err = storage.Serialize("server.json", server)
-		if err != nil {
-			return nil, err
-		}
+	wgMu.Lock()
+	defer wgMu.Unlock()
 
-		// server.json was missing, dump wg config after creation
-		err = UpdateServerConfigWg()
-		if err != nil {
-			return nil, err
+	return readServer()
+}
+
+// readServer returns the stored server, the default one is created and dumped when it was never
+// written, callers must hold wgMu
+func readServer() (*model.Server, error) {
+	current, err := storage.Get().ReadServer()
+	if err == nil {
+		return current, nil
+	}
+	if err != storage.ErrNotFound {
+		return nil, err
+	}
+
+	// server was never written, create default one
+	server := &model.Server{}
+
+	key, err := wgtypes.GeneratePrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	server.PrivateKey = key.String()
+	server.PublicKey = key.PublicKey().String()
+	server.Endpoint = os.Getenv("WG_ENDPOINT_HOST")
+	listenPort, _ := strconv.ParseInt(os.Getenv("WG_ENDPOINT_PORT"), 10, 32)
+
+	util.CheckError("Error while reading listen port:", err)
+	server.ListenPort = listenPort
+
+	// dual-stack when both subnets are set
+	server.Address = make([]string, 0)
+	for _, subnet := range []string{os.Getenv("WG_IPv4_SUBNET"), os.Getenv("WG_IPv6_SUBNET")} { //	"10.0.0.1/24", "fd9f:6666::10:0:0:1/64"
+		if subnet != "" {
+			server.Address = append(server.Address, subnet)
 		}
 	}
 
-	c, err := storage.Deserialize("server.json")
+	server.DNS = make([]string, 0)
+	// server.DNS = append(server.DNS, "fd9f::10:0:0:2")
+	server.DNS = append(server.DNS, os.Getenv("WG_DNS")) //	"1.1.1.1"
+
+	server.AllowedIPs = make([]string, 0)
+	server.AllowedIPs = append(server.AllowedIPs, os.Getenv("WG_ALLOWED_IP_1")) //	"0.0.0.0/0"
+	server.AllowedIPs = append(server.AllowedIPs, os.Getenv("WG_ALLOWED_IP_2")) //	"::/0"
+
+	server.PersistentKeepalive = 16
+	server.Mtu = 0
+	server.PreUp = os.Getenv("WG_PRE_UP")       //	"echo WireGuard PreUp"
+	server.PostUp = os.Getenv("WG_POST_UP")     //	"echo WireGuard PostUp"
+	server.PreDown = os.Getenv("WG_PRE_DOWN")   //	"echo WireGuard PreDown"
+	server.PostDown = os.Getenv("WG_POST_DOWN") //	"echo WireGuard PostDown"
+	server.CreatedAt = int64(time.Now().Nanosecond())
+	server.UpdatedAt = server.CreatedAt
+
+	err = storage.Get().WriteServer(server)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.(*model.Server), nil
+	// server.json was missing, dump wg config after creation
+	err = updateServerConfigWg()
+	if err != nil {
+		return nil, err
+	}
+
+	return storage.Get().ReadServer()
+}
+
+// PublicServer returns what clients need to reach server, without its keys, scripts or pools
+func PublicServer(server *model.Server) *model.Server {
+	return &model.Server{
+		PublicKey:     server.PublicKey,
+		NextPublicKey: server.NextPublicKey,
+		RotateAt:      server.RotateAt,
+		Endpoint:      server.Endpoint,
+		ListenPort:    server.ListenPort,
+		DNS:           server.DNS,
+		Mtu:           server.Mtu,
+	}
+}
+
+// WithoutPrivateKeys clears the active and staged private keys of server, they never leave the node
+func WithoutPrivateKeys(server *model.Server) *model.Server {
+	server.PrivateKey = ""
+	server.NextPrivateKey = ""
+	return server
 }
 
 // UpdateServer keep private values from existing one
 func UpdateServer(server *model.Server) (*model.Server, error) {
-	current, err := storage.Deserialize("server.json")
+	wgMu.Lock()
+	defer wgMu.Unlock()
+
+	current, err := storage.Get().ReadServer()
 	if err != nil {
 		return nil, err
 	}
@@ -95,33 +137,46 @@ func UpdateServer(server *model.Server) (*model.Server, error) {
 		return nil, errors.New("failed to validate server")
 	}
 
-	server.PrivateKey = current.(*model.Server).PrivateKey
-	server.PublicKey = current.(*model.Server).PublicKey
+	server.PrivateKey = current.PrivateKey
+	server.PublicKey = current.PublicKey
+	server.NextPrivateKey = current.NextPrivateKey
+	server.NextPublicKey = current.NextPublicKey
+	server.RotateAt = current.RotateAt
+	server.KeyHistory = current.KeyHistory
+	// pools are managed through UpdatePools
+	server.Pools = current.Pools
 	//server.PresharedKey = current.(*model.Server).PresharedKey
 	server.UpdatedAt = int64(time.Now().Nanosecond())
 
-	err = storage.Serialize("server.json", server)
+	err = storage.Get().WriteServer(server)
 	if err != nil {
 		return nil, err
 	}
 
-	v, err := storage.Deserialize("server.json")
+	server, err = storage.Get().ReadServer()
 	if err != nil {
 		return nil, err
 	}
-	server = v.(*model.Server)
 
-	return server, UpdateServerConfigWg()
+	return server, updateServerConfigWg()
 }
 
 // UpdateServerConfigWg in wg format
 func UpdateServerConfigWg() error {
-	clients, err := ReadClients()
+	wgMu.Lock()
+	defer wgMu.Unlock()
+
+	return updateServerConfigWg()
+}
+
+// updateServerConfigWg renders the wg config, callers must hold wgMu
+func updateServerConfigWg() error {
+	clients, err := readStoredClients()
 	if err != nil {
 		return err
 	}
 
-	server, err := ReadServer()
+	server, err := readServer()
 	if err != nil {
 		return err
 	}
@@ -131,12 +186,35 @@ func UpdateServerConfigWg() error {
 		return err
 	}
 
+	// the config file is persisted, apply the change to the running interface, an
+	// interface that is not up yet is brought up from the config file by wg-watcher
+	err = syncDevice(clients, server)
+	if errors.Is(err, os.ErrNotExist) {
+		log.WithFields(log.Fields{
+			"device": DeviceName(),
+		}).Warn("wireguard device not found, peers are applied once it is up")
+	} else if err != nil {
+		log.WithFields(log.Fields{
+			"err":    err,
+			"device": DeviceName(),
+		}).Error("failed to apply peers to the running wireguard device")
+		return err
+	}
+
+	err = shapeClients(clients)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err":    err,
+			"device": DeviceName(),
+		}).Warn("failed to apply client rate limits")
+	}
+
 	return nil
 }
 
 // GetAllReservedIps the list of all reserved IPs, client and server
 func GetAllReservedIps() ([]string, error) {
-	clients, err := ReadClients()
+	clients, err := readStoredClients()
 	if err != nil {
 		return nil, err
 	}
@@ -203,14 +281,11 @@ func GetServerStatus() (*model.Status, error) {
 	response.Region = os.Getenv("REGION")
 	response.VPNPort = os.Getenv("WG_ENDPOINT_PORT")
 
-	serverStatus, err := storage.Deserialize("server.json")
+	server, err := storage.Get().ReadServer()
 
 	if err != nil {
 		log.WithFields(util.StandardFields).Fatal(err)
 	} else {
-		var server model.Server
-		bodybytes, _ := json.Marshal(serverStatus)
-		json.Unmarshal(bodybytes, &server)
 		response.PublicKey = server.PublicKey
 		response.PersistentKeepalive = server.PersistentKeepalive
 		response.DNS = server.DNS
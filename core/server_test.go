@@ -0,0 +1,34 @@
+package core
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestReadServerCreatesOnce(t *testing.T) {
+	useTestStore(t)
+	t.Setenv("WG_IPv4_SUBNET", "10.0.0.1/24")
+
+	// concurrent first reads share the default server instead of each creating a key
+	keys := make([]string, 8)
+	var wg sync.WaitGroup
+	for i := range keys {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			server, err := ReadServer()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			keys[i] = server.PrivateKey
+		}(i)
+	}
+	wg.Wait()
+
+	for _, key := range keys {
+		if key == "" || key != keys[0] {
+			t.Fatalf("expected one default server, got keys %v", keys)
+		}
+	}
+}
@@ -1,12 +1,14 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 	"math/rand"
 	"net"
 	"os"
 	"path/filepath"
-	"strings"
+	"strconv"
+	"syscall"
 	"time"
 
 	"github.com/NetSepio/nexus/api/v1/service/util"
@@ -59,43 +61,42 @@ func Writefile(path string, bytes []byte) (err error) {
 	return nil
 }
 
-// ScanPort checks avilability of port
-func ScanPort(port int) (string, error) {
-	ip := os.Getenv("SERVER")
+// ScanPort reports whether a tcp port of host accepts connections, active or inactive,
+// dialing again a few times while the process is out of file descriptors
+func ScanPort(host string, port int) (string, error) {
 	timer := 500 * time.Millisecond
+	target := net.JoinHostPort(host, strconv.Itoa(port))
 
-	target := fmt.Sprintf("%s:%d", ip, port)
-	conn, err := net.DialTimeout("tcp", target, timer)
-
-	if err != nil {
-		if strings.Contains(err.Error(), "too many open files") {
-			time.Sleep(timer)
-			ScanPort(port)
-		} else {
+	for attempt := 1; ; attempt++ {
+		conn, err := net.DialTimeout("tcp", target, timer)
+		if err == nil {
+			conn.Close()
+			return "active", nil
+		}
+		if !errors.Is(err, syscall.EMFILE) {
 			return "inactive", nil
 		}
-		return "", err
+		if attempt == 3 {
+			return "", err
+		}
+		time.Sleep(timer)
 	}
-
-	conn.Close()
-	return "active", nil
 }
 
-// GetPort returns available port based on random generation
+// GetPort returns a random port of SERVER between min and max no one listens on
 func GetPort(max, min int) (int, error) {
-	port := rand.Intn(max-min) + min
+	for attempt := 0; attempt < 100; attempt++ {
+		port := rand.Intn(max-min) + min
 
-	status, err := ScanPort(port)
-	if err != nil {
-		util.LogError("Scan Port error: ", err)
-		return -1, err
-	}
-
-	if status == "inactive" {
-		return port, nil
-	} else if status == "active" {
-		GetPort(max, min)
+		status, err := ScanPort(os.Getenv("SERVER"), port)
+		if err != nil {
+			util.LogError("Scan Port error: ", err)
+			return -1, err
+		}
+		if status == "inactive" {
+			return port, nil
+		}
 	}
 
-	return -1, nil
+	return -1, fmt.Errorf("no free port between %d and %d", min, max)
 }
@@ -0,0 +1,38 @@
+package core
+
+import (
+	"os"
+	"strings"
+
+	"github.com/NetSepio/nexus/model"
+	"github.com/NetSepio/nexus/util/pkg/shaper"
+)
+
+// clientShaper remembers the limits applied to the interface, guarded by wgMu
+var clientShaper *shaper.Shaper
+
+// shapeClients reconciles the tc rate limits of the interface with the enabled clients,
+// with TC_DRY_RUN=true the commands are logged instead of run
+func shapeClients(clients []*model.Client) error {
+	limits := make([]shaper.Limit, 0)
+	for _, client := range clients {
+		if !client.Enable || (client.UploadRate == 0 && client.DownloadRate == 0) {
+			continue
+		}
+		for _, address := range client.Address {
+			limits = append(limits, shaper.Limit{
+				Address:  address,
+				Upload:   client.UploadRate,
+				Download: client.DownloadRate,
+			})
+		}
+	}
+
+	if clientShaper == nil {
+		clientShaper = &shaper.Shaper{
+			Device: DeviceName(),
+			DryRun: strings.EqualFold(os.Getenv("TC_DRY_RUN"), "true"),
+		}
+	}
+	return clientShaper.Apply(limits)
+}
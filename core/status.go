@@ -0,0 +1,66 @@
+package core
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/NetSepio/nexus/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrInvalid is returned when a client, pool or key in the request fails validation
+	ErrInvalid = errors.New("invalid request")
+	// ErrConflict is returned when a public key or address is already used by another peer
+	ErrConflict = errors.New("conflict")
+	// ErrPoolExhausted is returned when a network or pool has no free address left
+	ErrPoolExhausted = errors.New("address pool exhausted")
+)
+
+// ErrorStatus returns the HTTP status of an error returned by core, 404 for missing records
+func ErrorStatus(err error) int64 {
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalid):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, ErrPoolExhausted):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// StatusCode returns the gRPC code of an HTTP status, the REST gateway maps it back to the same status
+func StatusCode(httpStatus int64) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	default:
+		return codes.Internal
+	}
+}
+
+// StatusError returns the gRPC error of a failed request with the HTTP status the REST routes answer
+func StatusError(httpStatus int64, message string) error {
+	return status.Error(StatusCode(httpStatus), message)
+}
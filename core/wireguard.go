@@ -0,0 +1,207 @@
+package core
+
+import (
+	"net"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/NetSepio/nexus/model"
+	"github.com/NetSepio/nexus/util/pkg/stats"
+	log "github.com/sirupsen/logrus"
+	"golang.zx2c4.com/wireguard/wgctrl"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+var (
+	wgServerMu     sync.Mutex
+	wgServer       *model.WireGuardServer
+	peerStatsCache *stats.Collector
+)
+
+// DeviceName returns the wireguard interface name, WG_INTERFACE_NAME without the .conf suffix
+func DeviceName() string {
+	return strings.TrimSuffix(os.Getenv("WG_INTERFACE_NAME"), ".conf")
+}
+
+// getWireGuardServer returns the shared wgctrl handle, opened on first use
+func getWireGuardServer() (*model.WireGuardServer, error) {
+	wgServerMu.Lock()
+	defer wgServerMu.Unlock()
+
+	if wgServer != nil {
+		return wgServer, nil
+	}
+
+	wg, err := wgctrl.New()
+	if err != nil {
+		return nil, err
+	}
+	wgServer, err = model.NewServer(wg, DeviceName())
+	if err != nil {
+		wg.Close()
+		return nil, err
+	}
+	peerStatsCache = stats.NewCollector(wgServer)
+
+	return wgServer, nil
+}
+
+// ReadPeerStats returns the live statistics of every peer of the interface keyed on public key,
+// read from the device in a single call
+func ReadPeerStats() (map[string]*stats.PeerStats, error) {
+	if _, err := getWireGuardServer(); err != nil {
+		return nil, err
+	}
+	return peerStatsCache.Read()
+}
+
+// applyPeerStats copies the live statistics of the client peer onto the client
+func applyPeerStats(client *model.Client, peers map[string]*stats.PeerStats) {
+	p, ok := peers[client.PublicKey]
+	if !ok {
+		return
+	}
+	client.ReceiveBytes = p.ReceivedBytes
+	client.TransmitBytes = p.TransmittedBytes
+	client.Endpoint = p.Endpoint
+	client.Online = p.Online
+	if !p.LastHandshake.IsZero() {
+		client.LastHandshake = p.LastHandshake.UnixMilli()
+	}
+}
+
+// syncDevice applies the difference between the running interface and the stored state
+// through wgctrl, so unchanged peers keep their sessions. Callers must hold wgMu.
+func syncDevice(clients []*model.Client, server *model.Server) error {
+	wgs, err := getWireGuardServer()
+	if err != nil {
+		return err
+	}
+
+	device, err := wgs.Device()
+	if err != nil {
+		return err
+	}
+
+	cfg, err := deviceConfig(device, clients, server)
+	if err != nil || cfg == nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"device": DeviceName(),
+		"peers":  len(cfg.Peers),
+		"key":    cfg.PrivateKey != nil,
+	}).Info("applying changes to wireguard device")
+
+	return wgs.Configure(*cfg)
+}
+
+// deviceConfig returns the config turning the running device into the stored state, the peers,
+// listen port and private key that differ, or nil when the device is up to date
+func deviceConfig(device *wgtypes.Device, clients []*model.Client, server *model.Server) (*wgtypes.Config, error) {
+	peers, err := peerDiff(device.Peers, clients)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := wgtypes.Config{Peers: peers}
+	if port := int(server.ListenPort); port != 0 && port != device.ListenPort {
+		cfg.ListenPort = &port
+	}
+	// the key switches of a rotation reach the running interface without a restart
+	key, err := wgtypes.ParseKey(server.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	if key != device.PrivateKey {
+		cfg.PrivateKey = &key
+	}
+	if len(cfg.Peers) == 0 && cfg.ListenPort == nil && cfg.PrivateKey == nil {
+		return nil, nil
+	}
+	return &cfg, nil
+}
+
+// peerDiff returns the peer configs turning the running peers into the enabled clients:
+// unknown peers are removed, missing ones added and changed ones replaced in place
+func peerDiff(running []wgtypes.Peer, clients []*model.Client) ([]wgtypes.PeerConfig, error) {
+	current := make(map[wgtypes.Key]wgtypes.Peer, len(running))
+	for _, p := range running {
+		current[p.PublicKey] = p
+	}
+
+	desired := make(map[wgtypes.Key]bool, len(clients))
+	peers := make([]wgtypes.PeerConfig, 0)
+
+	for _, client := range clients {
+		if !client.Enable {
+			continue
+		}
+
+		key, err := wgtypes.ParseKey(client.PublicKey)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"err":  err,
+				"uuid": client.UUID,
+			}).Error("invalid client public key, peer skipped")
+			continue
+		}
+		desired[key] = true
+
+		cfg := wgtypes.PeerConfig{
+			PublicKey:         key,
+			ReplaceAllowedIPs: true,
+		}
+		if client.PresharedKey != "" {
+			psk, err := wgtypes.ParseKey(client.PresharedKey)
+			if err != nil {
+				return nil, err
+			}
+			cfg.PresharedKey = &psk
+		}
+		for _, cidr := range client.Address {
+			_, ipnet, err := net.ParseCIDR(cidr)
+			if err != nil {
+				return nil, err
+			}
+			cfg.AllowedIPs = append(cfg.AllowedIPs, *ipnet)
+		}
+
+		if p, ok := current[key]; ok && samePeer(p, cfg) {
+			continue
+		}
+		peers = append(peers, cfg)
+	}
+
+	for key := range current {
+		if !desired[key] {
+			peers = append(peers, wgtypes.PeerConfig{PublicKey: key, Remove: true})
+		}
+	}
+
+	return peers, nil
+}
+
+// samePeer reports whether a running peer already matches the desired config
+func samePeer(p wgtypes.Peer, cfg wgtypes.PeerConfig) bool {
+	psk := wgtypes.Key{}
+	if cfg.PresharedKey != nil {
+		psk = *cfg.PresharedKey
+	}
+	if p.PresharedKey != psk || len(p.AllowedIPs) != len(cfg.AllowedIPs) {
+		return false
+	}
+
+	allowed := make(map[string]bool, len(p.AllowedIPs))
+	for _, ipnet := range p.AllowedIPs {
+		allowed[ipnet.String()] = true
+	}
+	for _, ipnet := range cfg.AllowedIPs {
+		if !allowed[ipnet.String()] {
+			return false
+		}
+	}
+	return true
+}
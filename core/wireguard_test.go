@@ -0,0 +1,123 @@
+package core
+
+import (
+	"net"
+	"testing"
+
+	"github.com/NetSepio/nexus/model"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+func mustKey(t *testing.T) wgtypes.Key {
+	key, err := wgtypes.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key.PublicKey()
+}
+
+func mustNet(t *testing.T, cidr string) net.IPNet {
+	_, ipnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *ipnet
+}
+
+func TestPeerDiff(t *testing.T) {
+	unchanged, moved, added, removed, disabled := mustKey(t), mustKey(t), mustKey(t), mustKey(t), mustKey(t)
+	running := []wgtypes.Peer{
+		{PublicKey: unchanged, AllowedIPs: []net.IPNet{mustNet(t, "10.0.0.2/32")}},
+		{PublicKey: moved, AllowedIPs: []net.IPNet{mustNet(t, "10.0.0.3/32")}},
+		{PublicKey: removed, AllowedIPs: []net.IPNet{mustNet(t, "10.0.0.4/32")}},
+		{PublicKey: disabled, AllowedIPs: []net.IPNet{mustNet(t, "10.0.0.5/32")}},
+	}
+	clients := []*model.Client{
+		{UUID: "unchanged", Enable: true, PublicKey: unchanged.String(), Address: []string{"10.0.0.2/32"}},
+		{UUID: "moved", Enable: true, PublicKey: moved.String(), Address: []string{"10.0.0.9/32"}},
+		{UUID: "added", Enable: true, PublicKey: added.String(), Address: []string{"10.0.0.6/32"}},
+		{UUID: "disabled", Enable: false, PublicKey: disabled.String(), Address: []string{"10.0.0.5/32"}},
+		{UUID: "invalid", Enable: true, PublicKey: "not a key", Address: []string{"10.0.0.7/32"}},
+	}
+
+	peers, err := peerDiff(running, clients)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[wgtypes.Key]wgtypes.PeerConfig{}
+	for _, p := range peers {
+		got[p.PublicKey] = p
+	}
+
+	if len(got) != 4 {
+		t.Fatalf("expected 4 peer changes, got %d: %v", len(got), peers)
+	}
+	if _, ok := got[unchanged]; ok {
+		t.Error("an unchanged peer must not be reconfigured")
+	}
+	if p, ok := got[moved]; !ok || p.Remove || !p.ReplaceAllowedIPs || p.AllowedIPs[0].String() != "10.0.0.9/32" {
+		t.Errorf("expected the moved peer replaced in place, got %+v", p)
+	}
+	if p, ok := got[added]; !ok || p.Remove {
+		t.Errorf("expected the new peer added, got %+v", p)
+	}
+	for _, key := range []wgtypes.Key{removed, disabled} {
+		if p, ok := got[key]; !ok || !p.Remove {
+			t.Errorf("expected peer %s removed, got %+v", key, p)
+		}
+	}
+
+	if _, err := peerDiff(nil, []*model.Client{{Enable: true, PublicKey: added.String(), Address: []string{"10.0.0.300/32"}}}); err == nil {
+		t.Error("expected an invalid address to fail")
+	}
+}
+
+func TestSamePeer(t *testing.T) {
+	psk, other := mustKey(t), mustKey(t)
+	peer := wgtypes.Peer{
+		PresharedKey: psk,
+		AllowedIPs:   []net.IPNet{mustNet(t, "10.0.0.2/32"), mustNet(t, "fd9f::2/128")},
+	}
+
+	for _, tc := range []struct {
+		name string
+		cfg  wgtypes.PeerConfig
+		same bool
+	}{
+		{"same, any order", wgtypes.PeerConfig{PresharedKey: &psk, AllowedIPs: []net.IPNet{mustNet(t, "fd9f::2/128"), mustNet(t, "10.0.0.2/32")}}, true},
+		{"other psk", wgtypes.PeerConfig{PresharedKey: &other, AllowedIPs: peer.AllowedIPs}, false},
+		{"psk removed", wgtypes.PeerConfig{AllowedIPs: peer.AllowedIPs}, false},
+		{"fewer ips", wgtypes.PeerConfig{PresharedKey: &psk, AllowedIPs: peer.AllowedIPs[:1]}, false},
+		{"other ip", wgtypes.PeerConfig{PresharedKey: &psk, AllowedIPs: []net.IPNet{mustNet(t, "10.0.0.3/32"), mustNet(t, "fd9f::2/128")}}, false},
+	} {
+		if got := samePeer(peer, tc.cfg); got != tc.same {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.same, got)
+		}
+	}
+}
+
+func TestDeviceConfig(t *testing.T) {
+	current, _ := wgtypes.GeneratePrivateKey()
+	next, _ := wgtypes.GeneratePrivateKey()
+	peer := mustKey(t)
+	device := &wgtypes.Device{
+		PrivateKey: current,
+		ListenPort: 51820,
+		Peers:      []wgtypes.Peer{{PublicKey: peer, AllowedIPs: []net.IPNet{mustNet(t, "10.0.0.2/32")}}},
+	}
+	clients := []*model.Client{{UUID: "peer", Enable: true, PublicKey: peer.String(), Address: []string{"10.0.0.2/32"}}}
+
+	cfg, err := deviceConfig(device, clients, &model.Server{PrivateKey: current.String(), ListenPort: 51820})
+	if err != nil || cfg != nil {
+		t.Fatalf("expected an up to date device left alone, got %+v, %v", cfg, err)
+	}
+
+	// a rotation switched the server key, the peers keep their sessions
+	cfg, err = deviceConfig(device, clients, &model.Server{PrivateKey: next.String(), ListenPort: 51820})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg == nil || cfg.PrivateKey == nil || *cfg.PrivateKey != next || len(cfg.Peers) != 0 || cfg.ListenPort != nil {
+		t.Fatalf("expected only the new private key applied, got %+v", cfg)
+	}
+}
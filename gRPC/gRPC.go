@@ -1,6 +1,9 @@
 package grpc
 
 import (
+	"context"
+	"net/http"
+
 	v1 "github.com/NetSepio/nexus/gRPC/v1"
 	"google.golang.org/grpc"
 )
@@ -9,3 +12,8 @@ func Initialize() *grpc.Server {
 	grpc_server := v1.Initialize()
 	return grpc_server
 }
+
+// Gateway returns the REST gateway of the gRPC server listening on endpoint
+func Gateway(ctx context.Context, endpoint string) (http.Handler, error) {
+	return v1.Gateway(ctx, endpoint)
+}
@@ -0,0 +1,118 @@
+package agent
+
+import (
+	"context"
+	"errors"
+
+	"github.com/NetSepio/nexus/api/v1/agents"
+	"github.com/NetSepio/nexus/core"
+	"github.com/NetSepio/nexus/model"
+	"github.com/NetSepio/nexus/util"
+	log "github.com/sirupsen/logrus"
+)
+
+// gRPC agent service struct, the containers of the /agents routes
+type AgentService struct {
+	UnimplementedAgentServiceServer
+}
+
+// toAgent converts a stored agent to its message
+func toAgent(a *model.Agent) *Agent {
+	return &Agent{
+		Id:           a.ID,
+		Name:         a.Name,
+		Clients:      a.Clients,
+		Port:         int64(a.Port),
+		Domain:       a.Domain,
+		Status:       a.Status,
+		AvatarImg:    a.AvatarImg,
+		CoverImg:     a.CoverImg,
+		VoiceModel:   a.VoiceModel,
+		Organization: a.Organization,
+	}
+}
+
+// agentError returns the status error of an agents error, with the status the REST routes answer
+func agentError(err error) error {
+	switch {
+	case errors.Is(err, agents.ErrAgentNotFound):
+		return core.StatusError(404, err.Error())
+	case errors.Is(err, agents.ErrInvalidAction), errors.Is(err, agents.ErrInvalidCharacter):
+		return core.StatusError(400, err.Error())
+	default:
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Error("agent request failed")
+		return core.StatusError(500, err.Error())
+	}
+}
+
+// Method to create an agent from a character file
+func (as *AgentService) CreateAgent(ctx context.Context, request *CreateAgentRequest) (*AgentResponse, error) {
+	log.WithFields(util.StandardFieldsGRPC).Info("Agent Creation Request")
+	agent, err := agents.CreateAgent(agents.NewAgent{
+		CharacterFile: request.CharacterFile,
+		FileName:      request.FileName,
+		AvatarImg:     request.AvatarImg,
+		CoverImg:      request.CoverImg,
+		VoiceModel:    request.VoiceModel,
+		Organization:  request.Organization,
+		DockerImage:   request.DockerUrl,
+		Domain:        request.Domain,
+	})
+	if err != nil {
+		return nil, agentError(err)
+	}
+
+	return &AgentResponse{Agent: toAgent(agent), Status: 200, Success: true, Message: "Agent Created"}, nil
+}
+
+// Method to get all agents
+func (as *AgentService) GetAgents(ctx context.Context, request *Empty) (*Agents, error) {
+	log.WithFields(util.StandardFieldsGRPC).Info("Request For Get All Agents")
+	list, err := agents.ListAgents()
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Error("unable to read agents")
+		return nil, core.StatusError(500, err.Error())
+	}
+
+	response := &Agents{Status: 200, Success: true}
+	for i := range list {
+		response.Agents = append(response.Agents, toAgent(&list[i]))
+	}
+	return response, nil
+}
+
+// Method to get an agent
+func (as *AgentService) GetAgent(ctx context.Context, request *AgentRequest) (*AgentResponse, error) {
+	log.WithFields(util.StandardFieldsGRPC).Info("Agent Information Request ,for:", request.Id)
+	agent, err := agents.FindAgent(request.Id)
+	if err != nil {
+		return nil, agentError(err)
+	}
+
+	return &AgentResponse{Agent: toAgent(agent), Status: 200, Success: true, Message: "Agent Information Fetched"}, nil
+}
+
+// Method to delete an agent
+func (as *AgentService) DeleteAgent(ctx context.Context, request *AgentRequest) (*AgentResponse, error) {
+	log.WithFields(util.StandardFieldsGRPC).Info("Delete Agent Request ,for:", request.Id)
+	if err := agents.DeleteAgent(request.Id); err != nil {
+		return nil, agentError(err)
+	}
+
+	return &AgentResponse{Status: 200, Success: true, Message: "Agent Deleted"}, nil
+}
+
+// Method to pause or resume an agent
+func (as *AgentService) ManageAgent(ctx context.Context, request *ManageAgentRequest) (*AgentResponse, error) {
+	log.WithFields(util.StandardFieldsGRPC).Info("Manage Agent Request ,for:", request.Id)
+	agent, err := agents.ManageAgent(request.Id, request.Action)
+	if err != nil {
+		return nil, agentError(err)
+	}
+
+	return &AgentResponse{Agent: toAgent(agent), Status: 200, Success: true, Message: "Agent " + request.Action + "d"}, nil
+}
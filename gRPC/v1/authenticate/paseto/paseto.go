@@ -3,41 +3,47 @@ package paseto
 import (
 	context "context"
 	"encoding/json"
-	"fmt"
+	"strings"
 
-	gopaseto "aidanwoods.dev/go-paseto"
 	"github.com/NetSepio/nexus/util/pkg/auth"
 	"github.com/NetSepio/nexus/util/pkg/claims"
+	"github.com/NetSepio/nexus/util/pkg/policy"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
+// PASETO authenticates the token of the authorization metadata, with or without the Bearer
+// scheme, and rejects the call with codes.Unauthenticated when it is missing or invalid
 func PASETO(ctx context.Context) (context.Context, error) {
 	md, _ := metadata.FromIncomingContext(ctx)
-	token := md["authorization"][0]
-	if token == "" {
+	values := md.Get("authorization")
+	if len(values) == 0 || values[0] == "" {
 		log.WithFields(log.Fields{
 			"err": "Authorization header is missing",
 		}).Error("Authorization header is missing")
-		new_ctx := context.WithValue(ctx, "error", 1)
-		return new_ctx, nil
+		return nil, status.Error(codes.Unauthenticated, "authorization metadata is missing")
 	}
-	parser := gopaseto.NewParser()
-	parser.AddRule(gopaseto.NotExpired())
-	publickey := auth.Getpublickey()
-	parsedToken, err := parser.ParseV4Public(publickey, token, nil)
+	token := values[0]
+	if len(token) > len("Bearer ") && strings.EqualFold(token[:len("Bearer ")], "Bearer ") {
+		token = token[len("Bearer "):]
+	}
+
+	// tokens signed by the node or by the gateway
+	parsedToken, err := auth.Verify(token)
 	if err != nil {
-		err = fmt.Errorf("failed to scan claims for paseto token, %s", err)
 		log.WithFields(log.Fields{
 			"err": err,
-		}).Error("failed to bindfailed to scan claims for paseto token")
-		new_ctx := context.WithValue(ctx, "error", 1)
-		return new_ctx, nil
+		}).Error("failed to scan claims for paseto token")
+		return nil, status.Error(codes.Unauthenticated, "invalid paseto token")
 	}
-	jsonvalue := parsedToken.ClaimsJSON()
 	ClaimsValue := claims.CustomClaims{}
-	json.Unmarshal(jsonvalue, &ClaimsValue)
+	if err := json.Unmarshal(parsedToken.ClaimsJSON(), &ClaimsValue); err != nil || ClaimsValue.WalletAddress == "" {
+		return nil, status.Error(codes.Unauthenticated, "paseto token has no wallet address")
+	}
 	new_ctx := context.WithValue(ctx, "walletAddress", ClaimsValue.WalletAddress)
+	new_ctx = context.WithValue(new_ctx, "principal", policy.New(ClaimsValue.WalletAddress, ClaimsValue.Roles, ClaimsValue.Scopes))
 
 	return new_ctx, nil
 }
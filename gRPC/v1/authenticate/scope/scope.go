@@ -0,0 +1,73 @@
+package scope
+
+import (
+	"context"
+
+	"github.com/NetSepio/nexus/util/pkg/policy"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Methods maps the methods behind the PASETO interceptor to the scope they require, an empty
+// scope only requires a valid token and methods missing here are admin-only
+var Methods = map[string]string{
+	"/server.ServerService/GetServerInformation":  policy.ScopeNodeAdmin,
+	"/server.ServerService/GetServerConfiguraion": policy.ScopeNodeAdmin,
+	"/server.ServerService/UpdateServer":          policy.ScopeNodeAdmin,
+	"/server.ServerService/GetPools":              "",
+	"/server.ServerService/UpdatePools":           policy.ScopeNodeAdmin,
+	"/server.ServerService/GetServerSpeed":        "",
+	"/server.ServerService/WatchPeerStats":        policy.ScopeReadonly,
+	"/status.StatusService/WatchNodeEvents":       policy.ScopeReadonly,
+	"/client.ClientService/GetClientInformation":  "",
+	"/client.ClientService/GetClients":            "",
+	"/client.ClientService/RegisterClient":        policy.ScopeClientsWrite,
+	"/client.ClientService/UpdateClient":          policy.ScopeClientsWrite,
+	"/client.ClientService/DeleteClient":          policy.ScopeClientsWrite,
+	"/client.ClientService/GetClientConfig":       "",
+	"/client.ClientService/WatchClients":          "",
+	"/service.ServiceService/AddService":          policy.ScopeServicesWrite,
+	"/service.ServiceService/GetServices":         "",
+	"/service.ServiceService/GetService":          "",
+	"/service.ServiceService/UpdateService":       policy.ScopeServicesWrite,
+	"/service.ServiceService/DeleteService":       policy.ScopeServicesWrite,
+	"/agent.AgentService/CreateAgent":             policy.ScopeAgentsWrite,
+	"/agent.AgentService/GetAgents":               "",
+	"/agent.AgentService/GetAgent":                "",
+	"/agent.AgentService/DeleteAgent":             policy.ScopeAgentsWrite,
+	"/agent.AgentService/ManageAgent":             policy.ScopeAgentsWrite,
+}
+
+// authorize checks the principal set by the PASETO interceptor against the scope of method
+func authorize(ctx context.Context, method string) error {
+	required, ok := Methods[method]
+	if !ok {
+		required = policy.ScopeNodeAdmin
+	}
+	p, _ := ctx.Value("principal").(policy.Principal)
+	if err := policy.Authorize(p, required); err != nil {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return nil
+}
+
+// UnaryServerInterceptor rejects unary calls whose token does not grant the method scope
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor rejects streams whose token does not grant the method scope
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
@@ -6,12 +6,12 @@ import (
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors"
 )
 
-func LoginSkip(_ context.Context, c interceptors.CallMeta) bool {
-	methods := []string{"server.ServerService", "client.ClientService"}
-	for _, s := range methods {
-		if c.Service == s {
-			return true
-		}
-	}
-	return false
+// Exempt lists the full methods callable without a PASETO token, every other method requires one
+var Exempt = map[string]bool{
+	"/status.StatusService/GetStatus": true,
+}
+
+// AuthRequired matches the calls the PASETO and scope interceptors run on
+func AuthRequired(_ context.Context, c interceptors.CallMeta) bool {
+	return !Exempt[c.FullMethod()]
 }
@@ -2,11 +2,14 @@ package client
 
 import (
 	"context"
+	"slices"
 
 	"github.com/NetSepio/nexus/core"
 	"github.com/NetSepio/nexus/model"
 	"github.com/NetSepio/nexus/util"
+	"github.com/NetSepio/nexus/util/pkg/policy"
 	log "github.com/sirupsen/logrus"
+	"github.com/skip2/go-qrcode"
 )
 
 // gRPC client service struct
@@ -16,19 +19,11 @@ type ClientService struct {
 
 // Method to get Client information
 func (cs *ClientService) GetClientInformation(ctx context.Context, request *ClientRequest) (*model.Response, error) {
-	if ctx.Value("error") == 1 {
-		response := core.MakeErrorResponse(500, "Bad Token", nil, nil, nil)
-		return response, nil
-	}
 	id := request.UUID
 	log.WithFields(util.StandardFieldsGRPC).Info("Client Information Request ,for:", id)
-	client, err := core.ReadClient(id)
+	client, err := authorizeClient(ctx, id, false)
 	if err != nil {
-		log.WithFields(log.Fields{
-			"err": err,
-		}).Error("unable to read client")
-		response := core.MakeErrorResponse(500, err.Error(), nil, nil, nil)
-		return response, err
+		return nil, err
 	}
 
 	response := core.MakeSucessResponse(200, "Client Information Fetched", nil, client, nil)
@@ -36,20 +31,32 @@ func (cs *ClientService) GetClientInformation(ctx context.Context, request *Clie
 	return response, nil
 }
 
+// Method to register client, the RegisterClient rpc
+func (cs *ClientService) RegisterClient(ctx context.Context, request *model.Client) (*model.Response, error) {
+	return cs.CreateClient(ctx, request)
+}
+
 // Method to create client
 func (cs *ClientService) CreateClient(ctx context.Context, request *model.Client) (*model.Response, error) {
-	if ctx.Value("error") == 1 {
-		response := core.MakeErrorResponse(500, "Bad Token", nil, nil, nil)
-		return response, nil
+	// the client belongs to the wallet of the token, only admins register clients for others
+	p := principal(ctx)
+	if !p.IsAdmin() && request.WalletAddress != "" && !policy.SameWallet(request.WalletAddress, p.Wallet) {
+		return nil, core.StatusError(403, "walletAddress must be the wallet of the token")
 	}
+	if !p.IsAdmin() {
+		// limits and pools are set by admins only
+		core.KeepClientLimits(request, &model.Client{})
+	}
+	request.CreatedBy = p.Wallet
+	request.UpdatedBy = p.Wallet
+
 	client, err := core.RegisterClient(request)
 	log.WithFields(util.StandardFieldsGRPC).Info("Client Creation Request")
 	if err != nil {
 		log.WithFields(log.Fields{
 			"err": err,
 		}).Error("unable to read client")
-		response := core.MakeErrorResponse(500, err.Error(), nil, nil, nil)
-		return response, err
+		return nil, core.StatusError(core.ErrorStatus(err), err.Error())
 	}
 
 	response := core.MakeSucessResponse(201, "Client Created", nil, client, nil)
@@ -58,19 +65,28 @@ func (cs *ClientService) CreateClient(ctx context.Context, request *model.Client
 
 // Method to update client
 func (cs *ClientService) UpdateClient(ctx context.Context, request *UpdateRequest) (*model.Response, error) {
-	if ctx.Value("error") == 1 {
-		response := core.MakeErrorResponse(500, "Bad Token", nil, nil, nil)
-		return response, nil
-	}
 	id := request.UUID
 	log.WithFields(util.StandardFieldsGRPC).Info("Client Update Request ,for:", id)
+	current, err := authorizeClient(ctx, id, true)
+	if err != nil {
+		return nil, err
+	}
+	p := principal(ctx)
+	if !p.IsAdmin() {
+		if request.Client.WalletAddress != current.WalletAddress && request.Client.WalletAddress != "" && !policy.SameWallet(request.Client.WalletAddress, p.Wallet) {
+			return nil, core.StatusError(403, "walletAddress must be the wallet of the token")
+		}
+		// limits and pools are set by admins only
+		core.KeepClientLimits(request.Client, current)
+	}
+	request.Client.UpdatedBy = p.Wallet
+
 	client, err := core.UpdateClient(id, request.Client)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"err": err,
 		}).Error("unable to read client")
-		response := core.MakeErrorResponse(500, err.Error(), nil, nil, nil)
-		return response, err
+		return nil, core.StatusError(core.ErrorStatus(err), err.Error())
 	}
 
 	response := core.MakeSucessResponse(200, "Client Updated", nil, client, nil)
@@ -79,19 +95,17 @@ func (cs *ClientService) UpdateClient(ctx context.Context, request *UpdateReques
 
 // Method to delete client
 func (cs *ClientService) DeleteClient(ctx context.Context, request *ClientRequest) (*model.Response, error) {
-	if ctx.Value("error") == 1 {
-		response := core.MakeErrorResponse(500, "Bad Token", nil, nil, nil)
-		return response, nil
-	}
 	id := request.UUID
 	log.WithFields(util.StandardFieldsGRPC).Info("Delete Client Request ,for:", id)
+	if _, err := authorizeClient(ctx, id, true); err != nil {
+		return nil, err
+	}
 	err := core.DeleteClient(id)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"err": err,
 		}).Error("unable to read client")
-		response := core.MakeErrorResponse(500, err.Error(), nil, nil, nil)
-		return response, err
+		return nil, core.StatusError(core.ErrorStatus(err), err.Error())
 	}
 
 	response := core.MakeSucessResponse(200, "Client Deleted", nil, nil, nil)
@@ -100,20 +114,100 @@ func (cs *ClientService) DeleteClient(ctx context.Context, request *ClientReques
 
 // Method to get all clients
 func (cs *ClientService) GetClients(ctx context.Context, request *Empty) (*model.Response, error) {
-	if ctx.Value("error") == 1 {
-		response := core.MakeErrorResponse(500, "Bad Token", nil, nil, nil)
-		return response, nil
-	}
 	log.WithFields(util.StandardFieldsGRPC).Info("Request For Get All Clients")
 	clients, err := core.ReadClients()
 	if err != nil {
 		log.WithFields(log.Fields{
 			"err": err,
 		}).Error("unable to read client")
-		response := core.MakeErrorResponse(500, err.Error(), nil, nil, nil)
-		return response, err
+		return nil, core.StatusError(500, err.Error())
+	}
+
+	// admins and read-only wallets see every client, tenants only their own
+	p := principal(ctx)
+	if !p.ReadsAll() {
+		clients = slices.DeleteFunc(clients, func(client *model.Client) bool {
+			return !core.OwnsClient(p.Wallet, client)
+		})
 	}
 
 	response := core.MakeSucessResponse(200, "Client Information Fetched", nil, nil, clients)
 	return response, nil
 }
+
+// Method to get the WireGuard config of a client, as a png qrcode when requested
+func (cs *ClientService) GetClientConfig(ctx context.Context, request *ConfigRequest) (*Config, error) {
+	id := request.UUID
+	log.WithFields(util.StandardFieldsGRPC).Info("Client Config Request ,for:", id)
+	if _, err := authorizeClient(ctx, id, false); err != nil {
+		return nil, err
+	}
+
+	configData, err := core.ReadClientConfig(id)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Error("failed to read client config")
+		return nil, core.StatusError(core.ErrorStatus(err), err.Error())
+	}
+	if !request.Qrcode {
+		return &Config{Config: configData, ContentType: "application/config", Status: 200, Success: true}, nil
+	}
+
+	png, err := qrcode.Encode(string(configData), qrcode.Medium, 250)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Error("failed to create qrcode")
+		return nil, core.StatusError(500, err.Error())
+	}
+	return &Config{Config: png, ContentType: "image/png", Status: 200, Success: true}, nil
+}
+
+// Method to stream the create, update and delete events of the clients the token may read
+func (cs *ClientService) WatchClients(request *Empty, stream ClientService_WatchClientsServer) error {
+	log.WithFields(util.StandardFieldsGRPC).Info("Request For Watch Clients")
+	p := principal(stream.Context())
+	events, cancel := core.Subscribe("client.")
+	defer cancel()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case e := <-events:
+			if !core.CanReadClient(p, e.Client) {
+				continue
+			}
+			event := &ClientEvent{Type: e.Type, Time: e.Time.UnixMilli(), Client: e.Client}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// principal returns the principal set by the PASETO interceptor
+func principal(ctx context.Context) policy.Principal {
+	p, _ := ctx.Value("principal").(policy.Principal)
+	return p
+}
+
+// authorizeClient reads the client when the token may read it, or manage it when manage is set,
+// otherwise it returns the status error of the request
+func authorizeClient(ctx context.Context, id string, manage bool) (*model.Client, error) {
+	client, err := core.ReadClient(id)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Error("unable to read client")
+		return nil, core.StatusError(core.ErrorStatus(err), err.Error())
+	}
+
+	p := principal(ctx)
+	if (manage && !core.CanManageClient(p, client)) || (!manage && !core.CanReadClient(p, client)) {
+		return nil, core.StatusError(403, "client belongs to another wallet")
+	}
+
+	return client, nil
+}
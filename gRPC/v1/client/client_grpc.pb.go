@@ -1,13 +1,17 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v4.25.1
+// source: gRPC/v1/client/client.proto
 
 package client
 
 import (
 	context "context"
+	model "github.com/NetSepio/nexus/model"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
-	model "github.com/NetSepio/nexus/model"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -20,12 +24,12 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ClientServiceClient interface {
 	GetClientInformation(ctx context.Context, in *ClientRequest, opts ...grpc.CallOption) (*model.Response, error)
-	GetClientConfiguration(ctx context.Context, in *ClientRequest, opts ...grpc.CallOption) (*Config, error)
-	EmailClientConfiguration(ctx context.Context, in *ClientRequest, opts ...grpc.CallOption) (*Config, error)
-	CreateClient(ctx context.Context, in *model.Client, opts ...grpc.CallOption) (*model.Response, error)
+	RegisterClient(ctx context.Context, in *model.Client, opts ...grpc.CallOption) (*model.Response, error)
 	UpdateClient(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*model.Response, error)
 	DeleteClient(ctx context.Context, in *ClientRequest, opts ...grpc.CallOption) (*model.Response, error)
 	GetClients(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*model.Response, error)
+	GetClientConfig(ctx context.Context, in *ConfigRequest, opts ...grpc.CallOption) (*Config, error)
+	WatchClients(ctx context.Context, in *Empty, opts ...grpc.CallOption) (ClientService_WatchClientsClient, error)
 }
 
 type clientServiceClient struct {
@@ -45,58 +49,81 @@ func (c *clientServiceClient) GetClientInformation(ctx context.Context, in *Clie
 	return out, nil
 }
 
-func (c *clientServiceClient) GetClientConfiguration(ctx context.Context, in *ClientRequest, opts ...grpc.CallOption) (*Config, error) {
-	out := new(Config)
-	err := c.cc.Invoke(ctx, "/client.ClientService/GetClientConfiguration", in, out, opts...)
+func (c *clientServiceClient) RegisterClient(ctx context.Context, in *model.Client, opts ...grpc.CallOption) (*model.Response, error) {
+	out := new(model.Response)
+	err := c.cc.Invoke(ctx, "/client.ClientService/RegisterClient", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *clientServiceClient) EmailClientConfiguration(ctx context.Context, in *ClientRequest, opts ...grpc.CallOption) (*Config, error) {
-	out := new(Config)
-	err := c.cc.Invoke(ctx, "/client.ClientService/EmailClientConfiguration", in, out, opts...)
+func (c *clientServiceClient) UpdateClient(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*model.Response, error) {
+	out := new(model.Response)
+	err := c.cc.Invoke(ctx, "/client.ClientService/UpdateClient", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *clientServiceClient) CreateClient(ctx context.Context, in *model.Client, opts ...grpc.CallOption) (*model.Response, error) {
+func (c *clientServiceClient) DeleteClient(ctx context.Context, in *ClientRequest, opts ...grpc.CallOption) (*model.Response, error) {
 	out := new(model.Response)
-	err := c.cc.Invoke(ctx, "/client.ClientService/CreateClient", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/client.ClientService/DeleteClient", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *clientServiceClient) UpdateClient(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*model.Response, error) {
+func (c *clientServiceClient) GetClients(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*model.Response, error) {
 	out := new(model.Response)
-	err := c.cc.Invoke(ctx, "/client.ClientService/UpdateClient", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/client.ClientService/GetClients", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *clientServiceClient) DeleteClient(ctx context.Context, in *ClientRequest, opts ...grpc.CallOption) (*model.Response, error) {
-	out := new(model.Response)
-	err := c.cc.Invoke(ctx, "/client.ClientService/DeleteClient", in, out, opts...)
+func (c *clientServiceClient) GetClientConfig(ctx context.Context, in *ConfigRequest, opts ...grpc.CallOption) (*Config, error) {
+	out := new(Config)
+	err := c.cc.Invoke(ctx, "/client.ClientService/GetClientConfig", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *clientServiceClient) GetClients(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*model.Response, error) {
-	out := new(model.Response)
-	err := c.cc.Invoke(ctx, "/client.ClientService/GetClients", in, out, opts...)
+func (c *clientServiceClient) WatchClients(ctx context.Context, in *Empty, opts ...grpc.CallOption) (ClientService_WatchClientsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ClientService_ServiceDesc.Streams[0], "/client.ClientService/WatchClients", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &clientServiceWatchClientsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ClientService_WatchClientsClient interface {
+	Recv() (*ClientEvent, error)
+	grpc.ClientStream
+}
+
+type clientServiceWatchClientsClient struct {
+	grpc.ClientStream
+}
+
+func (x *clientServiceWatchClientsClient) Recv() (*ClientEvent, error) {
+	m := new(ClientEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
 // ClientServiceServer is the server API for ClientService service.
@@ -104,12 +131,12 @@ func (c *clientServiceClient) GetClients(ctx context.Context, in *Empty, opts ..
 // for forward compatibility
 type ClientServiceServer interface {
 	GetClientInformation(context.Context, *ClientRequest) (*model.Response, error)
-	GetClientConfiguration(context.Context, *ClientRequest) (*Config, error)
-	EmailClientConfiguration(context.Context, *ClientRequest) (*Config, error)
-	CreateClient(context.Context, *model.Client) (*model.Response, error)
+	RegisterClient(context.Context, *model.Client) (*model.Response, error)
 	UpdateClient(context.Context, *UpdateRequest) (*model.Response, error)
 	DeleteClient(context.Context, *ClientRequest) (*model.Response, error)
 	GetClients(context.Context, *Empty) (*model.Response, error)
+	GetClientConfig(context.Context, *ConfigRequest) (*Config, error)
+	WatchClients(*Empty, ClientService_WatchClientsServer) error
 	mustEmbedUnimplementedClientServiceServer()
 }
 
@@ -120,14 +147,8 @@ type UnimplementedClientServiceServer struct {
 func (UnimplementedClientServiceServer) GetClientInformation(context.Context, *ClientRequest) (*model.Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetClientInformation not implemented")
 }
-func (UnimplementedClientServiceServer) GetClientConfiguration(context.Context, *ClientRequest) (*Config, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetClientConfiguration not implemented")
-}
-func (UnimplementedClientServiceServer) EmailClientConfiguration(context.Context, *ClientRequest) (*Config, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method EmailClientConfiguration not implemented")
-}
-func (UnimplementedClientServiceServer) CreateClient(context.Context, *model.Client) (*model.Response, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateClient not implemented")
+func (UnimplementedClientServiceServer) RegisterClient(context.Context, *model.Client) (*model.Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterClient not implemented")
 }
 func (UnimplementedClientServiceServer) UpdateClient(context.Context, *UpdateRequest) (*model.Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateClient not implemented")
@@ -138,6 +159,12 @@ func (UnimplementedClientServiceServer) DeleteClient(context.Context, *ClientReq
 func (UnimplementedClientServiceServer) GetClients(context.Context, *Empty) (*model.Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetClients not implemented")
 }
+func (UnimplementedClientServiceServer) GetClientConfig(context.Context, *ConfigRequest) (*Config, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClientConfig not implemented")
+}
+func (UnimplementedClientServiceServer) WatchClients(*Empty, ClientService_WatchClientsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchClients not implemented")
+}
 func (UnimplementedClientServiceServer) mustEmbedUnimplementedClientServiceServer() {}
 
 // UnsafeClientServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -169,56 +196,20 @@ func _ClientService_GetClientInformation_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ClientService_GetClientConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ClientRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ClientServiceServer).GetClientConfiguration(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/client.ClientService/GetClientConfiguration",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClientServiceServer).GetClientConfiguration(ctx, req.(*ClientRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _ClientService_EmailClientConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ClientRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ClientServiceServer).EmailClientConfiguration(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/client.ClientService/EmailClientConfiguration",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClientServiceServer).EmailClientConfiguration(ctx, req.(*ClientRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _ClientService_CreateClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ClientService_RegisterClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(model.Client)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ClientServiceServer).CreateClient(ctx, in)
+		return srv.(ClientServiceServer).RegisterClient(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/client.ClientService/CreateClient",
+		FullMethod: "/client.ClientService/RegisterClient",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClientServiceServer).CreateClient(ctx, req.(*model.Client))
+		return srv.(ClientServiceServer).RegisterClient(ctx, req.(*model.Client))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -277,6 +268,45 @@ func _ClientService_GetClients_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ClientService_GetClientConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientServiceServer).GetClientConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/client.ClientService/GetClientConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientServiceServer).GetClientConfig(ctx, req.(*ConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientService_WatchClients_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ClientServiceServer).WatchClients(m, &clientServiceWatchClientsServer{stream})
+}
+
+type ClientService_WatchClientsServer interface {
+	Send(*ClientEvent) error
+	grpc.ServerStream
+}
+
+type clientServiceWatchClientsServer struct {
+	grpc.ServerStream
+}
+
+func (x *clientServiceWatchClientsServer) Send(m *ClientEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // ClientService_ServiceDesc is the grpc.ServiceDesc for ClientService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -289,16 +319,8 @@ var ClientService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _ClientService_GetClientInformation_Handler,
 		},
 		{
-			MethodName: "GetClientConfiguration",
-			Handler:    _ClientService_GetClientConfiguration_Handler,
-		},
-		{
-			MethodName: "EmailClientConfiguration",
-			Handler:    _ClientService_EmailClientConfiguration_Handler,
-		},
-		{
-			MethodName: "CreateClient",
-			Handler:    _ClientService_CreateClient_Handler,
+			MethodName: "RegisterClient",
+			Handler:    _ClientService_RegisterClient_Handler,
 		},
 		{
 			MethodName: "UpdateClient",
@@ -312,7 +334,17 @@ var ClientService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetClients",
 			Handler:    _ClientService_GetClients_Handler,
 		},
+		{
+			MethodName: "GetClientConfig",
+			Handler:    _ClientService_GetClientConfig_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchClients",
+			Handler:       _ClientService_WatchClients_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "gRPC/v1/client/client.proto",
 }
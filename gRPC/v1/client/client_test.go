@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/NetSepio/nexus/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestCreateClient(t *testing.T) {
@@ -17,9 +19,10 @@ func TestCreateClient(t *testing.T) {
 	data.Enable = true
 	data.AllowedIPs = []string{"0.0.0.0/0", "::/0"}
 	data.Address = []string{"10.0.0.1/24"}
+	// a token may only create clients of its own wallet
 	response, err := client.CreateClient(context.Background(), data)
-	if err != nil {
-		t.Error(err)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied, got %v", err)
 	} else {
 		t.Log("Sucess")
 		t.Log(response)
@@ -0,0 +1,73 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/NetSepio/nexus/core"
+	"github.com/NetSepio/nexus/gRPC/v1/agent"
+	"github.com/NetSepio/nexus/gRPC/v1/client"
+	"github.com/NetSepio/nexus/gRPC/v1/server"
+	"github.com/NetSepio/nexus/gRPC/v1/service"
+	"github.com/NetSepio/nexus/gRPC/v1/status"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	grpc_status "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Gateway returns the REST gateway of the gRPC services, mounted under /api/v2.
+// It calls the gRPC server listening on endpoint, so both transports share the
+// authentication and scope interceptors.
+func Gateway(ctx context.Context, endpoint string) (http.Handler, error) {
+	mux := runtime.NewServeMux(
+		// encode like the gin routes of /api/v1.0
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONBuiltin{}),
+		runtime.WithErrorHandler(errorHandler),
+		runtime.WithForwardResponseOption(forwardStatus),
+	)
+
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	for _, register := range []func(context.Context, *runtime.ServeMux, string, []grpc.DialOption) error{
+		server.RegisterServerServiceHandlerFromEndpoint,
+		client.RegisterClientServiceHandlerFromEndpoint,
+		status.RegisterStatusServiceHandlerFromEndpoint,
+		service.RegisterServiceServiceHandlerFromEndpoint,
+		agent.RegisterAgentServiceHandlerFromEndpoint,
+	} {
+		if err := register(ctx, mux, endpoint, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	return mux, nil
+}
+
+// errorHandler answers a failed call with the HTTP status of its code and the error response of /api/v1.0
+func errorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	s := grpc_status.Convert(err)
+	httpStatus := runtime.HTTPStatusFromCode(s.Code())
+	response := core.MakeErrorResponse(int64(httpStatus), s.Message(), nil, nil, nil)
+
+	buf, err := marshaler.Marshal(response)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Error("failed to marshal error response")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", marshaler.ContentType(response))
+	w.WriteHeader(httpStatus)
+	w.Write(buf)
+}
+
+// forwardStatus answers with the status of the response when it is not 200, such as 201 for created clients
+func forwardStatus(ctx context.Context, w http.ResponseWriter, m proto.Message) error {
+	if response, ok := m.(interface{ GetStatus() int64 }); ok && response.GetStatus() > http.StatusOK {
+		w.WriteHeader(int(response.GetStatus()))
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/NetSepio/nexus/core"
+	"github.com/NetSepio/nexus/model"
+	"github.com/NetSepio/nexus/storage"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+func TestErrorHandler(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"bad request", core.StatusError(400, "invalid"), 400},
+		// there is no code of its own for 422, it answers as a bad request
+		{"unprocessable entity", core.StatusError(422, "invalid"), 400},
+		{"unauthorized", core.StatusError(401, "invalid"), 401},
+		{"forbidden", core.StatusError(403, "invalid"), 403},
+		{"not found", core.StatusError(404, "invalid"), 404},
+		{"conflict", core.StatusError(409, "invalid"), 409},
+		{"too many requests", core.StatusError(429, "invalid"), 429},
+		{"internal", core.StatusError(500, "invalid"), 500},
+		{"not implemented", core.StatusError(501, "invalid"), 501},
+		{"unavailable", core.StatusError(503, "invalid"), 503},
+		{"gateway timeout", core.StatusError(504, "invalid"), 504},
+		{"unknown status", core.StatusError(418, "invalid"), 500},
+		{"missing record", core.StatusError(core.ErrorStatus(fmt.Errorf("read: %w", storage.ErrNotFound)), "invalid"), 404},
+		{"failed read", core.StatusError(core.ErrorStatus(errors.New("disk")), "invalid"), 500},
+		{"invalid client", core.StatusError(core.ErrorStatus(fmt.Errorf("%w: bad", core.ErrInvalid)), "invalid"), 400},
+		{"key in use", core.StatusError(core.ErrorStatus(fmt.Errorf("%w: bad", core.ErrConflict)), "invalid"), 409},
+		{"pool exhausted", core.StatusError(core.ErrorStatus(fmt.Errorf("%w: bad", core.ErrPoolExhausted)), "invalid"), 503},
+		{"plain error", errors.New("invalid"), 500},
+	} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/v2/server", nil)
+		errorHandler(context.Background(), nil, &runtime.JSONBuiltin{}, w, r, tc.err)
+
+		var response model.Response
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if w.Code != tc.status || response.Status != int64(tc.status) || response.Error != "invalid" {
+			t.Errorf("%s: expected %d, got %d with %v", tc.name, tc.status, w.Code, &response)
+		}
+	}
+}
+
+func TestForwardStatus(t *testing.T) {
+	for _, tc := range []struct {
+		status int64
+		code   int
+	}{
+		{201, 201},
+		{200, 200},
+		// responses without a status keep the status of the gateway
+		{0, 200},
+	} {
+		w := httptest.NewRecorder()
+		if err := forwardStatus(context.Background(), w, &model.Response{Status: tc.status}); err != nil {
+			t.Fatal(err)
+		}
+		if w.Code != tc.code {
+			t.Errorf("status %d: expected %d, got %d", tc.status, tc.code, w.Code)
+		}
+	}
+}
+
+func TestGateway(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	serveTestServer(t, listener)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gateway, err := Gateway(ctx, listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	call := func(method string, path string, token string, body string) (int, *model.Response) {
+		r := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		gateway.ServeHTTP(w, r)
+
+		response := new(model.Response)
+		if err := json.Unmarshal(w.Body.Bytes(), response); err != nil {
+			t.Fatalf("%s %s: %v: %s", method, path, err, w.Body.String())
+		}
+		return w.Code, response
+	}
+
+	admin := token(t, operatorWallet)
+	if code, response := call(http.MethodGet, "/api/v2/server", "", ""); code != 401 || response.Status != 401 {
+		t.Errorf("expected 401 without token, got %d with %v", code, response)
+	}
+	if code, response := call(http.MethodGet, "/api/v2/server", token(t, tenantWallet), ""); code != 403 {
+		t.Errorf("expected 403 for tenants, got %d with %v", code, response)
+	}
+	if code, response := call(http.MethodGet, "/api/v2/server", admin, ""); code != 200 || response.Server.GetPublicKey() != "pub" || response.Server.GetPrivateKey() != "" || response.Server.GetNextPrivateKey() != "" {
+		t.Errorf("expected the server without private keys, got %d with %v", code, response)
+	}
+
+	code, response := call(http.MethodPost, "/api/v2/client", admin, `{"name":"laptop","enable":true,"allowedIPs":["0.0.0.0/0"]}`)
+	if code != 201 || response.Client.GetUUID() == "" || response.Client.GetPrivateKey() == "" {
+		t.Fatalf("expected the created client, got %d with %v", code, response)
+	}
+	if code, response := call(http.MethodGet, "/api/v2/client/"+response.Client.GetUUID(), admin, ""); code != 200 || response.Client.GetPrivateKey() != "" {
+		t.Errorf("expected the client without private key, got %d with %v", code, response)
+	}
+	if code, response := call(http.MethodPost, "/api/v2/client", admin, `{"name":"phone","enable":true,"allowedIPs":["0.0.0.0/0"],"privateKey":"`+response.Client.GetPrivateKey()+`"}`); code != 400 {
+		t.Errorf("expected 400 for a submitted private key, got %d with %v", code, response)
+	}
+	if code, response := call(http.MethodPost, "/api/v2/client", admin, `{"name":"phone","enable":true,"allowedIPs":["0.0.0.0/0"],"publicKey":"`+response.Client.GetPublicKey()+`"}`); code != 409 {
+		t.Errorf("expected 409 for a public key in use, got %d with %v", code, response)
+	}
+	if code, response := call(http.MethodPost, "/api/v2/client", admin, `{"name":"phone","enable":true,"allowedIPs":["0.0.0.0/0"],"pool":"missing"}`); code != 400 {
+		t.Errorf("expected 400 for an unknown pool, got %d with %v", code, response)
+	}
+	if code, response := call(http.MethodGet, "/api/v2/client/missing", admin, ""); code != 404 || response.Status != 404 {
+		t.Errorf("expected 404 for a missing client, got %d with %v", code, response)
+	}
+}
@@ -2,11 +2,12 @@ package server
 
 import (
 	"context"
-	"errors"
+	"time"
 
 	"github.com/NetSepio/nexus/core"
 	"github.com/NetSepio/nexus/model"
 	"github.com/NetSepio/nexus/util"
+	"github.com/NetSepio/nexus/util/pkg/speedtest"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -16,37 +17,28 @@ type ServerService struct {
 
 // Method to get server information
 func (ss *ServerService) GetServerInformation(ctx context.Context, request *Empty) (*model.Response, error) {
-	if ctx.Value("error") == 1 {
-		response := core.MakeErrorResponse(500, "Bad Token", nil, nil, nil)
-		return response, nil
-	}
 	log.WithFields(util.StandardFieldsGRPC).Info("Request For Sever Information")
 	server, err := core.ReadServer()
 	if err != nil {
 		log.WithFields(log.Fields{
 			"err": err,
 		}).Error("unable to get server info")
-		response := core.MakeErrorResponse(500, err.Error(), nil, nil, nil)
-		return response, err
+		return nil, core.StatusError(500, err.Error())
 	}
 
-	response := core.MakeSucessResponse(200, "Server Information Fetched", server, nil, nil)
+	response := core.MakeSucessResponse(200, "Server Information Fetched", core.WithoutPrivateKeys(server), nil, nil)
 	return response, nil
 }
 
 // method to get server configuration
 func (ss *ServerService) GetServerConfiguraion(ctx context.Context, request *Empty) (*Config, error) {
-	if ctx.Value("error") == 1 {
-		return &Config{Status: 500, Success: false, Error: "bad token"}, nil
-	}
 	log.WithFields(util.StandardFieldsGRPC).Info("Request For Sever Configurtaion")
 	configData, err := core.ReadWgConfigFile()
 	if err != nil {
 		log.WithFields(log.Fields{
 			"err": err,
 		}).Error("unable to read server configuration")
-
-		return nil, errors.New(err.Error())
+		return nil, core.StatusError(500, err.Error())
 	}
 
 	return &Config{Config: configData, Status: 200, Success: true}, nil
@@ -54,18 +46,109 @@ func (ss *ServerService) GetServerConfiguraion(ctx context.Context, request *Emp
 
 // Method to update server
 func (ss *ServerService) UpdateServer(ctx context.Context, request *model.Server) (*model.Response, error) {
-	if ctx.Value("error") == 1 {
-		response := core.MakeErrorResponse(500, "Bad Token", nil, nil, nil)
-		return response, nil
-	}
 	log.WithFields(util.StandardFieldsGRPC).Info("Request For Update Server")
 	server, err := core.UpdateServer(request)
 	if err != nil {
 		log.WithFields(util.StandardFields).Error("Failed to update server")
-		response := core.MakeErrorResponse(500, err.Error(), nil, nil, nil)
-		return response, err
+		return nil, core.StatusError(500, err.Error())
 	}
 
-	response := core.MakeSucessResponse(200, "Server Updated", server, nil, nil)
+	response := core.MakeSucessResponse(200, "Server Updated", core.WithoutPrivateKeys(server), nil, nil)
 	return response, nil
 }
+
+// Method to get the utilization of the address pools
+func (ss *ServerService) GetPools(ctx context.Context, request *Empty) (*Pools, error) {
+	log.WithFields(util.StandardFieldsGRPC).Info("Request For Pools")
+	pools, err := core.ReadPools()
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Error("unable to read pools")
+		return nil, core.StatusError(500, err.Error())
+	}
+
+	return &Pools{Pools: pools, Status: 200, Success: true}, nil
+}
+
+// Method to replace the address pools
+func (ss *ServerService) UpdatePools(ctx context.Context, request *PoolsRequest) (*Pools, error) {
+	log.WithFields(util.StandardFieldsGRPC).Info("Request For Update Pools")
+	pools, err := core.UpdatePools(request.Pools)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Error("unable to update pools")
+		return nil, core.StatusError(core.ErrorStatus(err), err.Error())
+	}
+
+	return &Pools{Pools: pools, Status: 200, Success: true}, nil
+}
+
+// defaultStatsInterval is how often WatchPeerStats reads the device when no interval is requested
+const defaultStatsInterval = 5 * time.Second
+
+// Method to stream the statistics of every peer of the WireGuard device
+func (ss *ServerService) WatchPeerStats(request *PeerStatsRequest, stream ServerService_WatchPeerStatsServer) error {
+	log.WithFields(util.StandardFieldsGRPC).Info("Request For Watch Peer Stats")
+	interval := defaultStatsInterval
+	if request.Interval > 0 {
+		interval = time.Duration(request.Interval) * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		peers, err := core.ReadPeerStats()
+		if err != nil {
+			log.WithFields(log.Fields{
+				"err": err,
+			}).Error("unable to read peer stats")
+			return core.StatusError(503, err.Error())
+		}
+
+		message := &PeerStats{Time: time.Now().UnixMilli()}
+		for _, p := range peers {
+			stat := &PeerStat{
+				PublicKey:        p.PublicKey,
+				Endpoint:         p.Endpoint,
+				ReceivedBytes:    p.ReceivedBytes,
+				TransmittedBytes: p.TransmittedBytes,
+				ReceiveRate:      p.ReceiveRate,
+				TransmitRate:     p.TransmitRate,
+				Online:           p.Online,
+			}
+			if !p.LastHandshake.IsZero() {
+				stat.LastHandshake = p.LastHandshake.UnixMilli()
+			}
+			message.Peers = append(message.Peers, stat)
+		}
+		if err := stream.Send(message); err != nil {
+			return err
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Method to run a speed test from the server
+func (ss *ServerService) GetServerSpeed(ctx context.Context, request *Empty) (*Speed, error) {
+	log.WithFields(util.StandardFieldsGRPC).Info("Request For Server Speed")
+	res, err := speedtest.GetSpeedtestResults()
+	if err != nil || res == nil {
+		log.WithFields(util.StandardFields).Error("Failed to read server speed")
+		return nil, core.StatusError(500, "failed to read server speed")
+	}
+
+	return &Speed{
+		Latency:       res.Latency,
+		DownloadSpeed: res.DownloadSpeed,
+		UploadSpeed:   res.UploadSpeed,
+		Status:        200,
+		Success:       true,
+	}, nil
+}
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.2.0
-// - protoc             v3.21.12
+// - protoc             v4.25.1
 // source: gRPC/v1/server/server.proto
 
 package server
@@ -26,6 +26,10 @@ type ServerServiceClient interface {
 	GetServerInformation(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*model.Response, error)
 	GetServerConfiguraion(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Config, error)
 	UpdateServer(ctx context.Context, in *model.Server, opts ...grpc.CallOption) (*model.Response, error)
+	GetPools(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Pools, error)
+	UpdatePools(ctx context.Context, in *PoolsRequest, opts ...grpc.CallOption) (*Pools, error)
+	GetServerSpeed(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Speed, error)
+	WatchPeerStats(ctx context.Context, in *PeerStatsRequest, opts ...grpc.CallOption) (ServerService_WatchPeerStatsClient, error)
 }
 
 type serverServiceClient struct {
@@ -63,6 +67,65 @@ func (c *serverServiceClient) UpdateServer(ctx context.Context, in *model.Server
 	return out, nil
 }
 
+func (c *serverServiceClient) GetPools(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Pools, error) {
+	out := new(Pools)
+	err := c.cc.Invoke(ctx, "/server.ServerService/GetPools", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serverServiceClient) UpdatePools(ctx context.Context, in *PoolsRequest, opts ...grpc.CallOption) (*Pools, error) {
+	out := new(Pools)
+	err := c.cc.Invoke(ctx, "/server.ServerService/UpdatePools", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serverServiceClient) GetServerSpeed(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Speed, error) {
+	out := new(Speed)
+	err := c.cc.Invoke(ctx, "/server.ServerService/GetServerSpeed", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serverServiceClient) WatchPeerStats(ctx context.Context, in *PeerStatsRequest, opts ...grpc.CallOption) (ServerService_WatchPeerStatsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ServerService_ServiceDesc.Streams[0], "/server.ServerService/WatchPeerStats", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serverServiceWatchPeerStatsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ServerService_WatchPeerStatsClient interface {
+	Recv() (*PeerStats, error)
+	grpc.ClientStream
+}
+
+type serverServiceWatchPeerStatsClient struct {
+	grpc.ClientStream
+}
+
+func (x *serverServiceWatchPeerStatsClient) Recv() (*PeerStats, error) {
+	m := new(PeerStats)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ServerServiceServer is the server API for ServerService service.
 // All implementations must embed UnimplementedServerServiceServer
 // for forward compatibility
@@ -70,6 +133,10 @@ type ServerServiceServer interface {
 	GetServerInformation(context.Context, *Empty) (*model.Response, error)
 	GetServerConfiguraion(context.Context, *Empty) (*Config, error)
 	UpdateServer(context.Context, *model.Server) (*model.Response, error)
+	GetPools(context.Context, *Empty) (*Pools, error)
+	UpdatePools(context.Context, *PoolsRequest) (*Pools, error)
+	GetServerSpeed(context.Context, *Empty) (*Speed, error)
+	WatchPeerStats(*PeerStatsRequest, ServerService_WatchPeerStatsServer) error
 	mustEmbedUnimplementedServerServiceServer()
 }
 
@@ -86,6 +153,18 @@ func (UnimplementedServerServiceServer) GetServerConfiguraion(context.Context, *
 func (UnimplementedServerServiceServer) UpdateServer(context.Context, *model.Server) (*model.Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateServer not implemented")
 }
+func (UnimplementedServerServiceServer) GetPools(context.Context, *Empty) (*Pools, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPools not implemented")
+}
+func (UnimplementedServerServiceServer) UpdatePools(context.Context, *PoolsRequest) (*Pools, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePools not implemented")
+}
+func (UnimplementedServerServiceServer) GetServerSpeed(context.Context, *Empty) (*Speed, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServerSpeed not implemented")
+}
+func (UnimplementedServerServiceServer) WatchPeerStats(*PeerStatsRequest, ServerService_WatchPeerStatsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchPeerStats not implemented")
+}
 func (UnimplementedServerServiceServer) mustEmbedUnimplementedServerServiceServer() {}
 
 // UnsafeServerServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -153,6 +232,81 @@ func _ServerService_UpdateServer_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ServerService_GetPools_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerServiceServer).GetPools(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/server.ServerService/GetPools",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerServiceServer).GetPools(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServerService_UpdatePools_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PoolsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerServiceServer).UpdatePools(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/server.ServerService/UpdatePools",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerServiceServer).UpdatePools(ctx, req.(*PoolsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServerService_GetServerSpeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerServiceServer).GetServerSpeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/server.ServerService/GetServerSpeed",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerServiceServer).GetServerSpeed(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServerService_WatchPeerStats_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PeerStatsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServerServiceServer).WatchPeerStats(m, &serverServiceWatchPeerStatsServer{stream})
+}
+
+type ServerService_WatchPeerStatsServer interface {
+	Send(*PeerStats) error
+	grpc.ServerStream
+}
+
+type serverServiceWatchPeerStatsServer struct {
+	grpc.ServerStream
+}
+
+func (x *serverServiceWatchPeerStatsServer) Send(m *PeerStats) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // ServerService_ServiceDesc is the grpc.ServiceDesc for ServerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -172,7 +326,25 @@ var ServerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateServer",
 			Handler:    _ServerService_UpdateServer_Handler,
 		},
+		{
+			MethodName: "GetPools",
+			Handler:    _ServerService_GetPools_Handler,
+		},
+		{
+			MethodName: "UpdatePools",
+			Handler:    _ServerService_UpdatePools_Handler,
+		},
+		{
+			MethodName: "GetServerSpeed",
+			Handler:    _ServerService_GetServerSpeed_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchPeerStats",
+			Handler:       _ServerService_WatchPeerStats_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "gRPC/v1/server/server.proto",
 }
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/NetSepio/nexus/api/v1/middleware"
+	"github.com/NetSepio/nexus/core"
+	"github.com/NetSepio/nexus/model"
+	"github.com/NetSepio/nexus/util"
+	"github.com/NetSepio/nexus/util/pkg/policy"
+	log "github.com/sirupsen/logrus"
+)
+
+// gRPC service service struct, the reverse proxy services of the /caddy routes
+type ServiceService struct {
+	UnimplementedServiceServiceServer
+}
+
+// toService converts a stored service to its message, without the basic auth password
+func toService(s *model.Service) *Service {
+	service := &Service{
+		Name:          s.Name,
+		Type:          s.Type,
+		IpAddress:     s.IpAddress,
+		Port:          s.Port,
+		Domain:        s.Domain,
+		Status:        s.Status,
+		CreatedAt:     s.CreatedAt,
+		Owner:         s.Owner,
+		Paths:         s.Paths,
+		Upstreams:     s.Upstreams,
+		LoadBalancing: s.LoadBalancing,
+	}
+	if hc := s.HealthCheck; hc != nil {
+		service.HealthCheck = &HealthCheck{Path: hc.Path, Interval: hc.Interval, Timeout: hc.Timeout, ExpectStatus: int64(hc.ExpectStatus)}
+	}
+	if h := s.Headers; h != nil {
+		service.Headers = &Headers{Request: h.Request, Response: h.Response}
+	}
+	if a := s.Auth; a != nil {
+		service.Auth = &Auth{Type: a.Type, Username: a.Username, Wallets: a.Wallets}
+	}
+	if t := s.TLS; t != nil {
+		service.Tls = &TLS{Email: t.Email, Issuer: t.Issuer, Ca: t.CA}
+	}
+	if rl := s.RateLimit; rl != nil {
+		service.RateLimit = &RateLimit{Requests: int64(rl.Requests), Window: rl.Window}
+	}
+	if h := s.Health; h != nil {
+		service.Health = toHealth(h)
+	}
+	return service
+}
+
+// toHealth converts the health of a service to its message
+func toHealth(h *model.ServiceHealth) *ServiceHealth {
+	health := &ServiceHealth{Status: h.Status, Check: h.Check, LastChecked: h.LastChecked, Since: h.Since, LatencyMs: h.LatencyMs}
+	for _, u := range h.Upstreams {
+		health.Upstreams = append(health.Upstreams, &UpstreamHealth{Address: u.Address, Up: u.Up, LatencyMs: u.LatencyMs, Error: u.Error})
+	}
+	for _, sample := range h.History {
+		health.History = append(health.History, &HealthSample{Time: sample.Time, Status: sample.Status, LatencyMs: sample.LatencyMs})
+	}
+	for _, t := range h.Transitions {
+		health.Transitions = append(health.Transitions, &HealthTransition{Time: t.Time, From: t.From, To: t.To})
+	}
+	return health
+}
+
+// fromPayload converts a payload to the service it adds
+func fromPayload(p *ServicePayload) model.Service {
+	service := model.Service{
+		Name:          p.Name,
+		Type:          os.Getenv("NODE_TYPE"),
+		Port:          p.Port,
+		Domain:        middleware.ServiceDomain(p.Name),
+		IpAddress:     p.IpAddress,
+		Paths:         p.Paths,
+		Upstreams:     p.Upstreams,
+		LoadBalancing: p.LoadBalancing,
+	}
+	if hc := p.HealthCheck; hc != nil {
+		service.HealthCheck = &model.ServiceHealthCheck{Path: hc.Path, Interval: hc.Interval, Timeout: hc.Timeout, ExpectStatus: int(hc.ExpectStatus)}
+	}
+	if h := p.Headers; h != nil {
+		service.Headers = &model.ServiceHeaders{Request: h.Request, Response: h.Response}
+	}
+	if a := p.Auth; a != nil {
+		service.Auth = &model.ServiceAuth{Type: a.Type, Username: a.Username, Password: a.Password, Wallets: a.Wallets}
+	}
+	if t := p.Tls; t != nil {
+		service.TLS = &model.ServiceTLS{Email: t.Email, Issuer: t.Issuer, CA: t.Ca}
+	}
+	if rl := p.RateLimit; rl != nil {
+		service.RateLimit = &model.ServiceRateLimit{Requests: int(rl.Requests), Window: rl.Window}
+	}
+	return service
+}
+
+// Method to add a service, owned by the wallet of the token
+func (ss *ServiceService) AddService(ctx context.Context, request *ServicePayload) (*ServiceResponse, error) {
+	log.WithFields(util.StandardFieldsGRPC).Info("Add Service Request ,for:", request.Name)
+	p := principal(ctx)
+	data := fromPayload(request)
+	data.Owner = p.Wallet
+	value, msg, err := middleware.IsValidService(data)
+	if err != nil {
+		return nil, serviceError(err)
+	}
+	if value == -1 {
+		return nil, core.StatusError(400, msg)
+	}
+	if err := middleware.CheckServiceTargets(p, data); err != nil {
+		return nil, serviceError(err)
+	}
+
+	data.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	if err := middleware.AddServices(data, middleware.ServiceLimit(p)); err != nil {
+		return nil, serviceError(err)
+	}
+
+	return &ServiceResponse{Service: toService(&data), Status: 200, Success: true, Message: "Service Added"}, nil
+}
+
+// Method to update the upstreams and options of a service, empty fields keep their value and the
+// options named in clear are removed
+func (ss *ServiceService) UpdateService(ctx context.Context, request *UpdateServiceRequest) (*ServiceResponse, error) {
+	log.WithFields(util.StandardFieldsGRPC).Info("Update Service Request ,for:", request.Name)
+	current, err := authorizeService(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := middleware.ServiceChanges{Clear: request.Clear}
+	if payload := request.Service; payload != nil {
+		update := fromPayload(payload)
+		changes.IpAddress = update.IpAddress
+		changes.Port = update.Port
+		changes.Paths = update.Paths
+		changes.Upstreams = update.Upstreams
+		changes.LoadBalancing = update.LoadBalancing
+		changes.HealthCheck = update.HealthCheck
+		changes.Headers = update.Headers
+		changes.Auth = update.Auth
+		changes.TLS = update.TLS
+		changes.RateLimit = update.RateLimit
+	}
+	service, err := middleware.MergeService(*current, changes)
+	if err != nil {
+		return nil, serviceError(err)
+	}
+	if err := middleware.CheckServiceTargets(principal(ctx), service); err != nil {
+		return nil, serviceError(err)
+	}
+
+	updated, err := middleware.UpdateService(current.Name, service)
+	if err != nil {
+		return nil, serviceError(err)
+	}
+
+	return &ServiceResponse{Service: toService(updated), Status: 200, Success: true, Message: "Service Updated"}, nil
+}
+
+// Method to get all services
+func (ss *ServiceService) GetServices(ctx context.Context, request *Empty) (*Services, error) {
+	log.WithFields(util.StandardFieldsGRPC).Info("Request For Get All Services")
+	services, err := middleware.ReadServices()
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Error("unable to read services")
+		return nil, core.StatusError(500, err.Error())
+	}
+
+	response := &Services{Status: 200, Success: true}
+	for i := range services.Services {
+		service := middleware.WithHealth(services.Services[i])
+		response.Services = append(response.Services, toService(&service))
+	}
+	return response, nil
+}
+
+// Method to get a service with its health
+func (ss *ServiceService) GetService(ctx context.Context, request *ServiceRequest) (*ServiceResponse, error) {
+	log.WithFields(util.StandardFieldsGRPC).Info("Service Information Request ,for:", request.Name)
+	service, err := middleware.ReadService(request.Name)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Error("unable to read service")
+		return nil, core.StatusError(500, err.Error())
+	}
+	if service.Name == "" {
+		return nil, core.StatusError(404, "Service Doesn't Exists")
+	}
+
+	withHealth := middleware.WithHealth(*service)
+	return &ServiceResponse{Service: toService(&withHealth), Status: 200, Success: true, Message: "Service Information Fetched"}, nil
+}
+
+// Method to delete a service
+func (ss *ServiceService) DeleteService(ctx context.Context, request *ServiceRequest) (*ServiceResponse, error) {
+	log.WithFields(util.StandardFieldsGRPC).Info("Delete Service Request ,for:", request.Name)
+	service, err := authorizeService(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := middleware.DeleteService(service.Name); err != nil {
+		return nil, serviceError(err)
+	}
+
+	return &ServiceResponse{Status: 200, Success: true, Message: "Deleted Services " + request.Name}, nil
+}
+
+// principal returns the principal set by the PASETO interceptor
+func principal(ctx context.Context) policy.Principal {
+	p, _ := ctx.Value("principal").(policy.Principal)
+	return p
+}
+
+// authorizeService reads the service called name when the token may manage it,
+// otherwise it returns the status error of the request
+func authorizeService(ctx context.Context, name string) (*model.Service, error) {
+	service, err := middleware.ReadService(name)
+	if err != nil {
+		return nil, serviceError(err)
+	}
+	if service.Name == "" {
+		return nil, core.StatusError(404, "Service Doesn't Exists")
+	}
+	if !middleware.CanManageService(principal(ctx), service) {
+		return nil, core.StatusError(403, "service belongs to another wallet")
+	}
+	return service, nil
+}
+
+// serviceError returns the status error of a service error, with the status the REST routes answer
+func serviceError(err error) error {
+	status := middleware.ServiceErrorStatus(err)
+	if status == http.StatusInternalServerError {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Error("service request failed")
+	}
+	return core.StatusError(int64(status), err.Error())
+}
@@ -2,7 +2,6 @@ package status
 
 import (
 	"context"
-	"errors"
 
 	"github.com/NetSepio/nexus/core"
 	"github.com/NetSepio/nexus/model"
@@ -21,7 +20,26 @@ func (s *StatusService) GetStatus(ctx context.Context, request *Empty) (*model.S
 		log.WithFields(log.Fields{
 			"err": err,
 		}).Error("Failed to get Server Status")
-		return nil, errors.New(err.Error())
+		return nil, core.StatusError(500, err.Error())
 	}
 	return status, nil
 }
+
+// WatchNodeEvents streams the checkpoint, p2p peer and agent events of the node
+func (s *StatusService) WatchNodeEvents(request *Empty, stream StatusService_WatchNodeEventsServer) error {
+	log.WithFields(util.StandardFieldsGRPC).Info("Request For Watch Node Events")
+	events, cancel := core.Subscribe("node.")
+	defer cancel()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case e := <-events:
+			event := &NodeEvent{Type: e.Type, Time: e.Time.UnixMilli(), Data: e.Data}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.2.0
-// - protoc             v3.21.12
+// - protoc             v4.25.1
 // source: gRPC/v1/status/status.proto
 
 package status
@@ -24,6 +24,7 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type StatusServiceClient interface {
 	GetStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*model.Status, error)
+	WatchNodeEvents(ctx context.Context, in *Empty, opts ...grpc.CallOption) (StatusService_WatchNodeEventsClient, error)
 }
 
 type statusServiceClient struct {
@@ -43,11 +44,44 @@ func (c *statusServiceClient) GetStatus(ctx context.Context, in *Empty, opts ...
 	return out, nil
 }
 
+func (c *statusServiceClient) WatchNodeEvents(ctx context.Context, in *Empty, opts ...grpc.CallOption) (StatusService_WatchNodeEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StatusService_ServiceDesc.Streams[0], "/status.StatusService/WatchNodeEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &statusServiceWatchNodeEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StatusService_WatchNodeEventsClient interface {
+	Recv() (*NodeEvent, error)
+	grpc.ClientStream
+}
+
+type statusServiceWatchNodeEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *statusServiceWatchNodeEventsClient) Recv() (*NodeEvent, error) {
+	m := new(NodeEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // StatusServiceServer is the server API for StatusService service.
 // All implementations must embed UnimplementedStatusServiceServer
 // for forward compatibility
 type StatusServiceServer interface {
 	GetStatus(context.Context, *Empty) (*model.Status, error)
+	WatchNodeEvents(*Empty, StatusService_WatchNodeEventsServer) error
 	mustEmbedUnimplementedStatusServiceServer()
 }
 
@@ -58,6 +92,9 @@ type UnimplementedStatusServiceServer struct {
 func (UnimplementedStatusServiceServer) GetStatus(context.Context, *Empty) (*model.Status, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
 }
+func (UnimplementedStatusServiceServer) WatchNodeEvents(*Empty, StatusService_WatchNodeEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchNodeEvents not implemented")
+}
 func (UnimplementedStatusServiceServer) mustEmbedUnimplementedStatusServiceServer() {}
 
 // UnsafeStatusServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -89,6 +126,27 @@ func _StatusService_GetStatus_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StatusService_WatchNodeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StatusServiceServer).WatchNodeEvents(m, &statusServiceWatchNodeEventsServer{stream})
+}
+
+type StatusService_WatchNodeEventsServer interface {
+	Send(*NodeEvent) error
+	grpc.ServerStream
+}
+
+type statusServiceWatchNodeEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *statusServiceWatchNodeEventsServer) Send(m *NodeEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // StatusService_ServiceDesc is the grpc.ServiceDesc for StatusService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -101,6 +159,12 @@ var StatusService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _StatusService_GetStatus_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchNodeEvents",
+			Handler:       _StatusService_WatchNodeEvents_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "gRPC/v1/status/status.proto",
 }
@@ -1,10 +1,13 @@
 package v1
 
 import (
+	"github.com/NetSepio/nexus/gRPC/v1/agent"
 	"github.com/NetSepio/nexus/gRPC/v1/authenticate/paseto"
+	"github.com/NetSepio/nexus/gRPC/v1/authenticate/scope"
 	"github.com/NetSepio/nexus/gRPC/v1/authenticate/selector"
 	"github.com/NetSepio/nexus/gRPC/v1/client"
 	"github.com/NetSepio/nexus/gRPC/v1/server"
+	"github.com/NetSepio/nexus/gRPC/v1/service"
 	"github.com/NetSepio/nexus/gRPC/v1/status"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/auth"
 	selector_middleware "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/selector"
@@ -17,17 +20,25 @@ func Initialize() *grpc.Server {
 	ServerService := &server.ServerService{}
 	ClientService := &client.ClientService{}
 	StatusService := &status.StatusService{}
+	ServiceService := &service.ServiceService{}
+	AgentService := &agent.AgentService{}
 
 	//creating a new gRPC server
 	grpc_server := grpc.NewServer(
 		grpc.ChainStreamInterceptor(selector_middleware.StreamServerInterceptor(
-			auth.StreamServerInterceptor(paseto.PASETO), selector_middleware.MatchFunc(selector.LoginSkip))),
+			auth.StreamServerInterceptor(paseto.PASETO), selector_middleware.MatchFunc(selector.AuthRequired)),
+			selector_middleware.StreamServerInterceptor(
+				scope.StreamServerInterceptor(), selector_middleware.MatchFunc(selector.AuthRequired))),
 		grpc.ChainUnaryInterceptor(selector_middleware.UnaryServerInterceptor(
-			auth.UnaryServerInterceptor(paseto.PASETO), selector_middleware.MatchFunc(selector.LoginSkip))),
+			auth.UnaryServerInterceptor(paseto.PASETO), selector_middleware.MatchFunc(selector.AuthRequired)),
+			selector_middleware.UnaryServerInterceptor(
+				scope.UnaryServerInterceptor(), selector_middleware.MatchFunc(selector.AuthRequired))),
 	)
 	server.RegisterServerServiceServer(grpc_server, ServerService)
 	client.RegisterClientServiceServer(grpc_server, ClientService)
 	status.RegisterStatusServiceServer(grpc_server, StatusService)
+	service.RegisterServiceServiceServer(grpc_server, ServiceService)
+	agent.RegisterAgentServiceServer(grpc_server, AgentService)
 
 	return grpc_server
 }
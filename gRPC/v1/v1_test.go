@@ -0,0 +1,163 @@
+package v1
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NetSepio/nexus/gRPC/v1/server"
+	"github.com/NetSepio/nexus/gRPC/v1/status"
+	"github.com/NetSepio/nexus/model"
+	"github.com/NetSepio/nexus/storage"
+	"github.com/NetSepio/nexus/util/pkg/auth"
+	"github.com/NetSepio/nexus/util/pkg/claims"
+	"github.com/NetSepio/nexus/util/pkg/policy"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	operatorWallet = "0x00000000000000000000000000000000000000a1"
+	tenantWallet   = "0x00000000000000000000000000000000000000b2"
+)
+
+// dialTestServer serves Initialize over bufconn with a file store and a node key in a temporary
+// directory
+func dialTestServer(t *testing.T) *grpc.ClientConn {
+	listener := bufconn.Listen(1 << 20)
+	serveTestServer(t, listener)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// serveTestServer serves Initialize on listener with a file store and a node key in a temporary
+// directory
+func serveTestServer(t *testing.T, listener net.Listener) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("WG_CONF_DIR", dir)
+	t.Setenv("WG_CLIENTS_DIR", filepath.Join(dir, "clients"))
+	t.Setenv("WG_INTERFACE_NAME", "nexustest0.conf")
+	t.Setenv("PASETO_KEY_SOURCE", auth.KeySourceFile)
+	t.Setenv("PASETO_KEY_FILE", filepath.Join(dir, "paseto.key"))
+	t.Setenv("GATEWAY_PASETO_PUBLIC_KEYS", "")
+	t.Setenv("GATEWAY_WALLET", "")
+	t.Setenv("OPERATOR_WALLETS", operatorWallet)
+	// the status call of the node must not leave the sandbox
+	t.Setenv("HTTPS_PROXY", "http://127.0.0.1:1")
+	if err := os.MkdirAll(filepath.Join(dir, "clients"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := auth.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	previous := storage.Get()
+	store := storage.NewFileStore()
+	storage.Set(store)
+	t.Cleanup(func() { storage.Set(previous) })
+	if err := store.WriteServer(&model.Server{Address: []string{"10.0.0.1/24"}, PublicKey: "pub", PrivateKey: "priv", NextPrivateKey: "next"}); err != nil {
+		t.Fatal(err)
+	}
+
+	s := Initialize()
+	go s.Serve(listener)
+	t.Cleanup(s.Stop)
+}
+
+func token(t *testing.T, wallet string) string {
+	roles := []string{policy.RoleFor(wallet)}
+	signed, err := auth.GenerateTokenPaseto(claims.CustomClaims{WalletAddress: wallet, Roles: roles, Scopes: policy.ScopesFor(roles)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func withToken(ctx context.Context, value string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", value)
+}
+
+func TestInterceptors(t *testing.T) {
+	conn := dialTestServer(t)
+	servers := server.NewServerServiceClient(conn)
+
+	for _, tc := range []struct {
+		name string
+		ctx  context.Context
+		code codes.Code
+	}{
+		{"missing token", context.Background(), codes.Unauthenticated},
+		{"empty token", withToken(context.Background(), ""), codes.Unauthenticated},
+		{"invalid token", withToken(context.Background(), "Bearer v4.public.invalid"), codes.Unauthenticated},
+		{"missing scope", withToken(context.Background(), "Bearer "+token(t, tenantWallet)), codes.PermissionDenied},
+		{"admin", withToken(context.Background(), "Bearer "+token(t, operatorWallet)), codes.OK},
+		{"admin without the bearer scheme", withToken(context.Background(), token(t, operatorWallet)), codes.OK},
+	} {
+		_, err := servers.GetServerInformation(tc.ctx, &server.Empty{})
+		if code := grpcstatus.Code(err); code != tc.code {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.code, err)
+		}
+	}
+
+	// methods that only need a valid token
+	if _, err := servers.GetPools(withToken(context.Background(), "Bearer "+token(t, tenantWallet)), &server.Empty{}); err != nil {
+		t.Errorf("expected pools readable by tenants, got %v", err)
+	}
+}
+
+func TestStreamInterceptors(t *testing.T) {
+	conn := dialTestServer(t)
+	statuses := status.NewStatusServiceClient(conn)
+
+	for _, tc := range []struct {
+		name  string
+		token string
+		code  codes.Code
+	}{
+		{"missing token", "", codes.Unauthenticated},
+		{"missing scope", "Bearer " + token(t, tenantWallet), codes.PermissionDenied},
+		// the stream is open and waits for events
+		{"readonly scope", "Bearer " + token(t, operatorWallet), codes.DeadlineExceeded},
+	} {
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		if tc.token != "" {
+			ctx = withToken(ctx, tc.token)
+		}
+		stream, err := statuses.WatchNodeEvents(ctx, &status.Empty{})
+		if err == nil {
+			_, err = stream.Recv()
+		}
+		if code := grpcstatus.Code(err); code != tc.code {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.code, err)
+		}
+		cancel()
+	}
+}
+
+func TestExemptMethods(t *testing.T) {
+	conn := dialTestServer(t)
+	statuses := status.NewStatusServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// the status of the node is public, the call fails past the interceptors without network
+	_, err := statuses.GetStatus(ctx, &status.Empty{})
+	if code := grpcstatus.Code(err); code == codes.Unauthenticated || code == codes.PermissionDenied {
+		t.Errorf("expected GetStatus callable without a token, got %v", err)
+	}
+}
@@ -1,17 +1,22 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/NetSepio/nexus/api"
+	"github.com/NetSepio/nexus/api/v1/authenticate/challengeid"
+	"github.com/NetSepio/nexus/api/v1/middleware"
 	"github.com/NetSepio/nexus/core"
 	grpc "github.com/NetSepio/nexus/gRPC"
 	"github.com/NetSepio/nexus/p2p"
+	"github.com/NetSepio/nexus/storage"
 	"github.com/NetSepio/nexus/util"
 	"github.com/NetSepio/nexus/util/pkg/auth"
 	"github.com/NetSepio/nexus/util/pkg/node"
@@ -56,11 +61,31 @@ func init() {
 
 	core.GetIPInfo()
 
-	auth.Init()
+	if err := auth.Init(); err != nil {
+		log.WithFields(util.StandardFields).Fatalf("Error in loading the paseto key: %v", err)
+	}
+	if err := challengeid.Init(); err != nil {
+		log.WithFields(util.StandardFields).Fatalf("Error in selecting the challenge store: %v", err)
+	}
 	// agents.EnsureDockerAndCaddy()
 
 }
 
+// trustedProxies returns the comma separated TRUSTED_PROXIES, defaults to the loopback addresses
+// caddy forwards from
+func trustedProxies() []string {
+	proxies := make([]string, 0)
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	if len(proxies) == 0 {
+		return []string{"127.0.0.1", "::1"}
+	}
+	return proxies
+}
+
 func RungRPCServer() {
 	grpc_server := grpc.Initialize()
 
@@ -116,12 +141,18 @@ func main() {
 		}
 	}
 
-	// check if server.json exists otherwise create it with default values
-	if !util.FileExists(filepath.Join(os.Getenv("WG_CONF_DIR"), "server.json")) {
-		_, err := core.ReadServer()
-		if err != nil {
-			log.WithFields(util.StandardFields).Fatal("server.json does not exist and unable to open")
-		}
+	// open the state store selected by STORAGE_BACKEND
+	err := storage.Init()
+	util.CheckError("Error while opening storage: ", err)
+
+	// drop leftovers of interrupted writes and quarantine unreadable records
+	err = storage.Get().Check()
+	util.CheckError("Error while checking storage: ", err)
+
+	// read the server otherwise create it with default values
+	_, err = core.ReadServer()
+	if err != nil {
+		log.WithFields(util.StandardFields).Fatal("server does not exist and unable to create")
 	}
 
 	if os.Getenv("RUNTYPE") == "debug" {
@@ -137,10 +168,27 @@ func main() {
 	}
 
 	// dump wg config file
-	err := core.UpdateServerConfigWg()
+	err = core.UpdateServerConfigWg()
 	util.CheckError("Error while creating WireGuard config file: ", err)
 	// Call the function to generate the wallet address and store it in the global variable
 
+	// account client usage and enforce quotas and expiry
+	accountingInterval, err := time.ParseDuration(os.Getenv("ACCOUNTING_INTERVAL"))
+	if err != nil || accountingInterval <= 0 {
+		accountingInterval = time.Minute
+	}
+	core.StartAccountant(accountingInterval)
+
+	// check the upstreams of the services behind caddy
+	healthInterval, err := time.ParseDuration(os.Getenv("HEALTH_CHECK_INTERVAL"))
+	if err != nil || healthInterval <= 0 {
+		healthInterval = 30 * time.Second
+	}
+	middleware.StartHealthChecker(healthInterval)
+
+	// switch to staged keys once their overlap window elapsed
+	core.StartKeyRotation(10 * time.Second)
+
 	core.LoadNodeDetails()
 
 	// Register node on Peaq  or Monad if configured
@@ -162,6 +210,9 @@ func main() {
 	if os.Getenv("HTTP_PORT") != "" {
 		// creates a gin router with default middleware: logger and recovery (crash-free) middleware
 		ginApp := gin.Default()
+		// only these proxies may forward the client ip, login challenges are rate limited per client ip
+		err = ginApp.SetTrustedProxies(trustedProxies())
+		util.CheckError("Invalid TRUSTED_PROXIES: ", err)
 		// cors middleware
 		config := cors.DefaultConfig()
 		config.AllowOrigins = []string{os.Getenv("GATEWAY_DOMAIN")}
@@ -190,6 +241,13 @@ func main() {
 
 		// Apply API Routes
 		api.ApplyRoutes(ginApp)
+
+		// REST gateway of the gRPC services
+		if os.Getenv("GRPC_PORT") != "" {
+			gateway, err := grpc.Gateway(context.Background(), "localhost:"+os.Getenv("GRPC_PORT"))
+			util.CheckError("Failed to create REST gateway: ", err)
+			ginApp.Any("/api/v2/*path", gin.WrapH(gateway))
+		}
 		err = ginApp.Run(fmt.Sprintf("%s:%s", os.Getenv("SERVER"), os.Getenv("HTTP_PORT")))
 		util.CheckError("Failed to Start HTTP Server: ", err)
 	}
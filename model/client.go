@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/NetSepio/nexus/util"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 )
 
 // Client structure
@@ -53,16 +54,37 @@ func (a Client) IsValid() []error {
 			errs = append(errs, fmt.Errorf("allowedIP %s is invalid", allowedIP))
 		}
 	}
-	// check if the address empty
-	if len(a.Address) == 0 {
-		errs = append(errs, fmt.Errorf("address field is required"))
-	}
+	// address is optional, the server assigns one address per network when empty
 	// check if the address are valid
 	for _, address := range a.Address {
 		if !util.IsValidCidr(address) {
 			errs = append(errs, fmt.Errorf("address %s is invalid", address))
 		}
 	}
+	// publicKey is optional, the node generates a keypair when empty
+	if a.PublicKey != "" {
+		if _, err := wgtypes.ParseKey(a.PublicKey); err != nil {
+			errs = append(errs, fmt.Errorf("publicKey %s is invalid", a.PublicKey))
+		}
+	}
+	// quotas are in bytes, zero means unlimited
+	if a.DailyQuota < 0 {
+		errs = append(errs, fmt.Errorf("dailyQuota %d is invalid", a.DailyQuota))
+	}
+	if a.MonthlyQuota < 0 {
+		errs = append(errs, fmt.Errorf("monthlyQuota %d is invalid", a.MonthlyQuota))
+	}
+	// rates are in kbit/s, zero means unlimited
+	if a.UploadRate < 0 {
+		errs = append(errs, fmt.Errorf("uploadRate %d is invalid", a.UploadRate))
+	}
+	if a.DownloadRate < 0 {
+		errs = append(errs, fmt.Errorf("downloadRate %d is invalid", a.DownloadRate))
+	}
+	// expiresAt is in unix milliseconds, zero means never
+	if a.ExpiresAt < 0 {
+		errs = append(errs, fmt.Errorf("expiresAt %d is invalid", a.ExpiresAt))
+	}
 
 	return errs
 }
@@ -120,22 +120,44 @@ type Client struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UUID                      string   `protobuf:"bytes,1,opt,name=UUID,proto3" json:"UUID,omitempty"`
-	Name                      string   `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
-	Tags                      []string `protobuf:"bytes,3,rep,name=Tags,proto3" json:"Tags,omitempty"`
-	WalletAddress             string   `protobuf:"bytes,4,opt,name=WalletAddress,proto3" json:"WalletAddress,omitempty"`
-	Enable                    bool     `protobuf:"varint,5,opt,name=Enable,proto3" json:"Enable,omitempty"`
-	IgnorePersistentKeepalive bool     `protobuf:"varint,6,opt,name=IgnorePersistentKeepalive,proto3" json:"IgnorePersistentKeepalive,omitempty"`
-	PublicKey                 string   `protobuf:"bytes,7,opt,name=PublicKey,proto3" json:"PublicKey,omitempty"`
-	PresharedKey              string   `protobuf:"bytes,8,opt,name=PresharedKey,proto3" json:"PresharedKey,omitempty"`
-	AllowedIPs                []string `protobuf:"bytes,9,rep,name=AllowedIPs,proto3" json:"AllowedIPs,omitempty"`
-	Address                   []string `protobuf:"bytes,10,rep,name=Address,proto3" json:"Address,omitempty"`
-	CreatedBy                 string   `protobuf:"bytes,11,opt,name=CreatedBy,proto3" json:"CreatedBy,omitempty"`
-	UpdatedBy                 string   `protobuf:"bytes,12,opt,name=UpdatedBy,proto3" json:"UpdatedBy,omitempty"`
-	CreatedAt                 int64    `protobuf:"varint,13,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
-	UpdatedAt                 int64    `protobuf:"varint,14,opt,name=UpdatedAt,proto3" json:"UpdatedAt,omitempty"`
-	ReceiveBytes              int64    `protobuf:"varint,15,opt,name=ReceiveBytes,proto3" json:"ReceiveBytes"`
-	TransmitBytes             int64    `protobuf:"varint,16,opt,name=TransmitBytes,proto3" json:"TransmitBytes"`
+	UUID                      string         `protobuf:"bytes,1,opt,name=UUID,proto3" json:"UUID,omitempty"`
+	Name                      string         `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	Tags                      []string       `protobuf:"bytes,3,rep,name=Tags,proto3" json:"Tags,omitempty"`
+	WalletAddress             string         `protobuf:"bytes,4,opt,name=WalletAddress,proto3" json:"WalletAddress,omitempty"`
+	Enable                    bool           `protobuf:"varint,5,opt,name=Enable,proto3" json:"Enable,omitempty"`
+	IgnorePersistentKeepalive bool           `protobuf:"varint,6,opt,name=IgnorePersistentKeepalive,proto3" json:"IgnorePersistentKeepalive,omitempty"`
+	PublicKey                 string         `protobuf:"bytes,7,opt,name=PublicKey,proto3" json:"PublicKey,omitempty"`
+	PresharedKey              string         `protobuf:"bytes,8,opt,name=PresharedKey,proto3" json:"PresharedKey,omitempty"`
+	AllowedIPs                []string       `protobuf:"bytes,9,rep,name=AllowedIPs,proto3" json:"AllowedIPs,omitempty"`
+	Address                   []string       `protobuf:"bytes,10,rep,name=Address,proto3" json:"Address,omitempty"`
+	CreatedBy                 string         `protobuf:"bytes,11,opt,name=CreatedBy,proto3" json:"CreatedBy,omitempty"`
+	UpdatedBy                 string         `protobuf:"bytes,12,opt,name=UpdatedBy,proto3" json:"UpdatedBy,omitempty"`
+	CreatedAt                 int64          `protobuf:"varint,13,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	UpdatedAt                 int64          `protobuf:"varint,14,opt,name=UpdatedAt,proto3" json:"UpdatedAt,omitempty"`
+	ReceiveBytes              int64          `protobuf:"varint,15,opt,name=ReceiveBytes,proto3" json:"ReceiveBytes"`
+	TransmitBytes             int64          `protobuf:"varint,16,opt,name=TransmitBytes,proto3" json:"TransmitBytes"`
+	LastHandshake             int64          `protobuf:"varint,17,opt,name=LastHandshake,proto3" json:"LastHandshake,omitempty"`
+	Endpoint                  string         `protobuf:"bytes,18,opt,name=Endpoint,proto3" json:"Endpoint,omitempty"`
+	Online                    bool           `protobuf:"varint,19,opt,name=Online,proto3" json:"Online"`
+	DailyQuota                int64          `protobuf:"varint,20,opt,name=DailyQuota,proto3" json:"DailyQuota,omitempty"`
+	MonthlyQuota              int64          `protobuf:"varint,21,opt,name=MonthlyQuota,proto3" json:"MonthlyQuota,omitempty"`
+	TopUpBytes                int64          `protobuf:"varint,22,opt,name=TopUpBytes,proto3" json:"TopUpBytes,omitempty"`
+	ExpiresAt                 int64          `protobuf:"varint,23,opt,name=ExpiresAt,proto3" json:"ExpiresAt,omitempty"`
+	DailyUsage                int64          `protobuf:"varint,24,opt,name=DailyUsage,proto3" json:"DailyUsage"`
+	MonthlyUsage              int64          `protobuf:"varint,25,opt,name=MonthlyUsage,proto3" json:"MonthlyUsage"`
+	TotalUsage                int64          `protobuf:"varint,26,opt,name=TotalUsage,proto3" json:"TotalUsage"`
+	UsageUpdatedAt            int64          `protobuf:"varint,27,opt,name=UsageUpdatedAt,proto3" json:"UsageUpdatedAt,omitempty"`
+	CounterReceiveBytes       int64          `protobuf:"varint,28,opt,name=CounterReceiveBytes,proto3" json:"CounterReceiveBytes,omitempty"`
+	CounterTransmitBytes      int64          `protobuf:"varint,29,opt,name=CounterTransmitBytes,proto3" json:"CounterTransmitBytes,omitempty"`
+	DisabledReason            string         `protobuf:"bytes,30,opt,name=DisabledReason,proto3" json:"DisabledReason,omitempty"`
+	UploadRate                int64          `protobuf:"varint,31,opt,name=UploadRate,proto3" json:"UploadRate,omitempty"`
+	DownloadRate              int64          `protobuf:"varint,32,opt,name=DownloadRate,proto3" json:"DownloadRate,omitempty"`
+	Pool                      string         `protobuf:"bytes,33,opt,name=Pool,proto3" json:"Pool,omitempty"`
+	PrivateKey                string         `protobuf:"bytes,34,opt,name=PrivateKey,proto3" json:"PrivateKey,omitempty"`
+	NextPublicKey             string         `protobuf:"bytes,35,opt,name=NextPublicKey,proto3" json:"NextPublicKey,omitempty"`
+	NextPresharedKey          string         `protobuf:"bytes,36,opt,name=NextPresharedKey,proto3" json:"NextPresharedKey,omitempty"`
+	RotateAt                  int64          `protobuf:"varint,37,opt,name=RotateAt,proto3" json:"RotateAt,omitempty"`
+	KeyHistory                []*KeyRotation `protobuf:"bytes,38,rep,name=KeyHistory,proto3" json:"KeyHistory,omitempty"`
 }
 
 func (x *Client) Reset() {
@@ -282,27 +304,186 @@ func (x *Client) GetTransmitBytes() int64 {
 	return 0
 }
 
+func (x *Client) GetLastHandshake() int64 {
+	if x != nil {
+		return x.LastHandshake
+	}
+	return 0
+}
+
+func (x *Client) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *Client) GetOnline() bool {
+	if x != nil {
+		return x.Online
+	}
+	return false
+}
+
+func (x *Client) GetDailyQuota() int64 {
+	if x != nil {
+		return x.DailyQuota
+	}
+	return 0
+}
+
+func (x *Client) GetMonthlyQuota() int64 {
+	if x != nil {
+		return x.MonthlyQuota
+	}
+	return 0
+}
+
+func (x *Client) GetTopUpBytes() int64 {
+	if x != nil {
+		return x.TopUpBytes
+	}
+	return 0
+}
+
+func (x *Client) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *Client) GetDailyUsage() int64 {
+	if x != nil {
+		return x.DailyUsage
+	}
+	return 0
+}
+
+func (x *Client) GetMonthlyUsage() int64 {
+	if x != nil {
+		return x.MonthlyUsage
+	}
+	return 0
+}
+
+func (x *Client) GetTotalUsage() int64 {
+	if x != nil {
+		return x.TotalUsage
+	}
+	return 0
+}
+
+func (x *Client) GetUsageUpdatedAt() int64 {
+	if x != nil {
+		return x.UsageUpdatedAt
+	}
+	return 0
+}
+
+func (x *Client) GetCounterReceiveBytes() int64 {
+	if x != nil {
+		return x.CounterReceiveBytes
+	}
+	return 0
+}
+
+func (x *Client) GetCounterTransmitBytes() int64 {
+	if x != nil {
+		return x.CounterTransmitBytes
+	}
+	return 0
+}
+
+func (x *Client) GetDisabledReason() string {
+	if x != nil {
+		return x.DisabledReason
+	}
+	return ""
+}
+
+func (x *Client) GetUploadRate() int64 {
+	if x != nil {
+		return x.UploadRate
+	}
+	return 0
+}
+
+func (x *Client) GetDownloadRate() int64 {
+	if x != nil {
+		return x.DownloadRate
+	}
+	return 0
+}
+
+func (x *Client) GetPool() string {
+	if x != nil {
+		return x.Pool
+	}
+	return ""
+}
+
+func (x *Client) GetPrivateKey() string {
+	if x != nil {
+		return x.PrivateKey
+	}
+	return ""
+}
+
+func (x *Client) GetNextPublicKey() string {
+	if x != nil {
+		return x.NextPublicKey
+	}
+	return ""
+}
+
+func (x *Client) GetNextPresharedKey() string {
+	if x != nil {
+		return x.NextPresharedKey
+	}
+	return ""
+}
+
+func (x *Client) GetRotateAt() int64 {
+	if x != nil {
+		return x.RotateAt
+	}
+	return 0
+}
+
+func (x *Client) GetKeyHistory() []*KeyRotation {
+	if x != nil {
+		return x.KeyHistory
+	}
+	return nil
+}
+
 type Server struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Address             []string `protobuf:"bytes,1,rep,name=Address,proto3" json:"Address,omitempty"`
-	ListenPort          int64    `protobuf:"varint,2,opt,name=ListenPort,proto3" json:"ListenPort,omitempty"`
-	Mtu                 int64    `protobuf:"varint,3,opt,name=Mtu,proto3" json:"Mtu,omitempty"`
-	PrivateKey          string   `protobuf:"bytes,4,opt,name=PrivateKey,proto3" json:"PrivateKey,omitempty"`
-	PublicKey           string   `protobuf:"bytes,5,opt,name=PublicKey,proto3" json:"PublicKey,omitempty"`
-	Endpoint            string   `protobuf:"bytes,6,opt,name=Endpoint,proto3" json:"Endpoint,omitempty"`
-	PersistentKeepalive int64    `protobuf:"varint,7,opt,name=PersistentKeepalive,proto3" json:"PersistentKeepalive,omitempty"`
-	DNS                 []string `protobuf:"bytes,8,rep,name=DNS,proto3" json:"DNS,omitempty"`
-	AllowedIPs          []string `protobuf:"bytes,9,rep,name=AllowedIPs,proto3" json:"AllowedIPs,omitempty"`
-	PreUp               string   `protobuf:"bytes,10,opt,name=PreUp,proto3" json:"PreUp,omitempty"`
-	PostUp              string   `protobuf:"bytes,11,opt,name=PostUp,proto3" json:"PostUp,omitempty"`
-	PreDown             string   `protobuf:"bytes,12,opt,name=PreDown,proto3" json:"PreDown,omitempty"`
-	PostDown            string   `protobuf:"bytes,13,opt,name=PostDown,proto3" json:"PostDown,omitempty"`
-	UpdatedBy           string   `protobuf:"bytes,14,opt,name=UpdatedBy,proto3" json:"UpdatedBy,omitempty"`
-	CreatedAt           int64    `protobuf:"varint,15,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
-	UpdatedAt           int64    `protobuf:"varint,16,opt,name=UpdatedAt,proto3" json:"UpdatedAt,omitempty"`
+	Address             []string       `protobuf:"bytes,1,rep,name=Address,proto3" json:"Address,omitempty"`
+	ListenPort          int64          `protobuf:"varint,2,opt,name=ListenPort,proto3" json:"ListenPort,omitempty"`
+	Mtu                 int64          `protobuf:"varint,3,opt,name=Mtu,proto3" json:"Mtu,omitempty"`
+	PrivateKey          string         `protobuf:"bytes,4,opt,name=PrivateKey,proto3" json:"PrivateKey,omitempty"`
+	PublicKey           string         `protobuf:"bytes,5,opt,name=PublicKey,proto3" json:"PublicKey,omitempty"`
+	Endpoint            string         `protobuf:"bytes,6,opt,name=Endpoint,proto3" json:"Endpoint,omitempty"`
+	PersistentKeepalive int64          `protobuf:"varint,7,opt,name=PersistentKeepalive,proto3" json:"PersistentKeepalive,omitempty"`
+	DNS                 []string       `protobuf:"bytes,8,rep,name=DNS,proto3" json:"DNS,omitempty"`
+	AllowedIPs          []string       `protobuf:"bytes,9,rep,name=AllowedIPs,proto3" json:"AllowedIPs,omitempty"`
+	PreUp               string         `protobuf:"bytes,10,opt,name=PreUp,proto3" json:"PreUp,omitempty"`
+	PostUp              string         `protobuf:"bytes,11,opt,name=PostUp,proto3" json:"PostUp,omitempty"`
+	PreDown             string         `protobuf:"bytes,12,opt,name=PreDown,proto3" json:"PreDown,omitempty"`
+	PostDown            string         `protobuf:"bytes,13,opt,name=PostDown,proto3" json:"PostDown,omitempty"`
+	UpdatedBy           string         `protobuf:"bytes,14,opt,name=UpdatedBy,proto3" json:"UpdatedBy,omitempty"`
+	CreatedAt           int64          `protobuf:"varint,15,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	UpdatedAt           int64          `protobuf:"varint,16,opt,name=UpdatedAt,proto3" json:"UpdatedAt,omitempty"`
+	Pools               []*Pool        `protobuf:"bytes,17,rep,name=Pools,proto3" json:"Pools,omitempty"`
+	NextPrivateKey      string         `protobuf:"bytes,18,opt,name=NextPrivateKey,proto3" json:"NextPrivateKey,omitempty"`
+	NextPublicKey       string         `protobuf:"bytes,19,opt,name=NextPublicKey,proto3" json:"NextPublicKey,omitempty"`
+	RotateAt            int64          `protobuf:"varint,20,opt,name=RotateAt,proto3" json:"RotateAt,omitempty"`
+	KeyHistory          []*KeyRotation `protobuf:"bytes,21,rep,name=KeyHistory,proto3" json:"KeyHistory,omitempty"`
 }
 
 func (x *Server) Reset() {
@@ -449,6 +630,286 @@ func (x *Server) GetUpdatedAt() int64 {
 	return 0
 }
 
+func (x *Server) GetPools() []*Pool {
+	if x != nil {
+		return x.Pools
+	}
+	return nil
+}
+
+func (x *Server) GetNextPrivateKey() string {
+	if x != nil {
+		return x.NextPrivateKey
+	}
+	return ""
+}
+
+func (x *Server) GetNextPublicKey() string {
+	if x != nil {
+		return x.NextPublicKey
+	}
+	return ""
+}
+
+func (x *Server) GetRotateAt() int64 {
+	if x != nil {
+		return x.RotateAt
+	}
+	return 0
+}
+
+func (x *Server) GetKeyHistory() []*KeyRotation {
+	if x != nil {
+		return x.KeyHistory
+	}
+	return nil
+}
+
+type KeyRotation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind              string `protobuf:"bytes,1,opt,name=Kind,proto3" json:"Kind,omitempty"`
+	PublicKey         string `protobuf:"bytes,2,opt,name=PublicKey,proto3" json:"PublicKey,omitempty"`
+	PreviousPublicKey string `protobuf:"bytes,3,opt,name=PreviousPublicKey,proto3" json:"PreviousPublicKey,omitempty"`
+	RequestedAt       int64  `protobuf:"varint,4,opt,name=RequestedAt,proto3" json:"RequestedAt,omitempty"`
+	RotatedAt         int64  `protobuf:"varint,5,opt,name=RotatedAt,proto3" json:"RotatedAt,omitempty"`
+	RotatedBy         string `protobuf:"bytes,6,opt,name=RotatedBy,proto3" json:"RotatedBy,omitempty"`
+}
+
+func (x *KeyRotation) Reset() {
+	*x = KeyRotation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_model_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyRotation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyRotation) ProtoMessage() {}
+
+func (x *KeyRotation) ProtoReflect() protoreflect.Message {
+	mi := &file_model_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyRotation.ProtoReflect.Descriptor instead.
+func (*KeyRotation) Descriptor() ([]byte, []int) {
+	return file_model_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *KeyRotation) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *KeyRotation) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
+func (x *KeyRotation) GetPreviousPublicKey() string {
+	if x != nil {
+		return x.PreviousPublicKey
+	}
+	return ""
+}
+
+func (x *KeyRotation) GetRequestedAt() int64 {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return 0
+}
+
+func (x *KeyRotation) GetRotatedAt() int64 {
+	if x != nil {
+		return x.RotatedAt
+	}
+	return 0
+}
+
+func (x *KeyRotation) GetRotatedBy() string {
+	if x != nil {
+		return x.RotatedBy
+	}
+	return ""
+}
+
+type Pool struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string   `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Address []string `protobuf:"bytes,2,rep,name=Address,proto3" json:"Address,omitempty"`
+	Exclude []string `protobuf:"bytes,3,rep,name=Exclude,proto3" json:"Exclude,omitempty"`
+	Tags    []string `protobuf:"bytes,4,rep,name=Tags,proto3" json:"Tags,omitempty"`
+}
+
+func (x *Pool) Reset() {
+	*x = Pool{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_model_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Pool) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pool) ProtoMessage() {}
+
+func (x *Pool) ProtoReflect() protoreflect.Message {
+	mi := &file_model_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pool.ProtoReflect.Descriptor instead.
+func (*Pool) Descriptor() ([]byte, []int) {
+	return file_model_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Pool) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Pool) GetAddress() []string {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *Pool) GetExclude() []string {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
+func (x *Pool) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type PoolUsage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name       string   `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Address    string   `protobuf:"bytes,2,opt,name=Address,proto3" json:"Address,omitempty"`
+	Size       string   `protobuf:"bytes,3,opt,name=Size,proto3" json:"Size,omitempty"`
+	Used       string   `protobuf:"bytes,4,opt,name=Used,proto3" json:"Used,omitempty"`
+	Free       string   `protobuf:"bytes,5,opt,name=Free,proto3" json:"Free,omitempty"`
+	FreeRanges []string `protobuf:"bytes,6,rep,name=FreeRanges,proto3" json:"FreeRanges,omitempty"`
+}
+
+func (x *PoolUsage) Reset() {
+	*x = PoolUsage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_model_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PoolUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolUsage) ProtoMessage() {}
+
+func (x *PoolUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_model_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolUsage.ProtoReflect.Descriptor instead.
+func (*PoolUsage) Descriptor() ([]byte, []int) {
+	return file_model_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PoolUsage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PoolUsage) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *PoolUsage) GetSize() string {
+	if x != nil {
+		return x.Size
+	}
+	return ""
+}
+
+func (x *PoolUsage) GetUsed() string {
+	if x != nil {
+		return x.Used
+	}
+	return ""
+}
+
+func (x *PoolUsage) GetFree() string {
+	if x != nil {
+		return x.Free
+	}
+	return ""
+}
+
+func (x *PoolUsage) GetFreeRanges() []string {
+	if x != nil {
+		return x.FreeRanges
+	}
+	return nil
+}
+
 type Status struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -471,7 +932,7 @@ type Status struct {
 func (x *Status) Reset() {
 	*x = Status{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_model_proto_msgTypes[3]
+		mi := &file_model_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -484,7 +945,7 @@ func (x *Status) String() string {
 func (*Status) ProtoMessage() {}
 
 func (x *Status) ProtoReflect() protoreflect.Message {
-	mi := &file_model_proto_msgTypes[3]
+	mi := &file_model_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -497,7 +958,7 @@ func (x *Status) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Status.ProtoReflect.Descriptor instead.
 func (*Status) Descriptor() ([]byte, []int) {
-	return file_model_proto_rawDescGZIP(), []int{3}
+	return file_model_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Status) GetVersion() string {
@@ -602,7 +1063,7 @@ var file_model_proto_rawDesc = []byte{
 	0x65, 0x6c, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65,
 	0x72, 0x12, 0x27, 0x0a, 0x07, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03,
 	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e,
-	0x74, 0x52, 0x07, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x22, 0xfe, 0x03, 0x0a, 0x06, 0x43,
+	0x74, 0x52, 0x07, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x8e, 0x0a, 0x0a, 0x06, 0x43,
 	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x55, 0x55, 0x49, 0x44, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x09, 0x52, 0x04, 0x55, 0x55, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d,
 	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a,
@@ -634,7 +1095,56 @@ var file_model_proto_rawDesc = []byte{
 	0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
 	0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69,
 	0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x54, 0x72,
-	0x61, 0x6e, 0x73, 0x6d, 0x69, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0xd0, 0x03, 0x0a, 0x06,
+	0x61, 0x6e, 0x73, 0x6d, 0x69, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x4c,
+	0x61, 0x73, 0x74, 0x48, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x18, 0x11, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0d, 0x4c, 0x61, 0x73, 0x74, 0x48, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x12, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x4f,
+	0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x44, 0x61, 0x69, 0x6c, 0x79, 0x51, 0x75,
+	0x6f, 0x74, 0x61, 0x18, 0x14, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x44, 0x61, 0x69, 0x6c, 0x79,
+	0x51, 0x75, 0x6f, 0x74, 0x61, 0x12, 0x22, 0x0a, 0x0c, 0x4d, 0x6f, 0x6e, 0x74, 0x68, 0x6c, 0x79,
+	0x51, 0x75, 0x6f, 0x74, 0x61, 0x18, 0x15, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x4d, 0x6f, 0x6e,
+	0x74, 0x68, 0x6c, 0x79, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x12, 0x1e, 0x0a, 0x0a, 0x54, 0x6f, 0x70,
+	0x55, 0x70, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x16, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x54,
+	0x6f, 0x70, 0x55, 0x70, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x45, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x18, 0x17, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x45, 0x78,
+	0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x44, 0x61, 0x69, 0x6c, 0x79,
+	0x55, 0x73, 0x61, 0x67, 0x65, 0x18, 0x18, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x44, 0x61, 0x69,
+	0x6c, 0x79, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x4d, 0x6f, 0x6e, 0x74, 0x68,
+	0x6c, 0x79, 0x55, 0x73, 0x61, 0x67, 0x65, 0x18, 0x19, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x4d,
+	0x6f, 0x6e, 0x74, 0x68, 0x6c, 0x79, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x54,
+	0x6f, 0x74, 0x61, 0x6c, 0x55, 0x73, 0x61, 0x67, 0x65, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0a, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x55,
+	0x73, 0x61, 0x67, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x1b, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x12, 0x30, 0x0a, 0x13, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x52, 0x65,
+	0x63, 0x65, 0x69, 0x76, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x13, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x14, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x1d, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x14, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x6d, 0x69, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x44, 0x69, 0x73,
+	0x61, 0x62, 0x6c, 0x65, 0x64, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x1e, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x52, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x61, 0x74, 0x65, 0x18,
+	0x1f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x61, 0x74,
+	0x65, 0x12, 0x22, 0x0a, 0x0c, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x61, 0x74,
+	0x65, 0x18, 0x20, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61,
+	0x64, 0x52, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x6f, 0x6f, 0x6c, 0x18, 0x21, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x50, 0x72, 0x69,
+	0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x18, 0x22, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x50,
+	0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x24, 0x0a, 0x0d, 0x4e, 0x65, 0x78,
+	0x74, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x18, 0x23, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x4e, 0x65, 0x78, 0x74, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12,
+	0x2a, 0x0a, 0x10, 0x4e, 0x65, 0x78, 0x74, 0x50, 0x72, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64,
+	0x4b, 0x65, 0x79, 0x18, 0x24, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x4e, 0x65, 0x78, 0x74, 0x50,
+	0x72, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x52,
+	0x6f, 0x74, 0x61, 0x74, 0x65, 0x41, 0x74, 0x18, 0x25, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x52,
+	0x6f, 0x74, 0x61, 0x74, 0x65, 0x41, 0x74, 0x12, 0x32, 0x0a, 0x0a, 0x4b, 0x65, 0x79, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x26, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x6f,
+	0x64, 0x65, 0x6c, 0x2e, 0x4b, 0x65, 0x79, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0a, 0x4b, 0x65, 0x79, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x22, 0x91, 0x05, 0x0a, 0x06,
 	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
 	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
 	0x12, 0x1e, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x02,
@@ -663,32 +1173,73 @@ var file_model_proto_rawDesc = []byte{
 	0x42, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18,
 	0x0f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
 	0x12, 0x1c, 0x0a, 0x09, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x10, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x09, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0xdc,
-	0x02, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x56, 0x65, 0x72,
-	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x56, 0x65, 0x72, 0x73,
-	0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12,
-	0x16, 0x0a, 0x06, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x50, 0x75, 0x62, 0x6c, 0x69,
-	0x63, 0x49, 0x50, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x50, 0x75, 0x62, 0x6c, 0x69,
-	0x63, 0x49, 0x50, 0x12, 0x1a, 0x0a, 0x08, 0x67, 0x52, 0x50, 0x43, 0x50, 0x6f, 0x72, 0x74, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x52, 0x50, 0x43, 0x50, 0x6f, 0x72, 0x74, 0x12,
-	0x1c, 0x0a, 0x09, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x49, 0x50, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x49, 0x50, 0x12, 0x1a, 0x0a,
-	0x08, 0x48, 0x74, 0x74, 0x70, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x48, 0x74, 0x74, 0x70, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x65, 0x67,
-	0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x52, 0x65, 0x67, 0x69, 0x6f,
-	0x6e, 0x12, 0x18, 0x0a, 0x07, 0x56, 0x50, 0x4e, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x09, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x56, 0x50, 0x4e, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x50,
-	0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x30, 0x0a, 0x13, 0x50, 0x65, 0x72,
-	0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x4b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76, 0x65,
-	0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65,
-	0x6e, 0x74, 0x4b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x44,
-	0x4e, 0x53, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x44, 0x4e, 0x53, 0x42, 0x21, 0x5a,
-	0x1f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4e, 0x65, 0x74, 0x53,
-	0x65, 0x70, 0x69, 0x6f, 0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x3b, 0x6d, 0x6f, 0x64, 0x65, 0x6c,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x21,
+	0x0a, 0x05, 0x50, 0x6f, 0x6f, 0x6c, 0x73, 0x18, 0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x05, 0x50, 0x6f, 0x6f, 0x6c,
+	0x73, 0x12, 0x26, 0x0a, 0x0e, 0x4e, 0x65, 0x78, 0x74, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65,
+	0x4b, 0x65, 0x79, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x4e, 0x65, 0x78, 0x74, 0x50,
+	0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x24, 0x0a, 0x0d, 0x4e, 0x65, 0x78,
+	0x74, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x4e, 0x65, 0x78, 0x74, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12,
+	0x1a, 0x0a, 0x08, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x41, 0x74, 0x18, 0x14, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x41, 0x74, 0x12, 0x32, 0x0a, 0x0a, 0x4b,
+	0x65, 0x79, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x15, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x4b, 0x65, 0x79, 0x52, 0x6f, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x4b, 0x65, 0x79, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x22,
+	0xcb, 0x01, 0x0a, 0x0b, 0x4b, 0x65, 0x79, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x12, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4b,
+	0x69, 0x6e, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65,
+	0x79, 0x12, 0x2c, 0x0a, 0x11, 0x50, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x50, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x50, 0x72,
+	0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12,
+	0x20, 0x0a, 0x0b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12,
+	0x1c, 0x0a, 0x09, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x64, 0x42, 0x79, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x64, 0x42, 0x79, 0x22, 0x62, 0x0a,
+	0x04, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x54, 0x61, 0x67, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x54, 0x61, 0x67,
+	0x73, 0x22, 0x95, 0x01, 0x0a, 0x09, 0x50, 0x6f, 0x6f, 0x6c, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x53, 0x69, 0x7a,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x55, 0x73, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x55, 0x73, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x46, 0x72, 0x65, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x46, 0x72, 0x65, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x46, 0x72, 0x65,
+	0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x46,
+	0x72, 0x65, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0xdc, 0x02, 0x0a, 0x06, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a,
+	0x0a, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x44, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x44, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x49, 0x50, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x49, 0x50, 0x12, 0x1a,
+	0x0a, 0x08, 0x67, 0x52, 0x50, 0x43, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x67, 0x52, 0x50, 0x43, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x50, 0x72,
+	0x69, 0x76, 0x61, 0x74, 0x65, 0x49, 0x50, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x50,
+	0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x49, 0x50, 0x12, 0x1a, 0x0a, 0x08, 0x48, 0x74, 0x74, 0x70,
+	0x50, 0x6f, 0x72, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x48, 0x74, 0x74, 0x70,
+	0x50, 0x6f, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07,
+	0x56, 0x50, 0x4e, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x56,
+	0x50, 0x4e, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x4b, 0x65, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x50, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x4b, 0x65, 0x79, 0x12, 0x30, 0x0a, 0x13, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65,
+	0x6e, 0x74, 0x4b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x13, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x4b, 0x65, 0x65,
+	0x70, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x44, 0x4e, 0x53, 0x18, 0x0c, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x03, 0x44, 0x4e, 0x53, 0x42, 0x21, 0x5a, 0x1f, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4e, 0x65, 0x74, 0x53, 0x65, 0x70, 0x69, 0x6f, 0x2f,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x3b, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -703,22 +1254,28 @@ func file_model_proto_rawDescGZIP() []byte {
 	return file_model_proto_rawDescData
 }
 
-var file_model_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_model_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_model_proto_goTypes = []interface{}{
-	(*Response)(nil), // 0: model.Response
-	(*Client)(nil),   // 1: model.Client
-	(*Server)(nil),   // 2: model.Server
-	(*Status)(nil),   // 3: model.Status
+	(*Response)(nil),    // 0: model.Response
+	(*Client)(nil),      // 1: model.Client
+	(*Server)(nil),      // 2: model.Server
+	(*KeyRotation)(nil), // 3: model.KeyRotation
+	(*Pool)(nil),        // 4: model.Pool
+	(*PoolUsage)(nil),   // 5: model.PoolUsage
+	(*Status)(nil),      // 6: model.Status
 }
 var file_model_proto_depIdxs = []int32{
 	1, // 0: model.Response.client:type_name -> model.Client
 	2, // 1: model.Response.server:type_name -> model.Server
 	1, // 2: model.Response.clients:type_name -> model.Client
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	3, // 3: model.Client.KeyHistory:type_name -> model.KeyRotation
+	4, // 4: model.Server.Pools:type_name -> model.Pool
+	3, // 5: model.Server.KeyHistory:type_name -> model.KeyRotation
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_model_proto_init() }
@@ -764,6 +1321,42 @@ func file_model_proto_init() {
 			}
 		}
 		file_model_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyRotation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_model_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Pool); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_model_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PoolUsage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_model_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Status); i {
 			case 0:
 				return &v.state
@@ -782,7 +1375,7 @@ func file_model_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_model_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
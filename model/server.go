@@ -2,11 +2,13 @@ package model
 
 import (
 	"fmt"
+	"net/netip"
 
 	"github.com/NetSepio/nexus/util"
+	"github.com/NetSepio/nexus/util/pkg/ipam"
 
 	"golang.zx2c4.com/wireguard/wgctrl"
-	// "golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 )
 
 // Server structure
@@ -52,6 +54,29 @@ func NewServer(wg *wgctrl.Client, deviceName string) (*WireGuardServer, error) {
 	return &WireGuardServer{wg: wg, deviceName: deviceName}, nil
 }
 
+// Device retrieves the current state of the WireGuard interface, including its peers.
+func (wgServer *WireGuardServer) Device() (*wgtypes.Device, error) {
+	dev, err := wgServer.wg.Device(wgServer.deviceName)
+	if err != nil {
+		return nil, fmt.Errorf("could not get WireGuard device: %w", err)
+	}
+	return dev, nil
+}
+
+// Configure applies a partial configuration to the running WireGuard interface,
+// peers that are not part of the configuration keep their sessions.
+func (wgServer *WireGuardServer) Configure(cfg wgtypes.Config) error {
+	if err := wgServer.wg.ConfigureDevice(wgServer.deviceName, cfg); err != nil {
+		return fmt.Errorf("could not configure WireGuard device: %w", err)
+	}
+	return nil
+}
+
+// Close releases the underlying WireGuard client.
+func (wgServer *WireGuardServer) Close() error {
+	return wgServer.wg.Close()
+}
+
 // ListPeers retrieves information about all Peers known to the current
 // WireGuard interface, including allowed IP addresses and usage stats,
 // optionally with pagination.
@@ -144,6 +169,83 @@ func (a Server) IsValid() []error {
 			errs = append(errs, fmt.Errorf("allowedIP %s is invalid", allowedIP))
 		}
 	}
+	// check if the pools are valid
+	errs = append(errs, validatePools(a.Address, a.Pools)...)
+
+	return errs
+}
+
+// validatePools checks that pools have unique names and sit inside the server networks without
+// overlapping each other
+func validatePools(networks []string, pools []*Pool) []error {
+	errs := make([]error, 0)
+
+	parents := make([]netip.Prefix, 0, len(networks))
+	for _, network := range networks {
+		if prefix, err := netip.ParsePrefix(network); err == nil {
+			parents = append(parents, prefix.Masked())
+		}
+	}
+
+	names := make(map[string]bool, len(pools))
+	seen := make([]netip.Prefix, 0)
+	for _, pool := range pools {
+		if pool.Name == "" {
+			errs = append(errs, fmt.Errorf("pool name is required"))
+		} else if names[pool.Name] {
+			errs = append(errs, fmt.Errorf("pool %s is defined twice", pool.Name))
+		}
+		names[pool.Name] = true
+
+		if len(pool.Address) == 0 {
+			errs = append(errs, fmt.Errorf("pool %s address is required", pool.Name))
+		}
+		prefixes := make([]netip.Prefix, 0, len(pool.Address))
+		for _, address := range pool.Address {
+			prefix, err := netip.ParsePrefix(address)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("pool %s address %s is invalid", pool.Name, address))
+				continue
+			}
+			prefix = prefix.Masked()
+
+			inside := false
+			for _, parent := range parents {
+				if prefix.Bits() >= parent.Bits() && parent.Contains(prefix.Addr()) {
+					inside = true
+					break
+				}
+			}
+			if !inside {
+				errs = append(errs, fmt.Errorf("pool %s address %s is not inside the server address", pool.Name, address))
+			}
+			for _, other := range seen {
+				if prefix.Overlaps(other) {
+					errs = append(errs, fmt.Errorf("pool %s address %s overlaps %s", pool.Name, address, other))
+				}
+			}
+			seen = append(seen, prefix)
+			prefixes = append(prefixes, prefix)
+		}
+
+		for _, exclude := range pool.Exclude {
+			r, err := ipam.ParseRange(exclude)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("pool %s exclude %s is invalid", pool.Name, exclude))
+				continue
+			}
+			inside := false
+			for _, prefix := range prefixes {
+				if prefix.Contains(r.First) && prefix.Contains(r.Last) {
+					inside = true
+					break
+				}
+			}
+			if !inside {
+				errs = append(errs, fmt.Errorf("pool %s exclude %s is not inside the pool address", pool.Name, exclude))
+			}
+		}
+	}
 
 	return errs
 }
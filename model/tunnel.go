@@ -9,6 +9,111 @@ type Service struct { // name app
 	Domain    string `json:"domain"`
 	Status    string `json:"status,omitempty"`
 	CreatedAt string `json:"createdAt"`
+	// Owner is the wallet that added the service, empty for the services of agents
+	Owner string `json:"owner,omitempty"`
+	// Paths are the path prefixes routed to the service, every path when empty
+	Paths []string `json:"paths,omitempty"`
+	// Upstreams as host:port, IpAddress:Port when empty, websocket upgrades are proxied to them too
+	Upstreams []string `json:"upstreams,omitempty"`
+	// LoadBalancing across the upstreams, random, round_robin, least_conn, first or ip_hash
+	LoadBalancing string              `json:"loadBalancing,omitempty"`
+	HealthCheck   *ServiceHealthCheck `json:"healthCheck,omitempty"`
+	Headers       *ServiceHeaders     `json:"headers,omitempty"`
+	Auth          *ServiceAuth        `json:"auth,omitempty"`
+	TLS           *ServiceTLS         `json:"tls,omitempty"`
+	RateLimit     *ServiceRateLimit   `json:"rateLimit,omitempty"`
+	// DNSRecord is the record the node created for Domain, the only one it deletes with the service
+	DNSRecord *ServiceRecord `json:"dnsRecord,omitempty"`
+	// Health is the latest result of the health checker, it is not stored
+	Health *ServiceHealth `json:"health,omitempty"`
+}
+
+// ServiceRecord is a DNS record pointing the domain of a service at the node
+type ServiceRecord struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	TTL   int    `json:"ttl"`
+}
+
+// ServiceHealthCheck is the active health check of the upstreams of a service
+type ServiceHealthCheck struct {
+	Path string `json:"path"`
+	// Interval and Timeout as durations such as 30s
+	Interval     string `json:"interval,omitempty"`
+	Timeout      string `json:"timeout,omitempty"`
+	ExpectStatus int    `json:"expectStatus,omitempty"`
+}
+
+// ServiceHeaders are set on the proxied requests and responses, an empty value removes the header
+type ServiceHeaders struct {
+	Request  map[string]string `json:"request,omitempty"`
+	Response map[string]string `json:"response,omitempty"`
+}
+
+// ServiceAuth protects a service with basic auth or a PASETO token of the listed wallets
+type ServiceAuth struct {
+	// Type is basic or wallet
+	Type     string `json:"type"`
+	Username string `json:"username,omitempty"`
+	// Password is stored as a bcrypt hash
+	Password string `json:"password,omitempty"`
+	// Wallets allowed to the service, any wallet with a valid token when empty
+	Wallets []string `json:"wallets,omitempty"`
+}
+
+// ServiceTLS selects the issuer of the certificate of a service
+type ServiceTLS struct {
+	Email string `json:"email,omitempty"`
+	// Issuer is acme or internal
+	Issuer string `json:"issuer,omitempty"`
+	// CA is the ACME directory, Let's Encrypt when empty
+	CA string `json:"ca,omitempty"`
+}
+
+// ServiceRateLimit allows Requests per Window from each client address
+type ServiceRateLimit struct {
+	Requests int    `json:"requests"`
+	Window   string `json:"window"`
+}
+
+// ServiceHealth is the state of the upstreams of a service seen by the health checker
+type ServiceHealth struct {
+	// Status is active when every upstream is up, degraded when some are and inactive when none is,
+	// unknown until the first check
+	Status string `json:"status"`
+	// Check is http for services with a health check, tcp otherwise
+	Check       string `json:"check"`
+	LastChecked string `json:"lastChecked"`
+	// Since is when the service entered its status
+	Since string `json:"since"`
+	// LatencyMs of the slowest upstream up
+	LatencyMs   int64              `json:"latencyMs"`
+	Upstreams   []UpstreamHealth   `json:"upstreams"`
+	History     []HealthSample     `json:"history,omitempty"`
+	Transitions []HealthTransition `json:"transitions,omitempty"`
+}
+
+// UpstreamHealth is the result of the last check of an upstream
+type UpstreamHealth struct {
+	Address   string `json:"address"`
+	Up        bool   `json:"up"`
+	LatencyMs int64  `json:"latencyMs"`
+	Error     string `json:"error,omitempty"`
+}
+
+// HealthSample is the status and latency of a service at one check
+type HealthSample struct {
+	Time      string `json:"time"`
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latencyMs"`
+}
+
+// HealthTransition is a change of the status of a service
+type HealthTransition struct {
+	Time string `json:"time"`
+	From string `json:"from"`
+	To   string `json:"to"`
 }
 
 // type name services
@@ -12,6 +12,7 @@ import (
 	"github.com/NetSepio/nexus/util/pkg/node"
 	"github.com/docker/docker/pkg/namesgenerator"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/multiformats/go-multiaddr"
 	"github.com/sirupsen/logrus"
 )
@@ -47,6 +48,16 @@ func Init() {
 	fullAddr := getHostAddress(ha)
 	log.Printf("I am %s\n", fullAddr)
 
+	// publish the peers joining the node on the event bus
+	ha.Network().Notify(&network.NotifyBundle{
+		ConnectedF: func(_ network.Network, conn network.Conn) {
+			core.Publish(core.Event{Type: core.EventPeerJoined, Data: map[string]string{
+				"peerId": conn.RemotePeer().String(),
+				"addr":   conn.RemoteMultiaddr().String(),
+			}})
+		},
+	})
+
 	remoteAddr := "/ip4/" + os.Getenv("HOST_IP") + "/tcp/" + os.Getenv("LIBP2P_PORT") + "/p2p/" + ha.ID().String()
 	// Create a new PubSub service using the GossipSub router.
 	ps, err := pubsub.NewGossipSub(ctx, ha)
@@ -88,6 +99,21 @@ func Init() {
 			panic(err)
 		}
 	}()
+	// announce the server key and every rotation of it
+	core.OnServerKeyChange(func(a core.ServerKeyAnnouncement) {
+		msgBytes, err := json.Marshal(a)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"err": err,
+			}).Error("failed to encode server key announcement")
+			return
+		}
+		if err := topic.Publish(ctx, msgBytes); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"err": err,
+			}).Error("failed to announce server key")
+		}
+	})
 	//Subscribe to the topic.
 	sub, err := topic.Subscribe()
 	if err != nil {
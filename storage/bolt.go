@@ -0,0 +1,205 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/NetSepio/nexus/model"
+	log "github.com/sirupsen/logrus"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	bucketServer   = []byte("server")
+	bucketClients  = []byte("clients")
+	bucketServices = []byte("services")
+	bucketAgents   = []byte("agents")
+	// bucketQuarantine keeps records that could not be decoded
+	bucketQuarantine = []byte("quarantine")
+
+	keyServer   = []byte("server")
+	keyServices = []byte("services")
+	keyAgents   = []byte("agents")
+)
+
+// BoltStore keeps every record in a single transactional bbolt database
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// NewBoltStore opens the bbolt database at path, defaults to WG_CONF_DIR/erebrus.db
+func NewBoltStore(path string) (*BoltStore, error) {
+	if path == "" {
+		path = filepath.Join(os.Getenv("WG_CONF_DIR"), "erebrus.db")
+	}
+
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, b := range [][]byte{bucketServer, bucketClients, bucketServices, bucketAgents, bucketQuarantine} {
+			if _, err := tx.CreateBucketIfNotExists(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+func (s *BoltStore) get(bucket, key []byte, v interface{}) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(bucket).Get(key)
+		if data == nil {
+			return ErrNotFound
+		}
+		return json.Unmarshal(data, v)
+	})
+}
+
+func (s *BoltStore) put(bucket, key []byte, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).Put(key, data)
+	})
+}
+
+// ReadServer returns the server record
+func (s *BoltStore) ReadServer() (*model.Server, error) {
+	var server *model.Server
+	if err := s.get(bucketServer, keyServer, &server); err != nil {
+		return nil, err
+	}
+	return server, nil
+}
+
+// WriteServer replaces the server record
+func (s *BoltStore) WriteServer(server *model.Server) error {
+	return s.put(bucketServer, keyServer, server)
+}
+
+// ReadClient returns a client by uuid
+func (s *BoltStore) ReadClient(id string) (*model.Client, error) {
+	var client *model.Client
+	if err := s.get(bucketClients, []byte(id), &client); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// ReadClients returns all clients from a single read transaction
+func (s *BoltStore) ReadClients() ([]*model.Client, error) {
+	clients := make([]*model.Client, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketClients).ForEach(func(_, data []byte) error {
+			var c *model.Client
+			if err := json.Unmarshal(data, &c); err != nil {
+				return err
+			}
+			clients = append(clients, c)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return clients, nil
+}
+
+// WriteClient creates or replaces a client keyed on its uuid
+func (s *BoltStore) WriteClient(client *model.Client) error {
+	if client.UUID == "" {
+		return errors.New("client uuid is required")
+	}
+	return s.put(bucketClients, []byte(client.UUID), client)
+}
+
+// DeleteClient removes a client by uuid
+func (s *BoltStore) DeleteClient(id string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketClients)
+		if b.Get([]byte(id)) == nil {
+			return ErrNotFound
+		}
+		return b.Delete([]byte(id))
+	})
+}
+
+// ReadServices returns all services, an empty list if none were written
+func (s *BoltStore) ReadServices() (*model.ServicesList, error) {
+	services := &model.ServicesList{Services: []model.Service{}}
+	if err := s.get(bucketServices, keyServices, services); err != nil && err != ErrNotFound {
+		return nil, err
+	}
+	return services, nil
+}
+
+// WriteServices replaces all services
+func (s *BoltStore) WriteServices(services *model.ServicesList) error {
+	return s.put(bucketServices, keyServices, services)
+}
+
+// ReadAgents returns all agents, an empty list if none were written
+func (s *BoltStore) ReadAgents() ([]model.Agent, error) {
+	agents := []model.Agent{}
+	if err := s.get(bucketAgents, keyAgents, &agents); err != nil && err != ErrNotFound {
+		return nil, err
+	}
+	return agents, nil
+}
+
+// WriteAgents replaces all agents
+func (s *BoltStore) WriteAgents(agents []model.Agent) error {
+	return s.put(bucketAgents, keyAgents, agents)
+}
+
+// Check moves client records that cannot be decoded to the quarantine bucket
+func (s *BoltStore) Check() error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		clients := tx.Bucket(bucketClients)
+		quarantine := tx.Bucket(bucketQuarantine)
+
+		var corrupt [][]byte
+		err := clients.ForEach(func(k, data []byte) error {
+			var c model.Client
+			if json.Unmarshal(data, &c) != nil {
+				corrupt = append(corrupt, k)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, k := range corrupt {
+			if err := quarantine.Put(append([]byte("clients/"), k...), clients.Get(k)); err != nil {
+				return err
+			}
+			if err := clients.Delete(k); err != nil {
+				return err
+			}
+			log.WithFields(log.Fields{
+				"uuid": string(k),
+			}).Warn("quarantined unreadable client")
+		}
+		return nil
+	})
+}
+
+// Close closes the database
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
@@ -2,60 +2,302 @@ package storage
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/NetSepio/nexus/model"
 	"github.com/NetSepio/nexus/util"
+	uuid "github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
 )
 
-// Serialize write interface to disk
-func Serialize(id string, c interface{}) error {
-	b, err := json.MarshalIndent(c, "", "  ")
+// FileStore keeps one json file per client in WG_CLIENTS_DIR, server.json in WG_CONF_DIR,
+// caddy.json in CADDY_CONF_DIR and agents.json in $HOME/erebrus
+type FileStore struct{}
+
+// NewFileStore returns a store using the on-disk json layout
+func NewFileStore() *FileStore {
+	return &FileStore{}
+}
+
+func serverPath() string {
+	return filepath.Join(os.Getenv("WG_CONF_DIR"), "server.json")
+}
+
+func clientPath(id string) string {
+	return filepath.Join(os.Getenv("WG_CLIENTS_DIR"), id)
+}
+
+func servicesPath() string {
+	return filepath.Join(os.Getenv("CADDY_CONF_DIR"), "caddy.json")
+}
+
+func agentsPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, "erebrus", "agents.json"), nil
+}
+
+func readJSON(path string, v interface{}) error {
+	data, err := util.ReadFile(path)
 	if err != nil {
+		if os.IsNotExist(err) {
+			return ErrNotFound
+		}
 		return err
 	}
+	return json.Unmarshal(data, v)
+}
 
-	//If the file is not server.json write in clients directory
-	if id != "server.json" {
-		return util.WriteFile(filepath.Join(os.Getenv("WG_CLIENTS_DIR"), id), b)
+func writeJSON(path string, v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
 	}
+	return util.WriteFile(path, b)
+}
 
-	//If the file is server.json write in wg_conf directory
-	return util.WriteFile(filepath.Join(os.Getenv("WG_CONF_DIR"), id), b)
+// ReadServer reads server.json
+func (s *FileStore) ReadServer() (*model.Server, error) {
+	var server *model.Server
+	if err := readJSON(serverPath(), &server); err != nil {
+		return nil, err
+	}
+	return server, nil
 }
 
-// Deserialize read interface from disk
-func Deserialize(id string) (interface{}, error) {
-	var path string
+// WriteServer writes server.json
+func (s *FileStore) WriteServer(server *model.Server) error {
+	return writeJSON(serverPath(), server)
+}
 
-	//if the id is for client use client directory otherwise use WG_CONF directory
-	if id != "server.json" {
-		path = filepath.Join(os.Getenv("WG_CLIENTS_DIR"), id)
-	} else {
-		path = filepath.Join(os.Getenv("WG_CONF_DIR"), id)
+// readClients parses every client file, the unreadable ones are quarantined
+func readClients() ([]*model.Client, error) {
+	files, err := os.ReadDir(os.Getenv("WG_CLIENTS_DIR"))
+	if err != nil {
+		return nil, err
 	}
 
-	data, err := util.ReadFile(path)
-	if err != nil {
+	clients := make([]*model.Client, 0, len(files))
+	for _, f := range files {
+		// clients file name is an uuid
+		if _, err := uuid.Parse(f.Name()); err != nil {
+			continue
+		}
+		var c *model.Client
+		if err := readJSON(clientPath(f.Name()), &c); err != nil {
+			log.WithFields(log.Fields{
+				"err":  err,
+				"path": f.Name(),
+			}).Error("failed to deserialize client")
+			quarantine(clientPath(f.Name()))
+			continue
+		}
+		clients = append(clients, c)
+	}
+
+	return clients, nil
+}
+
+// ReadClient reads the client file, the files are read on every call so changes written
+// by other processes such as the cli are seen
+func (s *FileStore) ReadClient(id string) (*model.Client, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrNotFound
+	}
+	var client *model.Client
+	if err := readJSON(clientPath(id), &client); err != nil {
 		return nil, err
 	}
+	return client, nil
+}
 
-	if id == "server.json" {
-		var s *model.Server
-		err = json.Unmarshal(data, &s)
-		if err != nil {
-			return nil, err
+// ReadClients reads every client file
+func (s *FileStore) ReadClients() ([]*model.Client, error) {
+	return readClients()
+}
+
+// WriteClient writes the client file
+func (s *FileStore) WriteClient(client *model.Client) error {
+	if _, err := uuid.Parse(client.UUID); err != nil {
+		return errors.New("client uuid is required")
+	}
+	return writeJSON(clientPath(client.UUID), client)
+}
+
+// DeleteClient removes the client file
+func (s *FileStore) DeleteClient(id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return ErrNotFound
+	}
+	if err := os.Remove(clientPath(id)); err != nil {
+		if os.IsNotExist(err) {
+			return ErrNotFound
 		}
-		return s, nil
+		return err
 	}
+	return nil
+}
 
-	// if not the server, must be client
-	var c *model.Client
-	err = json.Unmarshal(data, &c)
+// ReadServices reads caddy.json, creating an empty one if missing
+func (s *FileStore) ReadServices() (*model.ServicesList, error) {
+	if err := os.MkdirAll(os.Getenv("CADDY_CONF_DIR"), 0755); err != nil {
+		return nil, err
+	}
+
+	data, err := util.ReadFile(servicesPath())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	services := &model.ServicesList{Services: []model.Service{}}
+	if len(data) == 0 {
+		return services, nil
+	}
+	if err := json.Unmarshal(data, services); err != nil {
+		return nil, err
+	}
+	return services, nil
+}
+
+// WriteServices writes caddy.json in CADDY_CONF_DIR and mirrors it to $HOME/SERVICE_CONF_DIR
+func (s *FileStore) WriteServices(services *model.ServicesList) error {
+	if err := writeJSON(servicesPath(), services); err != nil {
+		return err
+	}
+
+	homeDir, err := os.UserHomeDir()
 	if err != nil {
+		return err
+	}
+	serviceConfDir := filepath.Join(homeDir, os.Getenv("SERVICE_CONF_DIR"))
+	if err := os.MkdirAll(serviceConfDir, 0755); err != nil {
+		return err
+	}
+	return writeJSON(filepath.Join(serviceConfDir, "caddy.json"), services)
+}
+
+// ReadAgents reads agents.json
+func (s *FileStore) ReadAgents() ([]model.Agent, error) {
+	path, err := agentsPath()
+	if err != nil {
+		return nil, err
+	}
+	agents := []model.Agent{}
+	if err := readJSON(path, &agents); err != nil && err != ErrNotFound {
 		return nil, err
 	}
+	return agents, nil
+}
+
+// WriteAgents writes agents.json
+func (s *FileStore) WriteAgents(agents []model.Agent) error {
+	path, err := agentsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return writeJSON(path, agents)
+}
+
+// Check removes temporary files left by interrupted writes and quarantines json files that
+// cannot be parsed, server.json is never moved since its keys cannot be recovered
+func (s *FileStore) Check() error {
+	for _, dir := range []string{os.Getenv("WG_CONF_DIR"), os.Getenv("WG_CLIENTS_DIR"), os.Getenv("CADDY_CONF_DIR")} {
+		removeTempFiles(dir)
+	}
+	if path, err := agentsPath(); err == nil {
+		removeTempFiles(filepath.Dir(path))
+	}
+
+	var server *model.Server
+	if err := readJSON(serverPath(), &server); err != nil && err != ErrNotFound {
+		return fmt.Errorf("server.json is corrupted, restore it from a backup: %w", err)
+	}
+
+	// parse every client file, quarantining the unreadable ones
+	if _, err := readClients(); err != nil {
+		return err
+	}
+
+	var services model.ServicesList
+	if err := readJSON(servicesPath(), &services); err != nil && err != ErrNotFound && !isEmpty(servicesPath()) {
+		quarantine(servicesPath())
+	}
 
-	return c, nil
+	if path, err := agentsPath(); err == nil {
+		var agents []model.Agent
+		if err := readJSON(path, &agents); err != nil && err != ErrNotFound {
+			quarantine(path)
+		}
+	}
+
+	return nil
+}
+
+func isEmpty(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Size() == 0
+}
+
+// removeTempFiles deletes the temporary files util.WriteFile leaves behind on a crash
+func removeTempFiles(dir string) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, f := range files {
+		if strings.HasPrefix(f.Name(), ".") && strings.Contains(f.Name(), util.TempFileSuffix) {
+			os.Remove(filepath.Join(dir, f.Name()))
+		}
+	}
+}
+
+// quarantine moves an unreadable file next to its original location with a .corrupt suffix
+func quarantine(path string) {
+	target := fmt.Sprintf("%s.corrupt-%d", path, time.Now().Unix())
+	err := os.Rename(path, target)
+	log.WithFields(log.Fields{
+		"err":    err,
+		"path":   path,
+		"target": target,
+	}).Warn("quarantined unreadable file")
+}
+
+// Close is a no-op for the file layout
+func (s *FileStore) Close() error {
+	return nil
+}
+
+// Serialize write interface to the active store, "server.json" is the server and any other id a client
+func Serialize(id string, c interface{}) error {
+	switch v := c.(type) {
+	case *model.Server:
+		return Get().WriteServer(v)
+	case *model.Client:
+		return Get().WriteClient(v)
+	}
+
+	// unknown type, keep the historical behaviour of writing raw json
+	if id != "server.json" {
+		return writeJSON(clientPath(id), c)
+	}
+	return writeJSON(serverPath(), c)
+}
+
+// Deserialize read interface from the active store
+func Deserialize(id string) (interface{}, error) {
+	if id == "server.json" {
+		return Get().ReadServer()
+	}
+
+	// if not the server, must be client
+	return Get().ReadClient(id)
 }
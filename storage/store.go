@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/NetSepio/nexus/model"
+)
+
+// ErrNotFound is returned when a record does not exist in the store
+var ErrNotFound = errors.New("record not found")
+
+// Store persists the node state: wireguard server and clients, reverse-proxy services and agents
+type Store interface {
+	// ReadServer returns the wireguard server, ErrNotFound if it was never written
+	ReadServer() (*model.Server, error)
+	// WriteServer creates or replaces the wireguard server
+	WriteServer(server *model.Server) error
+
+	// ReadClient returns a client by uuid
+	ReadClient(id string) (*model.Client, error)
+	// ReadClients returns all clients, in no particular order
+	ReadClients() ([]*model.Client, error)
+	// WriteClient creates or replaces a client keyed on its uuid
+	WriteClient(client *model.Client) error
+	// DeleteClient removes a client by uuid
+	DeleteClient(id string) error
+
+	// ReadServices returns all reverse-proxy services
+	ReadServices() (*model.ServicesList, error)
+	// WriteServices replaces all reverse-proxy services
+	WriteServices(services *model.ServicesList) error
+
+	// ReadAgents returns all agents
+	ReadAgents() ([]model.Agent, error)
+	// WriteAgents replaces all agents
+	WriteAgents(agents []model.Agent) error
+
+	// Check verifies the stored records at startup and quarantines the unreadable ones
+	Check() error
+
+	// Close releases the resources held by the store
+	Close() error
+}
+
+const (
+	// BackendFile keeps one json file per record, the historical layout
+	BackendFile = "file"
+	// BackendBolt keeps every record in a single bbolt database
+	BackendBolt = "bolt"
+)
+
+var (
+	mu    sync.RWMutex
+	store Store
+)
+
+// Init opens the store selected by STORAGE_BACKEND, defaults to the file layout
+func Init() error {
+	s, err := Open(os.Getenv("STORAGE_BACKEND"))
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if store != nil {
+		store.Close()
+	}
+	store = s
+
+	return nil
+}
+
+// Open returns a new store for the given backend name
+func Open(backend string) (Store, error) {
+	switch strings.ToLower(backend) {
+	case "", BackendFile:
+		return NewFileStore(), nil
+	case BackendBolt:
+		return NewBoltStore(os.Getenv("STORAGE_PATH"))
+	default:
+		return nil, errors.New("unknown storage backend " + backend)
+	}
+}
+
+// Get returns the active store, the file layout is used if Init was never called
+func Get() Store {
+	mu.RLock()
+	s := store
+	mu.RUnlock()
+	if s != nil {
+		return s
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if store == nil {
+		store = NewFileStore()
+	}
+	return store
+}
+
+// Set replaces the active store
+func Set(s Store) {
+	mu.Lock()
+	defer mu.Unlock()
+	store = s
+}
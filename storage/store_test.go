@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NetSepio/nexus/model"
+	uuid "github.com/google/uuid"
+)
+
+// storeDirs points the file layout at a temporary directory
+func storeDirs(t *testing.T) string {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("WG_CONF_DIR", dir)
+	t.Setenv("WG_CLIENTS_DIR", filepath.Join(dir, "clients"))
+	t.Setenv("CADDY_CONF_DIR", filepath.Join(dir, "caddy"))
+	t.Setenv("SERVICE_CONF_DIR", "services")
+	if err := os.MkdirAll(filepath.Join(dir, "clients"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// TestStoreContract runs the same expectations against every backend
+func TestStoreContract(t *testing.T) {
+	for _, backend := range []string{BackendFile, BackendBolt} {
+		t.Run(backend, func(t *testing.T) {
+			storeDirs(t)
+			s, err := Open(backend)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.Close()
+
+			if _, err := s.ReadServer(); !errors.Is(err, ErrNotFound) {
+				t.Errorf("expected ErrNotFound for a missing server, got %v", err)
+			}
+			if err := s.WriteServer(&model.Server{ListenPort: 51820, PublicKey: "pub"}); err != nil {
+				t.Fatal(err)
+			}
+			if server, err := s.ReadServer(); err != nil || server.ListenPort != 51820 || server.PublicKey != "pub" {
+				t.Errorf("unexpected server %v, %v", server, err)
+			}
+
+			id := uuid.NewString()
+			if _, err := s.ReadClient(id); !errors.Is(err, ErrNotFound) {
+				t.Errorf("expected ErrNotFound for a missing client, got %v", err)
+			}
+			for _, name := range []string{"first", "second"} {
+				if err := s.WriteClient(&model.Client{UUID: id, Name: name, Enable: true}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := s.WriteClient(&model.Client{UUID: uuid.NewString(), Name: "other"}); err != nil {
+				t.Fatal(err)
+			}
+			if client, err := s.ReadClient(id); err != nil || client.Name != "second" || !client.Enable {
+				t.Errorf("expected the replaced client, got %v, %v", client, err)
+			}
+			if clients, err := s.ReadClients(); err != nil || len(clients) != 2 {
+				t.Errorf("expected 2 clients, got %d, %v", len(clients), err)
+			}
+			if err := s.DeleteClient(id); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.DeleteClient(id); !errors.Is(err, ErrNotFound) {
+				t.Errorf("expected ErrNotFound deleting twice, got %v", err)
+			}
+			if clients, err := s.ReadClients(); err != nil || len(clients) != 1 {
+				t.Errorf("expected 1 client, got %d, %v", len(clients), err)
+			}
+
+			if services, err := s.ReadServices(); err != nil || len(services.Services) != 0 {
+				t.Errorf("expected no services, got %v, %v", services, err)
+			}
+			if err := s.WriteServices(&model.ServicesList{Services: []model.Service{{Name: "app", Port: "3000"}}}); err != nil {
+				t.Fatal(err)
+			}
+			if services, err := s.ReadServices(); err != nil || len(services.Services) != 1 || services.Services[0].Name != "app" {
+				t.Errorf("unexpected services %v, %v", services, err)
+			}
+
+			if agents, err := s.ReadAgents(); err != nil || len(agents) != 0 {
+				t.Errorf("expected no agents, got %v, %v", agents, err)
+			}
+			if err := s.WriteAgents([]model.Agent{{ID: "a1", Name: "agent"}}); err != nil {
+				t.Fatal(err)
+			}
+			if agents, err := s.ReadAgents(); err != nil || len(agents) != 1 || agents[0].ID != "a1" {
+				t.Errorf("unexpected agents %v, %v", agents, err)
+			}
+
+			if err := s.Check(); err != nil {
+				t.Errorf("check failed: %v", err)
+			}
+		})
+	}
+}
+
+// TestFileStoreSharedWrites checks a store sees the clients written by another process
+func TestFileStoreSharedWrites(t *testing.T) {
+	storeDirs(t)
+	node, cli := NewFileStore(), NewFileStore()
+
+	id := uuid.NewString()
+	if err := node.WriteClient(&model.Client{UUID: id, Name: "before"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := node.ReadClients(); err != nil {
+		t.Fatal(err)
+	}
+	if err := cli.WriteClient(&model.Client{UUID: id, Name: "after"}); err != nil {
+		t.Fatal(err)
+	}
+	if client, err := node.ReadClient(id); err != nil || client.Name != "after" {
+		t.Errorf("expected the client written by the other store, got %v, %v", client, err)
+	}
+	if err := cli.DeleteClient(id); err != nil {
+		t.Fatal(err)
+	}
+	if clients, err := node.ReadClients(); err != nil || len(clients) != 0 {
+		t.Errorf("expected the client deleted by the other store gone, got %v, %v", clients, err)
+	}
+}
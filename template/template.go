@@ -40,7 +40,11 @@ AllowedIPs = {{ StringsJoin .Address ", " }}
 
 	clientTpl = `[Interface]
 Address = {{ StringsJoin .Client.Address ", " }}
-PrivateKey = {{ .Server.PrivateKey }}
+{{ if .Client.PrivateKey -}}
+PrivateKey = {{ .Client.PrivateKey }}
+{{- else -}}
+# PrivateKey = private key of {{ .Client.PublicKey }}, only known to the client
+{{- end }}
 {{ if ne (len .Server.DNS) 0 -}}
 DNS = {{ StringsJoin .Server.DNS ", " }}
 {{- end }}
@@ -48,6 +52,12 @@ DNS = {{ StringsJoin .Server.DNS ", " }}
 MTU = {{.Server.Mtu}}
 {{- end}}
 [Peer]
+{{ if .Server.NextPublicKey -}}
+# PublicKey changes to {{ .Server.NextPublicKey }} on {{ FormatTime .Server.RotateAt }}
+{{ end -}}
+{{ if .Client.NextPresharedKey -}}
+# PresharedKey changes to {{ .Client.NextPresharedKey }} on {{ FormatTime .Client.RotateAt }}
+{{ end -}}
 PublicKey = {{ .Server.PublicKey }}
 PresharedKey = {{ .Client.PresharedKey }}
 AllowedIPs = {{ StringsJoin .Client.AllowedIPs ", " }}
@@ -95,9 +105,10 @@ func dump(tpl *template.Template, data interface{}) ([]byte, error) {
 	return tplBuff.Bytes(), nil
 }
 
-// DumpClientWg dump client wg config with go template
+// DumpClientWg dump client wg config with go template, the private key is only rendered when
+// client still carries the one generated at registration
 func DumpClientWg(client *model.Client, server *model.Server) ([]byte, error) {
-	t, err := template.New("client").Funcs(template.FuncMap{"StringsJoin": strings.Join}).Parse(clientTpl)
+	t, err := template.New("client").Funcs(template.FuncMap{"StringsJoin": strings.Join, "FormatTime": FormatTime}).Parse(clientTpl)
 	if err != nil {
 		return nil, err
 	}
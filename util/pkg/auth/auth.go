@@ -2,6 +2,7 @@ package auth
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
@@ -15,9 +16,55 @@ import (
 var PublicKey gopaseto.V4AsymmetricPublicKey
 var secretKey gopaseto.V4AsymmetricSecretKey
 
-func Init() {
-	secretKey = gopaseto.NewV4AsymmetricSecretKey()
+// trustedKeys are the keys tokens are verified against, the node key comes first
+var trustedKeys []Key
+
+// Init loads the node signing key and the trusted gateway keys, it fails when the signing key
+// cannot be loaded since tokens signed with another key would not survive a restart
+func Init() error {
+	key, err := loadSecretKey()
+	if err != nil {
+		return fmt.Errorf("failed to load the paseto key: %w", err)
+	}
+	secretKey = key
 	PublicKey = secretKey.Public()
+	trustedKeys = []Key{newKey(PublicKey, IssuerNode)}
+
+	// an invalid entry is skipped, the other gateway keys stay trusted
+	gateway, err := gatewayKeys()
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Error("skipping invalid gateway paseto keys")
+	}
+	trustedKeys = append(trustedKeys, gateway...)
+
+	log.WithFields(log.Fields{
+		"kid":     trustedKeys[0].ID,
+		"gateway": len(gateway),
+	}).Info("paseto key loaded")
+	return nil
+}
+
+// Keys returns the public keys tokens are accepted from
+func Keys() []Key {
+	return trustedKeys
+}
+
+// Verify parses a v4.public token signed by any trusted key and checks it is not expired
+func Verify(token string) (*gopaseto.Token, error) {
+	parser := gopaseto.NewParser()
+	parser.AddRule(gopaseto.NotExpired())
+
+	err := errors.New("no paseto key loaded")
+	for _, key := range trustedKeys {
+		var parsed *gopaseto.Token
+		parsed, err = parser.ParseV4Public(key.key, token, nil)
+		if err == nil {
+			return parsed, nil
+		}
+	}
+	return nil, err
 }
 func GenerateTokenPaseto(claim claims.CustomClaims) (string, error) {
 	footer := os.Getenv("FOOTER")
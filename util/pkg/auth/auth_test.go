@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	gopaseto "aidanwoods.dev/go-paseto"
+	"github.com/NetSepio/nexus/util/pkg/claims"
+)
+
+func TestInitKeyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys", "paseto.key")
+	t.Setenv("PASETO_KEY_SOURCE", KeySourceFile)
+	t.Setenv("PASETO_KEY_FILE", path)
+	t.Setenv("GATEWAY_PASETO_PUBLIC_KEYS", "")
+
+	if err := Init(); err != nil {
+		t.Fatal(err)
+	}
+	kid := Keys()[0].ID
+
+	// the generated key is reused on restart
+	if err := Init(); err != nil {
+		t.Fatal(err)
+	}
+	if Keys()[0].ID != kid {
+		t.Errorf("expected the stored key reused, got %s and %s", kid, Keys()[0].ID)
+	}
+
+	if err := os.WriteFile(path, []byte("not a seed\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := Init(); err == nil {
+		t.Error("expected an unreadable key file to fail")
+	}
+}
+
+func TestInitKeySource(t *testing.T) {
+	t.Setenv("GATEWAY_PASETO_PUBLIC_KEYS", "")
+	t.Setenv("PASETO_KEY_SOURCE", "vault")
+	if err := Init(); err == nil {
+		t.Error("expected an unknown key source to fail")
+	}
+
+	t.Setenv("PASETO_KEY_SOURCE", KeySourceMnemonic)
+	t.Setenv("MNEMONIC", "")
+	if err := Init(); err == nil {
+		t.Error("expected a missing mnemonic to fail")
+	}
+
+	t.Setenv("MNEMONIC", "test test test test test test test test test test test junk")
+	if err := Init(); err != nil {
+		t.Fatal(err)
+	}
+	kid := Keys()[0].ID
+	if err := Init(); err != nil || Keys()[0].ID != kid {
+		t.Errorf("expected the mnemonic to derive the same key, got %v", err)
+	}
+}
+
+func TestGatewayKeys(t *testing.T) {
+	first := gopaseto.NewV4AsymmetricSecretKey().Public()
+	second := gopaseto.NewV4AsymmetricSecretKey().Public()
+	paserk := newKey(second, IssuerGateway).Paserk
+
+	t.Setenv("GATEWAY_PASETO_PUBLIC_KEYS", first.ExportHex()+", not-a-key ,"+paserk+",")
+	keys, err := gatewayKeys()
+	if err == nil || !strings.Contains(err.Error(), "not-a-key") {
+		t.Errorf("expected the invalid entry reported, got %v", err)
+	}
+	if len(keys) != 2 || keys[0].ID != KeyID(first) || keys[1].ID != KeyID(second) || keys[1].Issuer != IssuerGateway {
+		t.Errorf("expected the valid keys kept, got %+v", keys)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	gateway := gopaseto.NewV4AsymmetricSecretKey()
+	t.Setenv("PASETO_KEY_SOURCE", KeySourceFile)
+	t.Setenv("PASETO_KEY_FILE", filepath.Join(t.TempDir(), "paseto.key"))
+	t.Setenv("GATEWAY_PASETO_PUBLIC_KEYS", gateway.Public().ExportHex())
+	if err := Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	signed, err := GenerateTokenPaseto(claims.CustomClaims{WalletAddress: "0xabc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Verify(signed); err != nil {
+		t.Errorf("expected a node token accepted, got %v", err)
+	}
+
+	token := gopaseto.NewToken()
+	token.SetExpiration(time.Now().Add(time.Hour))
+	if _, err := Verify(token.V4Sign(gateway, nil)); err != nil {
+		t.Errorf("expected a gateway token accepted, got %v", err)
+	}
+	if _, err := Verify(token.V4Sign(gopaseto.NewV4AsymmetricSecretKey(), nil)); err == nil {
+		t.Error("expected a token of an unknown key rejected")
+	}
+
+	token.SetExpiration(time.Now().Add(-time.Minute))
+	if _, err := Verify(token.V4Sign(gateway, nil)); err == nil {
+		t.Error("expected an expired token rejected")
+	}
+}
@@ -0,0 +1,157 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	gopaseto "aidanwoods.dev/go-paseto"
+	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/hkdf"
+)
+
+// Sources of the node signing key
+const (
+	// KeySourceFile keeps a generated key in PASETO_KEY_FILE
+	KeySourceFile = "file"
+	// KeySourceMnemonic derives the key from MNEMONIC, nodes sharing a mnemonic share the key
+	KeySourceMnemonic = "mnemonic"
+)
+
+// Issuers of the trusted keys
+const (
+	IssuerNode    = "node"
+	IssuerGateway = "gateway"
+)
+
+// Key is a PASETO public key the node accepts tokens from
+type Key struct {
+	// ID is the PASERK k4.pid of the key
+	ID string `json:"kid"`
+	// Version is the PASETO version and purpose, always v4.public
+	Version string `json:"version"`
+	// PublicKey is the hex encoded ed25519 public key
+	PublicKey string `json:"publicKey"`
+	// Paserk is the public key in PASERK k4.public form
+	Paserk string `json:"paserk"`
+	Issuer string `json:"issuer"`
+
+	key gopaseto.V4AsymmetricPublicKey
+}
+
+// newKey returns the trusted key of publicKey
+func newKey(publicKey gopaseto.V4AsymmetricPublicKey, issuer string) Key {
+	paserk := "k4.public." + base64.RawURLEncoding.EncodeToString(publicKey.ExportBytes())
+	return Key{
+		ID:        KeyID(publicKey),
+		Version:   "v4.public",
+		PublicKey: publicKey.ExportHex(),
+		Paserk:    paserk,
+		Issuer:    issuer,
+		key:       publicKey,
+	}
+}
+
+// KeyID returns the PASERK k4.pid identifier of publicKey
+func KeyID(publicKey gopaseto.V4AsymmetricPublicKey) string {
+	const header = "k4.pid."
+	paserk := "k4.public." + base64.RawURLEncoding.EncodeToString(publicKey.ExportBytes())
+
+	h, _ := blake2b.New(33, nil)
+	h.Write([]byte(header))
+	h.Write([]byte(paserk))
+	return header + base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+// ParsePublicKey parses a hex encoded or PASERK k4.public key
+func ParsePublicKey(value string) (gopaseto.V4AsymmetricPublicKey, error) {
+	value = strings.TrimSpace(value)
+	if encoded, ok := strings.CutPrefix(value, "k4.public."); ok {
+		b, err := base64.RawURLEncoding.DecodeString(encoded)
+		if err != nil {
+			return gopaseto.V4AsymmetricPublicKey{}, err
+		}
+		return gopaseto.NewV4AsymmetricPublicKeyFromBytes(b)
+	}
+	return gopaseto.NewV4AsymmetricPublicKeyFromHex(value)
+}
+
+// loadSecretKey returns the node signing key from the source selected by PASETO_KEY_SOURCE
+func loadSecretKey() (gopaseto.V4AsymmetricSecretKey, error) {
+	switch strings.ToLower(os.Getenv("PASETO_KEY_SOURCE")) {
+	case "", KeySourceFile:
+		return loadKeyFile(keyFilePath())
+	case KeySourceMnemonic:
+		return deriveKey(os.Getenv("MNEMONIC"))
+	default:
+		return gopaseto.V4AsymmetricSecretKey{}, fmt.Errorf("unknown PASETO_KEY_SOURCE %s", os.Getenv("PASETO_KEY_SOURCE"))
+	}
+}
+
+// keyFilePath returns PASETO_KEY_FILE, defaults to $WG_CONF_DIR/paseto.key
+func keyFilePath() string {
+	if path := os.Getenv("PASETO_KEY_FILE"); path != "" {
+		return path
+	}
+	return filepath.Join(os.Getenv("WG_CONF_DIR"), "paseto.key")
+}
+
+// loadKeyFile reads the hex seed stored at path, a new key is generated and stored when the file
+// does not exist
+func loadKeyFile(path string) (gopaseto.V4AsymmetricSecretKey, error) {
+	b, err := os.ReadFile(path)
+	if err == nil {
+		return gopaseto.NewV4AsymmetricSecretKeyFromSeed(strings.TrimSpace(string(b)))
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return gopaseto.V4AsymmetricSecretKey{}, err
+	}
+
+	key := gopaseto.NewV4AsymmetricSecretKey()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return gopaseto.V4AsymmetricSecretKey{}, err
+	}
+	if err := os.WriteFile(path, []byte(key.ExportSeedHex()+"\n"), 0600); err != nil {
+		return gopaseto.V4AsymmetricSecretKey{}, err
+	}
+	return key, nil
+}
+
+// deriveKey derives the signing key from the BIP-39 seed of mnemonic
+func deriveKey(mnemonic string) (gopaseto.V4AsymmetricSecretKey, error) {
+	if mnemonic == "" {
+		return gopaseto.V4AsymmetricSecretKey{}, errors.New("MNEMONIC is required to derive the PASETO key")
+	}
+	seed := bip39.NewSeed(mnemonic, "")
+
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, seed, nil, []byte("erebrus paseto v4.public")), key); err != nil {
+		return gopaseto.V4AsymmetricSecretKey{}, err
+	}
+	return gopaseto.NewV4AsymmetricSecretKeyFromSeed(fmt.Sprintf("%x", key))
+}
+
+// gatewayKeys parses GATEWAY_PASETO_PUBLIC_KEYS, a comma separated list of hex or PASERK keys.
+// It returns the valid keys along with an error naming every invalid entry.
+func gatewayKeys() ([]Key, error) {
+	keys := make([]Key, 0)
+	errs := make([]error, 0)
+	for _, value := range strings.Split(os.Getenv("GATEWAY_PASETO_PUBLIC_KEYS"), ",") {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		publicKey, err := ParsePublicKey(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid gateway key %s: %w", strings.TrimSpace(value), err))
+			continue
+		}
+		keys = append(keys, newKey(publicKey, IssuerGateway))
+	}
+	return keys, errors.Join(errs...)
+}
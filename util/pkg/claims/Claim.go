@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/NetSepio/nexus/util/pkg/policy"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -13,6 +14,9 @@ type CustomClaims struct {
 	WalletAddress string    `json:"walletAddress"`
 	SignedBy      string    `json:"signedBy"`
 	Expiration    time.Time `json:"expiryTime"`
+	// Roles and Scopes granted to the wallet, see util/pkg/policy
+	Roles  []string `json:"roles,omitempty"`
+	Scopes []string `json:"scopes,omitempty"`
 }
 
 func (c CustomClaims) Valid() error {
@@ -37,9 +41,12 @@ func New(walletAddress string) CustomClaims {
 	pasetoExpirationHours := pasetoExpirationInHoursInt * time.Hour
 	expiration := time.Now().Add(pasetoExpirationHours)
 	signedBy := os.Getenv("SIGNED_BY")
+	roles := []string{policy.RoleFor(walletAddress)}
 	return CustomClaims{
-		walletAddress,
-		signedBy,
-		expiration,
+		WalletAddress: walletAddress,
+		SignedBy:      signedBy,
+		Expiration:    expiration,
+		Roles:         roles,
+		Scopes:        policy.ScopesFor(roles),
 	}
 }
@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/NetSepio/nexus/api/v1/authenticate/challengeid"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -35,12 +34,13 @@ func CheckSign(signature string, flowId string, message string) (string, bool, e
 	//Get address from public key
 	walletAddress := crypto.PubkeyToAddress(*pubKey)
 
-	localData, exists := challengeid.Data[flowId]
-	if !exists {
+	// expired challenges are never returned by the store
+	localData, err := challengeid.Consume(flowId)
+	if errors.Is(err, challengeid.ErrChallengeNotFound) {
 		return "", false, ErrFlowIdNotFound
 	}
-	if time.Since(localData.Timestamp) > 1*time.Hour {
-		return "", false, errors.New("challenge id expired for the request")
+	if err != nil {
+		return "", false, err
 	}
 	if strings.EqualFold(localData.WalletAddress, walletAddress.String()) {
 		return localData.WalletAddress, true, nil
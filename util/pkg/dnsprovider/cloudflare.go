@@ -0,0 +1,142 @@
+package dnsprovider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// defaultCloudflareURL is the Cloudflare API used when URL is empty
+const defaultCloudflareURL = "https://api.cloudflare.com/client/v4"
+
+// Cloudflare manages the records of a zone through the Cloudflare API, or any API
+// following its dns_records endpoints
+type Cloudflare struct {
+	URL    string
+	ZoneID string
+	// Token is an API token allowed to edit the dns records of the zone
+	Token string
+	// Proxied routes the records through the Cloudflare proxy
+	Proxied bool
+	Client  *http.Client
+}
+
+// cloudflareRecord is a dns record of the API
+type cloudflareRecord struct {
+	ID      string `json:"id,omitempty"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Content string `json:"content"`
+	TTL     int    `json:"ttl"`
+	Proxied bool   `json:"proxied"`
+}
+
+// cloudflareResponse is the envelope of the API responses
+type cloudflareResponse struct {
+	Success bool `json:"success"`
+	Errors  []struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"errors"`
+	Result json.RawMessage `json:"result"`
+}
+
+// Create adds record when its name has no A, AAAA or CNAME records yet
+func (cf *Cloudflare) Create(record Record) error {
+	existing, err := cf.list(record.Name)
+	if err != nil {
+		return err
+	}
+	for _, r := range existing {
+		if addressType(r.Type) {
+			return fmt.Errorf("cloudflare: %w: %s %s", ErrRecordExists, r.Type, r.Name)
+		}
+	}
+
+	body := cloudflareRecord{Type: record.Type, Name: record.Name, Content: record.Value, TTL: record.TTL, Proxied: cf.Proxied}
+	return cf.do(http.MethodPost, "/dns_records", body, nil)
+}
+
+// Delete removes the records of the name of record with its type and value
+func (cf *Cloudflare) Delete(record Record) error {
+	existing, err := cf.list(record.Name)
+	if err != nil {
+		return err
+	}
+	for _, r := range existing {
+		if r.Type != record.Type || !strings.EqualFold(strings.TrimSuffix(r.Content, "."), strings.TrimSuffix(record.Value, ".")) {
+			continue
+		}
+		if err := cf.do(http.MethodDelete, "/dns_records/"+r.ID, nil, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addressType reports whether records of recordType point a name at a host
+func addressType(recordType string) bool {
+	return recordType == "A" || recordType == "AAAA" || recordType == "CNAME"
+}
+
+// list returns the records of name
+func (cf *Cloudflare) list(name string) ([]cloudflareRecord, error) {
+	var records []cloudflareRecord
+	if err := cf.do(http.MethodGet, "/dns_records?"+url.Values{"name": {name}}.Encode(), nil, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// do calls an endpoint of the zone and decodes the result of the response into result
+func (cf *Cloudflare) do(method string, path string, body any, result any) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	base := cf.URL
+	if base == "" {
+		base = defaultCloudflareURL
+	}
+	req, err := http.NewRequest(method, strings.TrimSuffix(base, "/")+"/zones/"+url.PathEscape(cf.ZoneID)+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+cf.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := cf.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("cloudflare: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var envelope cloudflareResponse
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("cloudflare: %s %s: %s", method, path, resp.Status)
+	}
+	if !envelope.Success || resp.StatusCode >= 300 {
+		if len(envelope.Errors) > 0 {
+			return fmt.Errorf("cloudflare: %s %s: %d %s", method, path, envelope.Errors[0].Code, envelope.Errors[0].Message)
+		}
+		return fmt.Errorf("cloudflare: %s %s: %s", method, path, resp.Status)
+	}
+	if result != nil {
+		return json.Unmarshal(envelope.Result, result)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package dnsprovider
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Record a single DNS record, Name is a domain and Value an address or a host name
+type Record struct {
+	Name  string
+	Type  string
+	Value string
+	TTL   int
+}
+
+// ErrRecordExists is returned when a name already has A, AAAA or CNAME records, the node never
+// takes over records it did not create
+var ErrRecordExists = errors.New("name already has dns records")
+
+// DNSProvider creates and deletes the records of the domains served by the node
+type DNSProvider interface {
+	// Create adds record, ErrRecordExists when its name already has A, AAAA or CNAME records
+	Create(record Record) error
+	// Delete removes record, the other records of its name are kept
+	Delete(record Record) error
+}
+
+// defaultTTL is the ttl of the records when DNS_TTL is unset
+const defaultTTL = 300
+
+// Manager points the domains of a zone at the node through a provider
+type Manager struct {
+	Provider DNSProvider
+	// Zone the domains of the records must be under, such as example.com
+	Zone string
+	// Target is the address or host name the records point at
+	Target string
+	TTL    int
+}
+
+// FromEnv returns the manager configured by DNS_PROVIDER, nil when it is unset and
+// the domains are expected to be covered by a wildcard record
+func FromEnv() (*Manager, error) {
+	var provider DNSProvider
+	switch os.Getenv("DNS_PROVIDER") {
+	case "":
+		return nil, nil
+	case "rfc2136":
+		if os.Getenv("DNS_SERVER") == "" {
+			return nil, fmt.Errorf("DNS_SERVER environment variable is not set")
+		}
+		provider = &RFC2136{
+			Server:        os.Getenv("DNS_SERVER"),
+			Zone:          os.Getenv("DNS_ZONE"),
+			TSIGKey:       os.Getenv("DNS_TSIG_KEY"),
+			TSIGSecret:    os.Getenv("DNS_TSIG_SECRET"),
+			TSIGAlgorithm: os.Getenv("DNS_TSIG_ALGORITHM"),
+		}
+	case "cloudflare":
+		if os.Getenv("CLOUDFLARE_ZONE_ID") == "" || os.Getenv("CLOUDFLARE_API_TOKEN") == "" {
+			return nil, fmt.Errorf("CLOUDFLARE_ZONE_ID and CLOUDFLARE_API_TOKEN environment variables are required")
+		}
+		provider = &Cloudflare{
+			URL:     os.Getenv("CLOUDFLARE_API_URL"),
+			ZoneID:  os.Getenv("CLOUDFLARE_ZONE_ID"),
+			Token:   os.Getenv("CLOUDFLARE_API_TOKEN"),
+			Proxied: os.Getenv("CLOUDFLARE_PROXIED") == "true",
+		}
+	default:
+		return nil, fmt.Errorf("unknown DNS_PROVIDER %q, it must be rfc2136 or cloudflare", os.Getenv("DNS_PROVIDER"))
+	}
+
+	zone := strings.Trim(strings.ToLower(os.Getenv("DNS_ZONE")), ".")
+	if zone == "" {
+		return nil, fmt.Errorf("DNS_ZONE environment variable is not set")
+	}
+	target := os.Getenv("DNS_TARGET")
+	if target == "" {
+		target = os.Getenv("HOST_IP")
+	}
+	if target == "" {
+		return nil, fmt.Errorf("DNS_TARGET or HOST_IP environment variable is required")
+	}
+	ttl, err := strconv.Atoi(os.Getenv("DNS_TTL"))
+	if err != nil || ttl <= 0 {
+		ttl = defaultTTL
+	}
+
+	return &Manager{Provider: provider, Zone: zone, Target: target, TTL: ttl}, nil
+}
+
+// Manages reports whether domain is under the zone of the manager
+func (m *Manager) Manages(domain string) bool {
+	domain = strings.Trim(strings.ToLower(domain), ".")
+	return strings.HasSuffix(domain, "."+m.Zone)
+}
+
+// Create points domain at the target and returns the record created, nil for domains outside
+// the zone
+func (m *Manager) Create(domain string) (*Record, error) {
+	if !m.Manages(domain) {
+		return nil, nil
+	}
+	record := m.record(domain)
+	if err := m.Provider.Create(record); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Delete removes a record returned by Create
+func (m *Manager) Delete(record Record) error {
+	return m.Provider.Delete(record)
+}
+
+// record returns the record of domain, an A or AAAA record when the target is an
+// address and a CNAME otherwise
+func (m *Manager) record(domain string) Record {
+	record := Record{Name: strings.Trim(strings.ToLower(domain), "."), Type: "CNAME", Value: m.Target, TTL: m.TTL}
+	if ip := net.ParseIP(m.Target); ip != nil {
+		record.Type = "A"
+		if ip.To4() == nil {
+			record.Type = "AAAA"
+		}
+	}
+	return record
+}
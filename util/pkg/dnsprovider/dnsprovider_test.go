@@ -0,0 +1,164 @@
+package dnsprovider
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/NetSepio/nexus/util/pkg/dnsprovider/dnstest"
+)
+
+func TestRFC2136(t *testing.T) {
+	secret := "c2VjcmV0LXNlY3JldC1zZWNyZXQ="
+	server, err := dnstest.NewServer("example.com", map[string]string{"nexus.": secret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	m := &Manager{
+		Provider: &RFC2136{Server: server.Addr, Zone: "example.com", TSIGKey: "nexus", TSIGSecret: secret},
+		Zone:     "example.com",
+		Target:   "203.0.113.7",
+		TTL:      60,
+	}
+	record, err := m.Create("app1.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := server.Lookup("app1.example.com", "A"); !slices.Equal(got, []string{"203.0.113.7"}) || record.Type != "A" {
+		t.Fatalf("expected an A record, got %v", got)
+	}
+
+	// names with records are never taken over, whoever created them
+	m.Target = "node.example.net"
+	if _, err := m.Create("app1.example.com"); !errors.Is(err, ErrRecordExists) {
+		t.Fatalf("expected ErrRecordExists, got %v", err)
+	}
+	if err := server.Add("docs.example.com. 300 IN CNAME pages.example.org.", "docs.example.com. 300 IN TXT \"v=spf1 -all\""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Create("docs.example.com"); !errors.Is(err, ErrRecordExists) {
+		t.Fatalf("expected ErrRecordExists, got %v", err)
+	}
+	if got := server.Lookup("docs.example.com", "CNAME"); !slices.Equal(got, []string{"pages.example.org."}) {
+		t.Fatalf("expected the CNAME of docs kept, got %v", got)
+	}
+
+	// only the record created is deleted
+	if err := server.Add("app1.example.com. 300 IN A 198.51.100.1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Delete(*record); err != nil {
+		t.Fatal(err)
+	}
+	if got := server.Lookup("app1.example.com", "A"); !slices.Equal(got, []string{"198.51.100.1"}) {
+		t.Fatalf("expected the other A record kept, got %v", got)
+	}
+
+	// domains outside the zone are left alone
+	if record, err := m.Create("app1.other.org"); err != nil || record != nil {
+		t.Fatalf("expected no record, got %v %v", record, err)
+	}
+
+	unsigned := &RFC2136{Server: server.Addr, Zone: "example.com"}
+	if err := unsigned.Create(Record{Name: "app2.example.com", Type: "A", Value: "203.0.113.7", TTL: 60}); err == nil {
+		t.Error("expected the unsigned update to be refused")
+	}
+}
+
+// fakeCloudflare keeps the records of a zone like the dns_records endpoints of the API
+type fakeCloudflare struct {
+	mu      sync.Mutex
+	records map[string]cloudflareRecord
+	next    int
+}
+
+func (f *fakeCloudflare) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	reply := func(status int, result any) {
+		body, _ := json.Marshal(result)
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(cloudflareResponse{Success: status < 300, Result: body})
+	}
+	if r.Header.Get("Authorization") != "Bearer token" {
+		reply(http.StatusForbidden, nil)
+		return
+	}
+
+	id := r.URL.Path[len("/zones/zone/dns_records"):]
+	var record cloudflareRecord
+	switch r.Method {
+	case http.MethodGet:
+		matches := []cloudflareRecord{}
+		for _, rec := range f.records {
+			if rec.Name == r.URL.Query().Get("name") {
+				matches = append(matches, rec)
+			}
+		}
+		reply(http.StatusOK, matches)
+	case http.MethodPost, http.MethodPut:
+		json.NewDecoder(r.Body).Decode(&record)
+		if r.Method == http.MethodPost {
+			f.next++
+			record.ID = strconv.Itoa(f.next)
+		} else {
+			record.ID = id[1:]
+		}
+		f.records[record.ID] = record
+		reply(http.StatusOK, record)
+	case http.MethodDelete:
+		delete(f.records, id[1:])
+		reply(http.StatusOK, nil)
+	}
+}
+
+func TestCloudflare(t *testing.T) {
+	fake := &fakeCloudflare{records: map[string]cloudflareRecord{
+		"mx":   {ID: "mx", Type: "MX", Name: "app1.example.com", Content: "mail.example.com"},
+		"shop": {ID: "shop", Type: "A", Name: "shop.example.com", Content: "198.51.100.1"},
+	}}
+	api := httptest.NewServer(fake)
+	defer api.Close()
+
+	m := &Manager{Provider: &Cloudflare{URL: api.URL, ZoneID: "zone", Token: "token"}, Zone: "example.com", Target: "2001:db8::7", TTL: 60}
+	record, err := m.Create("app1.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.records) != 3 || fake.records["1"].Type != "AAAA" || fake.records["1"].Content != "2001:db8::7" {
+		t.Fatalf("expected an AAAA record, got %v", fake.records)
+	}
+
+	// names with address records are never taken over
+	m.Target = "203.0.113.7"
+	for _, name := range []string{"app1.example.com", "shop.example.com"} {
+		if _, err := m.Create(name); !errors.Is(err, ErrRecordExists) {
+			t.Errorf("%s: expected ErrRecordExists, got %v", name, err)
+		}
+	}
+	if fake.records["shop"].Content != "198.51.100.1" {
+		t.Fatalf("expected the record of shop kept, got %v", fake.records)
+	}
+
+	// only the record created is deleted
+	fake.records["other"] = cloudflareRecord{ID: "other", Type: "AAAA", Name: "app1.example.com", Content: "2001:db8::8"}
+	if err := m.Delete(*record); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := fake.records["1"]; ok || len(fake.records) != 3 {
+		t.Fatalf("expected only the created record deleted, got %v", fake.records)
+	}
+
+	unauthorized := &Cloudflare{URL: api.URL, ZoneID: "zone", Token: "wrong"}
+	if err := unauthorized.Delete(Record{Name: "app1.example.com"}); err == nil {
+		t.Error("expected the request to be refused")
+	}
+}
@@ -0,0 +1,171 @@
+// Package dnstest provides a local DNS server applying RFC 2136 dynamic updates, for tests
+package dnstest
+
+import (
+	"net"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/miekg/dns"
+)
+
+// Server is the primary server of a single zone listening on 127.0.0.1, it applies the
+// updates it receives to the records it holds
+type Server struct {
+	Addr string
+	Zone string
+
+	server  *dns.Server
+	mu      sync.Mutex
+	records []dns.RR
+	tsig    bool
+}
+
+// NewServer starts a server of zone, it refuses unsigned updates when tsig holds a key name and secret
+func NewServer(zone string, tsig map[string]string) (*Server, error) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{Addr: pc.LocalAddr().String(), Zone: dns.Fqdn(zone), tsig: len(tsig) > 0}
+	s.server = &dns.Server{
+		PacketConn: pc,
+		Handler:    s,
+		TsigSecret: tsig,
+		MsgAcceptFunc: func(dh dns.Header) dns.MsgAcceptAction {
+			if int(dh.Bits>>11)&0xF == dns.OpcodeUpdate {
+				return dns.MsgAccept
+			}
+			return dns.DefaultMsgAcceptFunc(dh)
+		},
+	}
+
+	started := make(chan struct{})
+	s.server.NotifyStartedFunc = func() { close(started) }
+	go s.server.ActivateAndServe()
+	<-started
+	return s, nil
+}
+
+// Close stops the server
+func (s *Server) Close() error {
+	return s.server.Shutdown()
+}
+
+// Lookup returns the values of the records of name and type, such as the address of an A record
+func (s *Server) Lookup(name string, recordType string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var values []string
+	for _, rr := range s.records {
+		h := rr.Header()
+		if strings.EqualFold(h.Name, dns.Fqdn(name)) && dns.TypeToString[h.Rrtype] == recordType {
+			values = append(values, strings.TrimPrefix(rr.String(), h.String()))
+		}
+	}
+	return values
+}
+
+// ServeDNS applies updates, other messages are not implemented
+func (s *Server) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
+	m := new(dns.Msg)
+	m.SetReply(r)
+
+	switch {
+	case r.Opcode != dns.OpcodeUpdate:
+		m.Rcode = dns.RcodeNotImplemented
+	case s.tsig && (r.IsTsig() == nil || w.TsigStatus() != nil):
+		m.Rcode = dns.RcodeRefused
+	case len(r.Question) != 1 || !strings.EqualFold(r.Question[0].Name, s.Zone):
+		m.Rcode = dns.RcodeNotZone
+	default:
+		m.Rcode = s.apply(r.Answer, r.Ns)
+	}
+
+	if t := r.IsTsig(); t != nil && w.TsigStatus() == nil {
+		m.SetTsig(t.Hdr.Name, t.Algorithm, 300, int64(t.TimeSigned))
+	}
+	w.WriteMsg(m)
+}
+
+// Add adds records given in zone file format, such as "docs.example.com. 300 IN A 198.51.100.1"
+func (s *Server) Add(records ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, record := range records {
+		rr, err := dns.NewRR(record)
+		if err != nil {
+			return err
+		}
+		s.records = append(s.records, rr)
+	}
+	return nil
+}
+
+// apply applies the updates when the prerequisites hold and returns the rcode of the answer
+func (s *Server) apply(prerequisites []dns.RR, updates []dns.RR) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if rcode := s.check(prerequisites); rcode != dns.RcodeSuccess {
+		return rcode
+	}
+	s.update(updates)
+	return dns.RcodeSuccess
+}
+
+// check returns the rcode of the first prerequisite that does not hold, see RFC 2136 section 3.2.5
+func (s *Server) check(prerequisites []dns.RR) int {
+	for _, p := range prerequisites {
+		h := p.Header()
+		exists := slices.ContainsFunc(s.records, func(rr dns.RR) bool {
+			return strings.EqualFold(rr.Header().Name, h.Name) && (h.Rrtype == dns.TypeANY || rr.Header().Rrtype == h.Rrtype)
+		})
+		switch {
+		case h.Class == dns.ClassANY && h.Rrtype == dns.TypeANY && !exists:
+			return dns.RcodeNameError
+		case h.Class == dns.ClassANY && !exists:
+			return dns.RcodeNXRrset
+		case h.Class == dns.ClassNONE && h.Rrtype == dns.TypeANY && exists:
+			return dns.RcodeYXDomain
+		case h.Class == dns.ClassNONE && h.Rrtype != dns.TypeANY && exists:
+			return dns.RcodeYXRrset
+		}
+	}
+	return dns.RcodeSuccess
+}
+
+// update applies the update section of a message, see RFC 2136 section 3.4.2
+func (s *Server) update(updates []dns.RR) {
+	for _, u := range updates {
+		h := u.Header()
+		switch h.Class {
+		case dns.ClassANY:
+			s.records = remove(s.records, func(rr dns.RR) bool {
+				return strings.EqualFold(rr.Header().Name, h.Name) && (h.Rrtype == dns.TypeANY || rr.Header().Rrtype == h.Rrtype)
+			})
+		case dns.ClassNONE:
+			s.records = remove(s.records, func(rr dns.RR) bool {
+				r := dns.Copy(rr)
+				r.Header().Class, r.Header().Ttl = dns.ClassNONE, 0
+				return dns.IsDuplicate(r, u)
+			})
+		default:
+			s.records = append(s.records, dns.Copy(u))
+		}
+	}
+}
+
+func remove(records []dns.RR, match func(dns.RR) bool) []dns.RR {
+	kept := records[:0]
+	for _, rr := range records {
+		if !match(rr) {
+			kept = append(kept, rr)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,100 @@
+package dnsprovider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// RFC2136 updates the zone on its primary server with dynamic updates, signed with
+// TSIG when a key is set
+type RFC2136 struct {
+	// Server is the host:port of the primary server of the zone
+	Server string
+	Zone   string
+	// TSIGKey is the name of the key, TSIGAlgorithm defaults to hmac-sha256
+	TSIGKey       string
+	TSIGSecret    string
+	TSIGAlgorithm string
+	Timeout       time.Duration
+}
+
+// Create adds record in one update, on the condition that its name has no A, AAAA or CNAME records
+func (r *RFC2136) Create(record Record) error {
+	rr, err := r.rr(record)
+	if err != nil {
+		return err
+	}
+
+	m := new(dns.Msg)
+	m.SetUpdate(dns.Fqdn(r.Zone))
+	// prerequisites of RFC 2136 section 2.4.3, the server answers YXRRSET when one of them exists
+	for _, t := range []uint16{dns.TypeA, dns.TypeAAAA, dns.TypeCNAME} {
+		m.RRsetNotUsed([]dns.RR{&dns.ANY{Hdr: dns.RR_Header{Name: dns.Fqdn(record.Name), Rrtype: t}}})
+	}
+	m.Insert([]dns.RR{rr})
+	return r.exchange(m)
+}
+
+// Delete removes record, the other records of its name are kept
+func (r *RFC2136) Delete(record Record) error {
+	rr, err := r.rr(record)
+	if err != nil {
+		return err
+	}
+
+	m := new(dns.Msg)
+	m.SetUpdate(dns.Fqdn(r.Zone))
+	m.Remove([]dns.RR{rr})
+	return r.exchange(m)
+}
+
+// rr returns the resource record of record
+func (r *RFC2136) rr(record Record) (dns.RR, error) {
+	rr, err := dns.NewRR(fmt.Sprintf("%s %d IN %s %s", dns.Fqdn(record.Name), record.TTL, record.Type, dnsValue(record)))
+	if err != nil {
+		return nil, fmt.Errorf("rfc2136: %w", err)
+	}
+	return rr, nil
+}
+
+// exchange sends the update, over tcp when the answer is truncated
+func (r *RFC2136) exchange(m *dns.Msg) error {
+	c := &dns.Client{Timeout: r.Timeout}
+	if c.Timeout == 0 {
+		c.Timeout = 10 * time.Second
+	}
+	if r.TSIGKey != "" {
+		algorithm := r.TSIGAlgorithm
+		if algorithm == "" {
+			algorithm = dns.HmacSHA256
+		}
+		c.TsigSecret = map[string]string{dns.Fqdn(r.TSIGKey): r.TSIGSecret}
+		m.SetTsig(dns.Fqdn(r.TSIGKey), dns.Fqdn(algorithm), 300, time.Now().Unix())
+	}
+
+	resp, _, err := c.Exchange(m, r.Server)
+	if err == nil && resp.Truncated {
+		c.Net = "tcp"
+		resp, _, err = c.Exchange(m, r.Server)
+	}
+	if err != nil {
+		return fmt.Errorf("rfc2136: %w", err)
+	}
+	if resp.Rcode == dns.RcodeYXRrset {
+		return fmt.Errorf("rfc2136: %w", ErrRecordExists)
+	}
+	if resp.Rcode != dns.RcodeSuccess {
+		return fmt.Errorf("rfc2136: update of %s refused: %s", r.Zone, dns.RcodeToString[resp.Rcode])
+	}
+	return nil
+}
+
+// dnsValue returns the rdata of record, host names of CNAME records are made absolute
+func dnsValue(record Record) string {
+	if record.Type == "CNAME" {
+		return dns.Fqdn(record.Value)
+	}
+	return record.Value
+}
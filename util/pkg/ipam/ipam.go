@@ -0,0 +1,312 @@
+package ipam
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/netip"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrExhausted is returned when every address of the prefix is in use
+	ErrExhausted = errors.New("no more available address in prefix")
+	// ErrInUse is returned when reserving an address that is already taken
+	ErrInUse = errors.New("address already in use")
+	// ErrOutOfRange is returned for addresses outside of the usable range of the prefix
+	ErrOutOfRange = errors.New("address out of range")
+)
+
+// Range is an inclusive range of addresses
+type Range struct {
+	First, Last netip.Addr
+}
+
+// String returns the range as a single address or first-last
+func (r Range) String() string {
+	if r.First == r.Last {
+		return r.First.String()
+	}
+	return r.First.String() + "-" + r.Last.String()
+}
+
+// ParseRange parses a single address, a cidr or a first-last range
+func ParseRange(s string) (Range, error) {
+	if first, last, ok := strings.Cut(s, "-"); ok {
+		from, err := netip.ParseAddr(strings.TrimSpace(first))
+		if err != nil {
+			return Range{}, err
+		}
+		to, err := netip.ParseAddr(strings.TrimSpace(last))
+		if err != nil {
+			return Range{}, err
+		}
+		from, to = from.Unmap(), to.Unmap()
+		if from.BitLen() != to.BitLen() || from.Compare(to) > 0 {
+			return Range{}, fmt.Errorf("invalid range %s", s)
+		}
+		return Range{First: from, Last: to}, nil
+	}
+
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return Range{}, err
+		}
+		return PrefixRange(prefix), nil
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return Range{}, err
+	}
+	return Range{First: addr.Unmap(), Last: addr.Unmap()}, nil
+}
+
+// PrefixRange returns every address of prefix as a range
+func PrefixRange(prefix netip.Prefix) Range {
+	prefix = netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()).Masked()
+	return Range{First: prefix.Addr(), Last: lastAddr(prefix)}
+}
+
+// size returns the number of addresses in the range
+func (r Range) size() *big.Int {
+	n := new(big.Int).Sub(new(big.Int).SetBytes(r.Last.AsSlice()), new(big.Int).SetBytes(r.First.AsSlice()))
+	return n.Add(n, big.NewInt(1))
+}
+
+// Allocator hands out the addresses of a single prefix. Used addresses are kept as a sorted
+// set of merged intervals so a /64 costs as much as a /24, the lowest free address is always
+// right before the first interval or right after it.
+type Allocator struct {
+	prefix      netip.Prefix
+	first, last netip.Addr
+	used        []Range
+}
+
+// New returns an allocator for cidr, the address part of cidr does not need to be the
+// network address. The network and broadcast addresses of IPv4 prefixes and the
+// subnet-router anycast address of IPv6 prefixes are never handed out.
+func New(cidr string) (*Allocator, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return nil, err
+	}
+	prefix = netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()).Masked()
+
+	first := prefix.Addr()
+	last := lastAddr(prefix)
+	if prefix.Addr().Is4() {
+		if prefix.Bits() < 31 {
+			first = first.Next()
+			last = last.Prev()
+		}
+	} else if prefix.Bits() < 127 {
+		first = first.Next()
+	}
+
+	return &Allocator{prefix: prefix, first: first, last: last}, nil
+}
+
+// lastAddr returns the highest address of the prefix
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	b := prefix.Addr().AsSlice()
+	for i := prefix.Bits(); i < len(b)*8; i++ {
+		b[i/8] |= 0x80 >> (i % 8)
+	}
+	addr, _ := netip.AddrFromSlice(b)
+	return addr
+}
+
+// Prefix returns the masked prefix of the allocator
+func (a *Allocator) Prefix() netip.Prefix {
+	return a.prefix
+}
+
+// Contains reports whether addr can be handed out by the allocator
+func (a *Allocator) Contains(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return a.prefix.Contains(addr) && addr.Compare(a.first) >= 0 && addr.Compare(a.last) <= 0
+}
+
+// search returns the index of the first interval ending at or after addr
+func (a *Allocator) search(addr netip.Addr) int {
+	return sort.Search(len(a.used), func(i int) bool {
+		return a.used[i].Last.Compare(addr) >= 0
+	})
+}
+
+// InUse reports whether addr is taken
+func (a *Allocator) InUse(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	i := a.search(addr)
+	return i < len(a.used) && a.used[i].First.Compare(addr) <= 0
+}
+
+// Reserve marks addr as used, it fails if addr is already taken or outside of the prefix
+func (a *Allocator) Reserve(addr netip.Addr) error {
+	addr = addr.Unmap()
+	if !a.Contains(addr) {
+		return fmt.Errorf("%w: %s not in %s", ErrOutOfRange, addr, a.prefix)
+	}
+
+	i := a.search(addr)
+	if i < len(a.used) && a.used[i].First.Compare(addr) <= 0 {
+		return fmt.Errorf("%w: %s", ErrInUse, addr)
+	}
+
+	// merge with the neighbouring intervals when adjacent
+	joinPrev := i > 0 && a.used[i-1].Last.Next() == addr
+	joinNext := i < len(a.used) && a.used[i].First.Prev() == addr
+	switch {
+	case joinPrev && joinNext:
+		a.used[i-1].Last = a.used[i].Last
+		a.used = append(a.used[:i], a.used[i+1:]...)
+	case joinPrev:
+		a.used[i-1].Last = addr
+	case joinNext:
+		a.used[i].First = addr
+	default:
+		a.used = append(a.used, Range{})
+		copy(a.used[i+1:], a.used[i:])
+		a.used[i] = Range{First: addr, Last: addr}
+	}
+
+	return nil
+}
+
+// ReserveRange marks every address of r inside the usable range as used, addresses already
+// taken are not an error
+func (a *Allocator) ReserveRange(r Range) {
+	r.First, r.Last = r.First.Unmap(), r.Last.Unmap()
+	if r.First.BitLen() != a.first.BitLen() || r.Last.Compare(a.first) < 0 || r.First.Compare(a.last) > 0 {
+		return
+	}
+	if r.First.Compare(a.first) < 0 {
+		r.First = a.first
+	}
+	if r.Last.Compare(a.last) > 0 {
+		r.Last = a.last
+	}
+
+	// absorb every interval overlapping or adjacent to r
+	i := sort.Search(len(a.used), func(i int) bool {
+		next := a.used[i].Last.Next()
+		return !next.IsValid() || next.Compare(r.First) >= 0
+	})
+	j := i
+	for ; j < len(a.used); j++ {
+		prev := a.used[j].First.Prev()
+		if prev.IsValid() && prev.Compare(r.Last) > 0 {
+			break
+		}
+		if a.used[j].First.Compare(r.First) < 0 {
+			r.First = a.used[j].First
+		}
+		if a.used[j].Last.Compare(r.Last) > 0 {
+			r.Last = a.used[j].Last
+		}
+	}
+
+	a.used = append(a.used[:i], append([]Range{r}, a.used[j:]...)...)
+}
+
+// Sub returns an allocator restricted to prefix sharing none of its state, addresses already
+// used in a stay used in the new allocator
+func (a *Allocator) Sub(prefix netip.Prefix) (*Allocator, error) {
+	prefix = netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()).Masked()
+	if prefix.Bits() < a.prefix.Bits() || !a.prefix.Contains(prefix.Addr()) {
+		return nil, fmt.Errorf("%w: %s not in %s", ErrOutOfRange, prefix, a.prefix)
+	}
+
+	sub := &Allocator{prefix: prefix, first: prefix.Addr(), last: lastAddr(prefix)}
+	if sub.first.Compare(a.first) < 0 {
+		sub.first = a.first
+	}
+	if sub.last.Compare(a.last) > 0 {
+		sub.last = a.last
+	}
+	if sub.first.Compare(sub.last) > 0 {
+		return nil, fmt.Errorf("%w: %s has no usable address", ErrOutOfRange, prefix)
+	}
+
+	for _, r := range a.used {
+		sub.ReserveRange(r)
+	}
+	return sub, nil
+}
+
+// Size returns the number of addresses the allocator can hand out
+func (a *Allocator) Size() *big.Int {
+	return Range{First: a.first, Last: a.last}.size()
+}
+
+// Used returns the number of addresses in use
+func (a *Allocator) Used() *big.Int {
+	used := new(big.Int)
+	for _, r := range a.used {
+		used.Add(used, r.size())
+	}
+	return used
+}
+
+// FreeRanges returns up to limit ranges of free addresses in ascending order, all of them when
+// limit is zero
+func (a *Allocator) FreeRanges(limit int) []Range {
+	free := make([]Range, 0)
+	next := a.first
+	for _, r := range a.used {
+		if limit > 0 && len(free) == limit {
+			return free
+		}
+		if r.First.Compare(next) > 0 {
+			free = append(free, Range{First: next, Last: r.First.Prev()})
+		}
+		next = r.Last.Next()
+		if !next.IsValid() {
+			return free
+		}
+	}
+	if (limit == 0 || len(free) < limit) && next.Compare(a.last) <= 0 {
+		free = append(free, Range{First: next, Last: a.last})
+	}
+	return free
+}
+
+// Allocate reserves and returns the lowest free address
+func (a *Allocator) Allocate() (netip.Addr, error) {
+	addr := a.first
+	if len(a.used) > 0 && a.used[0].First == a.first {
+		addr = a.used[0].Last.Next()
+	}
+	if !addr.IsValid() || !a.Contains(addr) {
+		return netip.Addr{}, ErrExhausted
+	}
+
+	return addr, a.Reserve(addr)
+}
+
+// Release frees addr, releasing a free address is a no-op
+func (a *Allocator) Release(addr netip.Addr) {
+	addr = addr.Unmap()
+	i := a.search(addr)
+	if i == len(a.used) || a.used[i].First.Compare(addr) > 0 {
+		return
+	}
+
+	s := a.used[i]
+	switch {
+	case s.First == addr && s.Last == addr:
+		a.used = append(a.used[:i], a.used[i+1:]...)
+	case s.First == addr:
+		a.used[i].First = addr.Next()
+	case s.Last == addr:
+		a.used[i].Last = addr.Prev()
+	default:
+		a.used = append(a.used, Range{})
+		copy(a.used[i+2:], a.used[i+1:])
+		a.used[i] = Range{First: s.First, Last: addr.Prev()}
+		a.used[i+1] = Range{First: addr.Next(), Last: s.Last}
+	}
+}
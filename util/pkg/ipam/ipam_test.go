@@ -0,0 +1,191 @@
+package ipam
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+)
+
+func mustAllocator(t *testing.T, cidr string) *Allocator {
+	a, err := New(cidr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func ranges(rs []Range) []string {
+	out := make([]string, 0, len(rs))
+	for _, r := range rs {
+		out = append(out, r.String())
+	}
+	return out
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestNew(t *testing.T) {
+	for _, tc := range []struct {
+		cidr        string
+		first, last string
+		size        string
+	}{
+		{"10.0.0.5/24", "10.0.0.1", "10.0.0.254", "254"},
+		{"10.0.0.0/31", "10.0.0.0", "10.0.0.1", "2"},
+		{"10.0.0.1/32", "10.0.0.1", "10.0.0.1", "1"},
+		{"fd9f::/120", "fd9f::1", "fd9f::ff", "255"},
+		{"fd9f::/64", "fd9f::1", "fd9f::ffff:ffff:ffff:ffff", "18446744073709551615"},
+	} {
+		a := mustAllocator(t, tc.cidr)
+		if a.first.String() != tc.first || a.last.String() != tc.last || a.Size().String() != tc.size {
+			t.Errorf("%s: expected %s-%s of size %s, got %s-%s of size %s", tc.cidr, tc.first, tc.last, tc.size, a.first, a.last, a.Size())
+		}
+	}
+
+	if _, err := New("10.0.0.0/33"); err == nil {
+		t.Error("expected an invalid cidr to fail")
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	a := mustAllocator(t, "10.0.0.0/29")
+	if err := a.Reserve(netip.MustParseAddr("10.0.0.2")); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for {
+		addr, err := a.Allocate()
+		if errors.Is(err, ErrExhausted) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, addr.String())
+	}
+	if want := []string{"10.0.0.1", "10.0.0.3", "10.0.0.4", "10.0.0.5", "10.0.0.6"}; !equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if a.Used().String() != "6" || len(a.FreeRanges(0)) != 0 {
+		t.Errorf("expected the prefix full, got %s used and %v free", a.Used(), ranges(a.FreeRanges(0)))
+	}
+
+	// the lowest released address is handed out first
+	a.Release(netip.MustParseAddr("10.0.0.4"))
+	a.Release(netip.MustParseAddr("10.0.0.3"))
+	a.Release(netip.MustParseAddr("10.0.0.3"))
+	if addr, err := a.Allocate(); err != nil || addr.String() != "10.0.0.3" {
+		t.Errorf("expected 10.0.0.3, got %s, %v", addr, err)
+	}
+	if free := ranges(a.FreeRanges(0)); !equal(free, []string{"10.0.0.4"}) {
+		t.Errorf("expected 10.0.0.4 free, got %v", free)
+	}
+}
+
+func TestReserve(t *testing.T) {
+	a := mustAllocator(t, "10.0.0.0/24")
+	for _, tc := range []struct {
+		addr string
+		err  error
+	}{
+		{"10.0.0.10", nil},
+		{"10.0.0.10", ErrInUse},
+		{"10.0.0.0", ErrOutOfRange},
+		{"10.0.0.255", ErrOutOfRange},
+		{"10.0.1.1", ErrOutOfRange},
+		{"::ffff:10.0.0.11", nil},
+	} {
+		if err := a.Reserve(netip.MustParseAddr(tc.addr)); !errors.Is(err, tc.err) {
+			t.Errorf("%s: expected %v, got %v", tc.addr, tc.err, err)
+		}
+	}
+	if !a.InUse(netip.MustParseAddr("10.0.0.11")) || a.InUse(netip.MustParseAddr("10.0.0.12")) {
+		t.Error("unexpected in use state")
+	}
+	// adjacent addresses are merged into one interval
+	if len(a.used) != 1 || a.used[0].String() != "10.0.0.10-10.0.0.11" {
+		t.Errorf("expected one merged interval, got %v", ranges(a.used))
+	}
+}
+
+func TestReserveRange(t *testing.T) {
+	a := mustAllocator(t, "10.0.0.0/24")
+	for _, addr := range []string{"10.0.0.5", "10.0.0.20", "10.0.0.40"} {
+		if err := a.Reserve(netip.MustParseAddr(addr)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	a.ReserveRange(Range{First: netip.MustParseAddr("10.0.0.6"), Last: netip.MustParseAddr("10.0.0.25")})
+	a.ReserveRange(PrefixRange(netip.MustParsePrefix("10.0.0.248/29")))
+	a.ReserveRange(PrefixRange(netip.MustParsePrefix("fd9f::/64")))
+	if got, want := ranges(a.used), []string{"10.0.0.5-10.0.0.25", "10.0.0.40", "10.0.0.248-10.0.0.254"}; !equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if a.Used().String() != "29" {
+		t.Errorf("expected 29 used, got %s", a.Used())
+	}
+	if got, want := ranges(a.FreeRanges(2)), []string{"10.0.0.1-10.0.0.4", "10.0.0.26-10.0.0.39"}; !equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestSub(t *testing.T) {
+	a := mustAllocator(t, "10.0.0.0/24")
+	if err := a.Reserve(netip.MustParseAddr("10.0.0.130")); err != nil {
+		t.Fatal(err)
+	}
+
+	sub, err := a.Sub(netip.MustParsePrefix("10.0.0.128/25"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the broadcast address of the network stays out of the pool
+	if sub.Size().String() != "127" || !sub.InUse(netip.MustParseAddr("10.0.0.130")) {
+		t.Errorf("expected 127 addresses with 10.0.0.130 used, got %s and %v", sub.Size(), ranges(sub.used))
+	}
+	if addr, err := sub.Allocate(); err != nil || addr.String() != "10.0.0.128" {
+		t.Errorf("expected 10.0.0.128, got %s, %v", addr, err)
+	}
+	if a.InUse(netip.MustParseAddr("10.0.0.128")) {
+		t.Error("the pool must not share the state of its network")
+	}
+
+	if _, err := a.Sub(netip.MustParsePrefix("10.0.0.0/16")); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("expected a wider prefix to fail, got %v", err)
+	}
+	if _, err := a.Sub(netip.MustParsePrefix("10.0.1.0/25")); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("expected a foreign prefix to fail, got %v", err)
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	for _, tc := range []struct {
+		in, out string
+		ok      bool
+	}{
+		{"10.0.0.1", "10.0.0.1", true},
+		{"10.0.0.1 - 10.0.0.9", "10.0.0.1-10.0.0.9", true},
+		{"10.0.0.8/30", "10.0.0.8-10.0.0.11", true},
+		{"fd9f::1-fd9f::ff", "fd9f::1-fd9f::ff", true},
+		{"10.0.0.9-10.0.0.1", "", false},
+		{"10.0.0.1-fd9f::1", "", false},
+		{"nope", "", false},
+	} {
+		r, err := ParseRange(tc.in)
+		if (err == nil) != tc.ok || (tc.ok && r.String() != tc.out) {
+			t.Errorf("%s: expected %q ok=%v, got %q, %v", tc.in, tc.out, tc.ok, r, err)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package policy
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Scopes carried in PASETO tokens
+const (
+	// ScopeNodeAdmin grants every other scope
+	ScopeNodeAdmin = "node:admin"
+	// ScopeClientsWrite creates and manages the clients of the wallet
+	ScopeClientsWrite = "clients:write"
+	// ScopeServicesWrite creates and manages web services
+	ScopeServicesWrite = "services:write"
+	// ScopeAgentsWrite deploys and manages agents
+	ScopeAgentsWrite = "agents:write"
+	// ScopeReadonly reads every resource of the node, not only the ones of the wallet
+	ScopeReadonly = "readonly"
+)
+
+// Roles assigned to wallets
+const (
+	RoleOperator = "operator"
+	RoleTenant   = "tenant"
+	RoleReadonly = "readonly"
+)
+
+// roleScopes lists the scopes granted by each role
+var roleScopes = map[string][]string{
+	RoleOperator: {ScopeNodeAdmin, ScopeClientsWrite, ScopeServicesWrite, ScopeAgentsWrite, ScopeReadonly},
+	RoleTenant:   {ScopeClientsWrite, ScopeServicesWrite},
+	RoleReadonly: {ScopeReadonly},
+}
+
+// ErrForbidden is returned when a principal lacks the scope of an operation
+var ErrForbidden = errors.New("the token does not grant the scope required for this operation")
+
+// SameWallet compares two wallet addresses, hex addresses are case insensitive
+func SameWallet(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	if strings.HasPrefix(a, "0x") && strings.HasPrefix(b, "0x") {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
+
+// listed reports whether wallet is in the comma separated list of the env variable, * matches
+// every wallet
+func listed(env string, wallet string) bool {
+	for _, entry := range strings.Split(os.Getenv(env), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "*" || SameWallet(entry, wallet) {
+			return true
+		}
+	}
+	return false
+}
+
+// RoleFor returns the role the node grants to wallet. Operators are the gateway wallet and the
+// wallets of OPERATOR_WALLETS, READONLY_WALLETS are read-only and every other wallet is a tenant.
+func RoleFor(wallet string) string {
+	switch {
+	case listed("GATEWAY_WALLET", wallet) || listed("OPERATOR_WALLETS", wallet):
+		return RoleOperator
+	case listed("READONLY_WALLETS", wallet):
+		return RoleReadonly
+	default:
+		return RoleTenant
+	}
+}
+
+// OperatorWallet returns a wallet granted the operator role, the gateway wallet first, empty if
+// none is configured
+func OperatorWallet() string {
+	for _, env := range []string{"GATEWAY_WALLET", "OPERATOR_WALLETS"} {
+		for _, entry := range strings.Split(os.Getenv(env), ",") {
+			if entry = strings.TrimSpace(entry); entry != "" && entry != "*" {
+				return entry
+			}
+		}
+	}
+	return ""
+}
+
+// ScopesFor returns the scopes granted by roles
+func ScopesFor(roles []string) []string {
+	scopes := make([]string, 0)
+	for _, role := range roles {
+		for _, scope := range roleScopes[role] {
+			if !slices.Contains(scopes, scope) {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	return scopes
+}
+
+// Principal is the wallet of a request with the scopes it was granted
+type Principal struct {
+	Wallet string
+	Roles  []string
+	Scopes []string
+}
+
+// New returns the principal of a token. The scopes of the token and of its roles are capped by
+// what the node currently grants the wallet, so removing a wallet from OPERATOR_WALLETS revokes
+// its tokens. Tokens without roles nor scopes get the current role of the wallet.
+func New(wallet string, roles []string, scopes []string) Principal {
+	role := RoleFor(wallet)
+	allowed := ScopesFor([]string{role})
+	if len(roles) == 0 && len(scopes) == 0 {
+		return Principal{Wallet: wallet, Roles: []string{role}, Scopes: allowed}
+	}
+
+	granted := make([]string, 0)
+	for _, scope := range append(ScopesFor(roles), scopes...) {
+		if slices.Contains(allowed, scope) && !slices.Contains(granted, scope) {
+			granted = append(granted, scope)
+		}
+	}
+	roles = slices.DeleteFunc(slices.Clone(roles), func(r string) bool {
+		return r != role && (r == RoleOperator || roleScopes[r] == nil)
+	})
+	if len(roles) == 0 {
+		roles = []string{role}
+	}
+
+	return Principal{Wallet: wallet, Roles: roles, Scopes: granted}
+}
+
+// Has reports whether the principal was granted scope, node:admin grants every scope
+func (p Principal) Has(scope string) bool {
+	return slices.Contains(p.Scopes, scope) || slices.Contains(p.Scopes, ScopeNodeAdmin)
+}
+
+// IsAdmin reports whether the principal administers the node
+func (p Principal) IsAdmin() bool {
+	return p.Has(ScopeNodeAdmin)
+}
+
+// ReadsAll reports whether the principal sees the resources of every wallet
+func (p Principal) ReadsAll() bool {
+	return p.Has(ScopeReadonly)
+}
+
+// Authorize checks that the principal was granted scope, an empty scope only requires a valid
+// token
+func Authorize(p Principal, scope string) error {
+	if scope == "" || p.Has(scope) {
+		return nil
+	}
+	return ErrForbidden
+}
@@ -0,0 +1,253 @@
+package shaper
+
+import (
+	"fmt"
+	"net"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Limit rate limits of a single client tunnel address, rates are in kbit/s and zero is unlimited
+type Limit struct {
+	Address  string
+	Upload   int64
+	Download int64
+}
+
+// Class is the shaping applied to one address: its HTB class id and filter priority, and its rates
+type Class struct {
+	ID       int
+	Upload   int64
+	Download int64
+}
+
+// Command a single tc invocation, errors of cleanup commands are ignored since the
+// qdisc may not exist yet
+type Command struct {
+	Args        []string
+	IgnoreError bool
+}
+
+func (c Command) String() string {
+	return "tc " + strings.Join(c.Args, " ")
+}
+
+// Shaper applies per-client rate limits on a wireguard interface with linux tc:
+// downloads go through an HTB class per client on the root qdisc, uploads are policed
+// on the ingress qdisc. It remembers what it applied so only the changed clients are touched.
+type Shaper struct {
+	Device string
+	// DryRun logs the commands instead of running them
+	DryRun bool
+
+	mu sync.Mutex
+	// applied is the shaping of the device by address, nil until the first Apply
+	applied map[string]Class
+}
+
+// Apply reconciles the shaping of the device with limits, the device is shaped from scratch
+// on the next call when a command fails
+func (s *Shaper) Apply(limits []Limit) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	commands, applied, err := Commands(s.Device, s.applied, limits)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range commands {
+		if s.DryRun {
+			log.WithFields(log.Fields{
+				"device": s.Device,
+			}).Info("dry run: ", c.String())
+			continue
+		}
+
+		output, err := exec.Command("tc", c.Args...).CombinedOutput()
+		if err != nil && !c.IgnoreError {
+			s.applied = nil
+			return fmt.Errorf("%s: %v: %s", c.String(), err, strings.TrimSpace(string(output)))
+		}
+	}
+
+	s.applied = applied
+	return nil
+}
+
+// Commands returns the tc invocations turning the shaping current of device into limits, and the
+// resulting shaping. A nil current is an unknown state: nothing is run without limits, otherwise
+// the qdiscs are removed and built again. Clients whose limits did not change are not touched.
+func Commands(device string, current map[string]Class, limits []Limit) ([]Command, map[string]Class, error) {
+	desired := make(map[string]Class, len(limits))
+	for _, l := range limits {
+		ip, err := parseAddress(l.Address)
+		if err != nil {
+			return nil, nil, err
+		}
+		if l.Upload > 0 || l.Download > 0 {
+			desired[host(ip)] = Class{Upload: l.Upload, Download: l.Download}
+		}
+	}
+
+	var commands []Command
+	if current == nil {
+		if len(desired) == 0 {
+			return nil, nil, nil
+		}
+		commands = append(commands,
+			Command{Args: []string{"qdisc", "del", "dev", device, "root"}, IgnoreError: true},
+			Command{Args: []string{"qdisc", "del", "dev", device, "ingress"}, IgnoreError: true},
+		)
+		current = map[string]Class{}
+	}
+
+	// keep the ids of the shaped addresses, new ones take the lowest free id, 1 is the root
+	used := map[int]bool{}
+	for address, class := range current {
+		if _, ok := desired[address]; ok {
+			desired[address] = Class{ID: class.ID, Upload: desired[address].Upload, Download: desired[address].Download}
+			used[class.ID] = true
+		}
+	}
+	next := 2
+	for _, address := range sortedKeys(desired) {
+		if desired[address].ID != 0 {
+			continue
+		}
+		for used[next] {
+			next++
+		}
+		if next > 0xffff {
+			return nil, nil, fmt.Errorf("too many shaped addresses on %s", device)
+		}
+		class := desired[address]
+		class.ID = next
+		desired[address] = class
+		used[next] = true
+	}
+
+	hadRoot, needRoot := anyRate(current, true), anyRate(desired, true)
+	hadIngress, needIngress := anyRate(current, false), anyRate(desired, false)
+
+	// removals and changed uploads first, a removed qdisc takes its classes and filters along
+	for _, address := range sortedKeys(current) {
+		before, after := current[address], desired[address]
+		protocol := protocolOf(address)
+		prio := fmt.Sprint(before.ID)
+		if before.Download > 0 && after.Download == 0 && needRoot {
+			commands = append(commands,
+				Command{Args: []string{"filter", "del", "dev", device, "parent", "1:", "protocol", protocol, "prio", prio}},
+				Command{Args: []string{"class", "del", "dev", device, "classid", classID(before.ID)}},
+			)
+		}
+		if before.Upload > 0 && after.Upload != before.Upload && needIngress {
+			commands = append(commands, Command{Args: []string{"filter", "del", "dev", device, "parent", "ffff:", "protocol", protocol, "prio", prio}})
+		}
+	}
+	if hadRoot && !needRoot {
+		commands = append(commands, Command{Args: []string{"qdisc", "del", "dev", device, "root"}})
+	}
+	if hadIngress && !needIngress {
+		commands = append(commands, Command{Args: []string{"qdisc", "del", "dev", device, "ingress"}})
+	}
+
+	if !hadRoot && needRoot {
+		// unclassified traffic has no class to go to and is not shaped
+		commands = append(commands, Command{Args: []string{"qdisc", "add", "dev", device, "root", "handle", "1:", "htb"}})
+	}
+	if !hadIngress && needIngress {
+		commands = append(commands, Command{Args: []string{"qdisc", "add", "dev", device, "handle", "ffff:", "ingress"}})
+	}
+
+	for _, address := range sortedKeys(desired) {
+		before, after := current[address], desired[address]
+		protocol, match := protocolOf(address), "ip"
+		if protocol == "ipv6" {
+			match = "ip6"
+		}
+		prio := fmt.Sprint(after.ID)
+
+		if after.Download > 0 && after.Download != before.Download {
+			rate := fmt.Sprintf("%dkbit", after.Download)
+			if before.Download > 0 {
+				commands = append(commands, Command{Args: []string{"class", "change", "dev", device, "parent", "1:", "classid", classID(after.ID), "htb", "rate", rate, "ceil", rate}})
+			} else {
+				commands = append(commands,
+					Command{Args: []string{"class", "add", "dev", device, "parent", "1:", "classid", classID(after.ID), "htb", "rate", rate, "ceil", rate}},
+					Command{Args: []string{"filter", "add", "dev", device, "parent", "1:", "protocol", protocol, "prio", prio, "u32", "match", match, "dst", address, "flowid", classID(after.ID)}},
+				)
+			}
+		}
+
+		if after.Upload > 0 && after.Upload != before.Upload {
+			rate := fmt.Sprintf("%dkbit", after.Upload)
+			commands = append(commands,
+				Command{Args: []string{"filter", "add", "dev", device, "parent", "ffff:", "protocol", protocol, "prio", prio, "u32", "match", match, "src", address, "police", "rate", rate, "burst", burst(after.Upload), "drop", "flowid", ":1"}},
+			)
+		}
+	}
+
+	return commands, desired, nil
+}
+
+// anyRate reports whether a class has a download rate, or an upload rate when download is false
+func anyRate(classes map[string]Class, download bool) bool {
+	for _, class := range classes {
+		if (download && class.Download > 0) || (!download && class.Upload > 0) {
+			return true
+		}
+	}
+	return false
+}
+
+func classID(id int) string {
+	// class ids are hex
+	return fmt.Sprintf("1:%x", id)
+}
+
+func host(ip net.IP) string {
+	if ip.To4() == nil {
+		return ip.String() + "/128"
+	}
+	return ip.String() + "/32"
+}
+
+func protocolOf(host string) string {
+	if strings.Contains(host, ":") {
+		return "ipv6"
+	}
+	return "ip"
+}
+
+func sortedKeys(classes map[string]Class) []string {
+	keys := make([]string, 0, len(classes))
+	for k := range classes {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// burst allows 100ms worth of traffic at rate kbit/s, never less than 16k
+func burst(rate int64) string {
+	kbytes := rate / 8 / 10
+	if kbytes < 16 {
+		kbytes = 16
+	}
+	return fmt.Sprintf("%dk", kbytes)
+}
+
+func parseAddress(address string) (net.IP, error) {
+	if ip, _, err := net.ParseCIDR(address); err == nil {
+		return ip, nil
+	}
+	if ip := net.ParseIP(address); ip != nil {
+		return ip, nil
+	}
+	return nil, fmt.Errorf("address %s is invalid", address)
+}
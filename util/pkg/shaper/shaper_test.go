@@ -0,0 +1,90 @@
+package shaper
+
+import (
+	"strings"
+	"testing"
+)
+
+func commandLines(commands []Command) []string {
+	lines := make([]string, 0, len(commands))
+	for _, c := range commands {
+		lines = append(lines, c.String())
+	}
+	return lines
+}
+
+func TestCommands(t *testing.T) {
+	steps := []struct {
+		name     string
+		limits   []Limit
+		commands []string
+	}{
+		{
+			name:     "no limits",
+			limits:   []Limit{{Address: "10.0.0.2/32"}},
+			commands: []string{},
+		},
+		{
+			name:   "first limits",
+			limits: []Limit{{Address: "10.0.0.2/32", Download: 1000}, {Address: "fd9f::3/128", Upload: 800}},
+			commands: []string{
+				"tc qdisc del dev wg0 root",
+				"tc qdisc del dev wg0 ingress",
+				"tc qdisc add dev wg0 root handle 1: htb",
+				"tc qdisc add dev wg0 handle ffff: ingress",
+				"tc class add dev wg0 parent 1: classid 1:2 htb rate 1000kbit ceil 1000kbit",
+				"tc filter add dev wg0 parent 1: protocol ip prio 2 u32 match ip dst 10.0.0.2/32 flowid 1:2",
+				"tc filter add dev wg0 parent ffff: protocol ipv6 prio 3 u32 match ip6 src fd9f::3/128 police rate 800kbit burst 16k drop flowid :1",
+			},
+		},
+		{
+			name:     "unchanged",
+			limits:   []Limit{{Address: "10.0.0.2/32", Download: 1000}, {Address: "fd9f::3/128", Upload: 800}},
+			commands: []string{},
+		},
+		{
+			name:   "one client changed, another added",
+			limits: []Limit{{Address: "10.0.0.2/32", Download: 2000}, {Address: "fd9f::3/128", Upload: 800}, {Address: "10.0.0.4", Download: 500, Upload: 500}},
+			commands: []string{
+				"tc class change dev wg0 parent 1: classid 1:2 htb rate 2000kbit ceil 2000kbit",
+				"tc class add dev wg0 parent 1: classid 1:4 htb rate 500kbit ceil 500kbit",
+				"tc filter add dev wg0 parent 1: protocol ip prio 4 u32 match ip dst 10.0.0.4/32 flowid 1:4",
+				"tc filter add dev wg0 parent ffff: protocol ip prio 4 u32 match ip src 10.0.0.4/32 police rate 500kbit burst 16k drop flowid :1",
+			},
+		},
+		{
+			name:   "one client removed",
+			limits: []Limit{{Address: "fd9f::3/128", Upload: 800}, {Address: "10.0.0.4", Download: 500, Upload: 500}},
+			commands: []string{
+				"tc filter del dev wg0 parent 1: protocol ip prio 2",
+				"tc class del dev wg0 classid 1:2",
+			},
+		},
+		{
+			name:     "all removed",
+			limits:   nil,
+			commands: []string{"tc qdisc del dev wg0 root", "tc qdisc del dev wg0 ingress"},
+		},
+		{
+			name:     "still no limits",
+			limits:   nil,
+			commands: []string{},
+		},
+	}
+
+	var current map[string]Class
+	for _, step := range steps {
+		commands, next, err := Commands("wg0", current, step.limits)
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if got := commandLines(commands); strings.Join(got, "\n") != strings.Join(step.commands, "\n") {
+			t.Errorf("%s: expected\n%s\ngot\n%s", step.name, strings.Join(step.commands, "\n"), strings.Join(got, "\n"))
+		}
+		current = next
+	}
+
+	if _, _, err := Commands("wg0", nil, []Limit{{Address: "10.0.0.300", Upload: 1}}); err == nil {
+		t.Error("expected an invalid address to fail")
+	}
+}
@@ -1,75 +1,103 @@
 package stats
 
 import (
-	"fmt"
-	"os/exec"
-	"strings"
+	"sync"
+	"time"
+
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 )
 
-func GetWireGuardStats() (map[string]map[string]int64, error) {
-	cmd := exec.Command("wg", "show", "all", "transfer")
+// OnlineTimeout is how recent the last handshake of a peer must be for it to count as online,
+// wireguard renews a session at least every two minutes while traffic flows
+const OnlineTimeout = 3 * time.Minute
 
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute 'sudo wg show all transfer': %v", err)
-	}
+// DeviceReader returns the current state of a wireguard interface
+type DeviceReader interface {
+	Device() (*wgtypes.Device, error)
+}
 
-	stats := make(map[string]map[string]int64)
-	lines := strings.Split(string(output), "\n")
-	for _, line := range lines {
-		fields := strings.Fields(line)
-		if len(fields) >= 4 {
-			peerPublicKey := fields[1]
-			receivedBytes := fields[2]
-			transmittedBytes := fields[3]
-
-			if _, ok := stats[peerPublicKey]; !ok {
-				stats[peerPublicKey] = make(map[string]int64)
-			}
+// PeerStats live statistics of a single wireguard peer
+type PeerStats struct {
+	PublicKey         string
+	Endpoint          string
+	LastHandshake     time.Time
+	KeepaliveInterval time.Duration
+	ReceivedBytes     int64
+	TransmittedBytes  int64
+	// ReceiveRate and TransmitRate are in bytes per second since the previous read
+	ReceiveRate  float64
+	TransmitRate float64
+	Online       bool
+}
 
-			stats[peerPublicKey]["ReceivedBytes"] = parseBytes(receivedBytes)
-			stats[peerPublicKey]["TransmittedBytes"] = parseBytes(transmittedBytes)
-		}
-	}
+// minRateInterval avoids noisy rates when reads happen in quick succession
+const minRateInterval = time.Second
 
-	return stats, nil
+type sample struct {
+	at           time.Time
+	received     int64
+	sent         int64
+	receiveRate  float64
+	transmitRate float64
 }
 
-type WireGuardStats struct {
-	Interface        string
-	PeerPublicKey    string
-	ReceivedBytes    int64
-	TransmittedBytes int64
+// Collector reads every peer of a wireguard interface in one call and derives throughput
+// from the counters of the previous read
+type Collector struct {
+	mu     sync.Mutex
+	reader DeviceReader
+	prev   map[wgtypes.Key]sample
 }
 
-func GetWireGuardStatsForPeer(publicKey string) (*WireGuardStats, error) {
-	cmd := exec.Command("wg", "show", "all", "transfer")
+// NewCollector returns a collector reading from the given device
+func NewCollector(reader DeviceReader) *Collector {
+	return &Collector{reader: reader, prev: make(map[wgtypes.Key]sample)}
+}
 
-	output, err := cmd.CombinedOutput()
+// Read returns the statistics of every peer keyed on its base64 public key
+func (c *Collector) Read() (map[string]*PeerStats, error) {
+	device, err := c.reader.Device()
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute 'sudo wg show all transfer': %v", err)
+		return nil, err
 	}
 
-	lines := strings.Split(string(output), "\n")
-	for _, line := range lines {
-		fields := strings.Fields(line)
-		if len(fields) >= 4 && fields[1] == publicKey {
-			return &WireGuardStats{
-				Interface:        fields[0],
-				PeerPublicKey:    fields[1],
-				ReceivedBytes:    parseBytes(fields[2]),
-				TransmittedBytes: parseBytes(fields[3]),
-			}, nil
-		}
-	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	return nil, fmt.Errorf("stats not found for public key: %s", publicKey)
-}
+	now := time.Now()
+	peers := make(map[string]*PeerStats, len(device.Peers))
+	next := make(map[wgtypes.Key]sample, len(device.Peers))
 
-func parseBytes(bytesStr string) int64 {
-	var unit int64
+	for _, p := range device.Peers {
+		s := &PeerStats{
+			PublicKey:         p.PublicKey.String(),
+			LastHandshake:     p.LastHandshakeTime,
+			KeepaliveInterval: p.PersistentKeepaliveInterval,
+			ReceivedBytes:     p.ReceiveBytes,
+			TransmittedBytes:  p.TransmitBytes,
+			Online:            !p.LastHandshakeTime.IsZero() && now.Sub(p.LastHandshakeTime) < OnlineTimeout,
+		}
+		if p.Endpoint != nil {
+			s.Endpoint = p.Endpoint.String()
+		}
 
-	fmt.Sscanf(bytesStr, "%d", &unit)
+		cur := sample{at: now, received: p.ReceiveBytes, sent: p.TransmitBytes}
+		// counters go back to zero when the interface is restarted, skip the rate then
+		if prev, ok := c.prev[p.PublicKey]; ok && p.ReceiveBytes >= prev.received && p.TransmitBytes >= prev.sent {
+			if elapsed := now.Sub(prev.at); elapsed < minRateInterval {
+				cur = prev
+			} else {
+				cur.receiveRate = float64(p.ReceiveBytes-prev.received) / elapsed.Seconds()
+				cur.transmitRate = float64(p.TransmitBytes-prev.sent) / elapsed.Seconds()
+			}
+		}
+		s.ReceiveRate = cur.receiveRate
+		s.TransmitRate = cur.transmitRate
+
+		next[p.PublicKey] = cur
+		peers[s.PublicKey] = s
+	}
+	c.prev = next
 
-	return unit
+	return peers, nil
 }
@@ -3,18 +3,22 @@ package util
 import (
 	"crypto/rand"
 	"encoding/base64"
-	"errors"
-	"io/ioutil"
 	"net"
+	"net/netip"
 	"os"
+	"path/filepath"
 	"regexp"
 
+	"github.com/NetSepio/nexus/util/pkg/ipam"
 	log "github.com/sirupsen/logrus"
 )
 
 // Erebrus Version
 var Version = "1.0"
 
+// TempFileSuffix marks the temporary files created by WriteFile
+const TempFileSuffix = ".tmp-"
+
 // Hostname
 var hostname, _ = os.Hostname()
 
@@ -53,7 +57,7 @@ func LogError(message string, err error) {
 
 // ReadFile file content
 func ReadFile(path string) (bytes []byte, err error) {
-	bytes, err = ioutil.ReadFile(path)
+	bytes, err = os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
@@ -61,14 +65,47 @@ func ReadFile(path string) (bytes []byte, err error) {
 	return bytes, nil
 }
 
-// WriteFile content to file
+// WriteFile content to file atomically, the content is written and synced to a temporary
+// file in the same directory which is then renamed over path, so a crash never leaves a
+// truncated file behind
 func WriteFile(path string, bytes []byte) (err error) {
-	err = ioutil.WriteFile(path, bytes, 0644)
+	dir := filepath.Dir(path)
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+TempFileSuffix+"*")
 	if err != nil {
 		return err
 	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(bytes); err != nil {
+		return err
+	}
+	if err = tmp.Chmod(0644); err != nil {
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
 
-	return nil
+	// persist the rename itself
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.Sync()
 }
 
 // FileExists check if file exists
@@ -91,30 +128,25 @@ func DirectoryExists(name string) bool {
 
 // GetAvailableIP search for an available ip in cidr against a list of reserved ips
 func GetAvailableIP(cidr string, reserved []string) (string, error) {
-	ip, ipnet, err := net.ParseCIDR(cidr)
+	allocator, err := ipam.New(cidr)
 	if err != nil {
 		return "", err
 	}
 
-	// this two addresses are not usable
-	broadcastAddr := BroadcastAddr(ipnet).String()
-	networkAddr := ipnet.IP.String()
-
-	for ip := ip.Mask(ipnet.Mask); ipnet.Contains(ip); inc(ip) {
-		ok := true
-		address := ip.String()
-		for _, r := range reserved {
-			if address == r {
-				ok = false
-				break
-			}
-		}
-		if ok && address != networkAddr && address != broadcastAddr {
-			return address, nil
+	for _, r := range reserved {
+		addr, err := netip.ParseAddr(r)
+		if err != nil {
+			continue
 		}
+		// reserved ips outside of cidr are irrelevant
+		allocator.Reserve(addr)
 	}
 
-	return "", errors.New("no more available address from cidr")
+	addr, err := allocator.Allocate()
+	if err != nil {
+		return "", err
+	}
+	return addr.String(), nil
 }
 
 // IsIPv6 check if given ip is IPv6
@@ -146,16 +178,6 @@ func GetIPFromCidr(cidr string) (string, error) {
 	return ip.String(), nil
 }
 
-// http://play.golang.org/p/m8TNTtygK0
-func inc(ip net.IP) {
-	for j := len(ip) - 1; j >= 0; j-- {
-		ip[j]++
-		if ip[j] > 0 {
-			break
-		}
-	}
-}
-
 // BroadcastAddr returns the last address in the given network, or the broadcast address.
 func BroadcastAddr(n *net.IPNet) net.IP {
 	// The golang net package doesn't make it easy to calculate the broadcast address. :(
@@ -1,5 +1,10 @@
 #!/bin/sh
-while inotifywait -e modify -e create /etc/wireguard; do
-  wg-quick down wg0
-  wg-quick up wg0
+# Peers are applied live by erebrus through wgctrl. The watcher only brings the interface up
+# when it is down, otherwise it syncs the config without dropping existing sessions.
+while inotifywait -e modify -e create -e moved_to /etc/wireguard; do
+  if wg show wg0 >/dev/null 2>&1; then
+    wg-quick strip wg0 > /tmp/wg0.stripped && wg syncconf wg0 /tmp/wg0.stripped
+  else
+    wg-quick up wg0
+  fi
 done